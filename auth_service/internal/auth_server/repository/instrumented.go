@@ -0,0 +1,80 @@
+package repository
+
+import (
+	authinterfaces "auth_service/internal/auth_interfaces"
+	"auth_service/internal/metrics"
+	"context"
+	globalmodels "global_models"
+	"time"
+)
+
+// обёртки репозиториев, которые учитывают время запросов к PostgreSQL и Redis в метриках (операция - имя метода)
+
+// обёртка репозитория пользователей (PostgreSQL)
+type instrumentedDBRepo struct {
+	next authinterfaces.DBRepoInterface
+}
+
+// конструктор обёртки репозитория пользователей
+func NewInstrumentedDBRepo(next authinterfaces.DBRepoInterface) authinterfaces.DBRepoInterface {
+	return &instrumentedDBRepo{next: next}
+}
+
+func (r *instrumentedDBRepo) CheckIfInBaseByEmail(ctx context.Context, email string) (int64, bool, error) {
+	start := time.Now()
+	id, found, err := r.next.CheckIfInBaseByEmail(ctx, email)
+	metrics.ObservePostgres("check_user", start, err)
+	return id, found, err
+}
+
+func (r *instrumentedDBRepo) AddUser(ctx context.Context, email, hashedPass string) (int64, error) {
+	start := time.Now()
+	id, err := r.next.AddUser(ctx, email, hashedPass)
+	metrics.ObservePostgres("add_user", start, err)
+	return id, err
+}
+
+func (r *instrumentedDBRepo) FindUserByEmail(ctx context.Context, email string) (*globalmodels.User, error) {
+	start := time.Now()
+	user, err := r.next.FindUserByEmail(ctx, email)
+	metrics.ObservePostgres("find_user", start, err)
+	return user, err
+}
+
+func (r *instrumentedDBRepo) AddRefreshToken(ctx context.Context, email, refreshToken, tokenJTI string) error {
+	start := time.Now()
+	err := r.next.AddRefreshToken(ctx, email, refreshToken, tokenJTI)
+	metrics.ObservePostgres("add_refresh_token", start, err)
+	return err
+}
+
+func (r *instrumentedDBRepo) FindTokenHashByEmail(ctx context.Context, email string) (string, string, error) {
+	start := time.Now()
+	tokenHash, tokenJTI, err := r.next.FindTokenHashByEmail(ctx, email)
+	metrics.ObservePostgres("find_token_hash", start, err)
+	return tokenHash, tokenJTI, err
+}
+
+// обёртка репозитория черного списка (Redis)
+type instrumentedBlackListRepo struct {
+	next authinterfaces.BlackListRepository
+}
+
+// конструктор обёртки репозитория черного списка
+func NewInstrumentedBlackListRepo(next authinterfaces.BlackListRepository) authinterfaces.BlackListRepository {
+	return &instrumentedBlackListRepo{next: next}
+}
+
+func (r *instrumentedBlackListRepo) AddToBlacklist(ctx context.Context, tokenJTI, tokenHash, userID string, ttl time.Duration) error {
+	start := time.Now()
+	err := r.next.AddToBlacklist(ctx, tokenJTI, tokenHash, userID, ttl)
+	metrics.ObserveRedis("add_to_blacklist", start, err)
+	return err
+}
+
+func (r *instrumentedBlackListRepo) IsBlacklisted(ctx context.Context, tokenJTI, tokenHash string) (bool, error) {
+	start := time.Now()
+	blacklisted, err := r.next.IsBlacklisted(ctx, tokenJTI, tokenHash)
+	metrics.ObserveRedis("is_blacklisted", start, err)
+	return blacklisted, err
+}
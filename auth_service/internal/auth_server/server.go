@@ -4,10 +4,12 @@ package authserver
 import (
 	"auth_service/internal/auth_server/dto"
 	"auth_service/internal/auth_server/handlers"
+	"auth_service/internal/metrics"
 	"context"
 	"log"
 	"net/http"
 	"shared/config"
+	sharedmetrics "shared/metrics"
 	"shared/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -37,6 +39,10 @@ func NewAuthServer(ctx context.Context, config *config.ServerConfig, handler han
 		c.Next()
 	})
 
+	// метрики HTTP запросов и эндпоинт для Prometheus (регистрируется до CORS: его опрашивает Prometheus напрямую, снаружи он закрыт в nginx)
+	router.Use(sharedmetrics.HTTPMiddleware())
+	router.GET("/metrics", sharedmetrics.Handler())
+
 	router.Use(middleware.CORSMiddleware()) // используем для всех маршруторв работу с CORS
 
 	return &AuthServer{
@@ -50,11 +56,11 @@ func NewAuthServer(ctx context.Context, config *config.ServerConfig, handler han
 func (a *AuthServer) SetUpRoutes() {
 	a.router.GET("/hello", a.Handler.EchoAuthServer) // тестовый ендпоинт
 	a.router.POST("/register", middleware.ValidateAuthMiddleware(&dto.RegisterRequest{}), a.Handler.RegisterHandler)
-	a.router.POST("/login", middleware.ValidateAuthMiddleware(&dto.LoginRequest{}), a.Handler.LoginHandler)
-	a.router.GET("/refresh", a.Handler.ProcessRefreshTokenHandler)
+	a.router.POST("/login", metrics.CountOperation(metrics.OperationLogin), middleware.ValidateAuthMiddleware(&dto.LoginRequest{}), a.Handler.LoginHandler)
+	a.router.GET("/refresh", metrics.CountOperation(metrics.OperationRefresh), a.Handler.ProcessRefreshTokenHandler)
 
 	// Эндпоинт для валидации access токена (используется nginx)
-	a.router.POST("/api/v1/validate", a.Handler.ValidateTokenHandler)
+	a.router.POST("/api/v1/validate", metrics.CountOperation(metrics.OperationValidate), a.Handler.ValidateTokenHandler)
 
 	// зазищённые эндпоинты
 	a.router.GET("/logout", a.Handler.LogoutHandler)
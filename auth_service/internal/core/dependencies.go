@@ -48,8 +48,8 @@ func InitDependencies(ctx context.Context) (*AuthServiceDepenencies, error) {
 		return nil, fmt.Errorf("failed to create PostgreSQL repository: %w", err)
 	}
 
-	// создаём репозиторий для авторизации пользователя
-	userRepo := repository.NewAuthUserRepository(pgPool)
+	// создаём репозиторий для авторизации пользователя (время запросов учитывается в метриках)
+	userRepo := repository.NewInstrumentedDBRepo(repository.NewAuthUserRepository(pgPool))
 
 	// создаём экземпляр redis
 	redisCacherepo, err := redis.NewRedisCacheRepository(conf.RedisConf)
@@ -59,6 +59,11 @@ func InitDependencies(ctx context.Context) (*AuthServiceDepenencies, error) {
 
 	// создаём репозиторий черного списка
 	blackListrepo, err := repository.NewBlackListRepo(redisCacherepo, "auth")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Black List repository: %w", err)
+	}
+	// время запросов к черному списку учитывается в метриках
+	blackListrepo = repository.NewInstrumentedBlackListRepo(blackListrepo)
 
 	// создаём слой репозитория (на базе репозитория Postgres и репозитория токенов (на базе redis))
 	repo, err := repository.NewAuthRepository(userRepo, blackListrepo)
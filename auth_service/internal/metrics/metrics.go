@@ -0,0 +1,83 @@
+// метрики Prometheus сервиса авторизации: результаты входа, обновления и проверки токенов, время запросов к PostgreSQL и Redis
+package metrics
+
+import (
+	sharedmetrics "shared/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "auth"
+
+// операции авторизации (метка operation)
+const (
+	OperationLogin    = "login"
+	OperationRefresh  = "refresh"
+	OperationValidate = "validate"
+)
+
+// результаты операций авторизации (метка result)
+const (
+	ResultSuccess  = sharedmetrics.ResultSuccess
+	ResultRejected = "rejected" // неверные данные или токен (ответ 4xx)
+	ResultError    = sharedmetrics.ResultError
+)
+
+// границы гистограмм времени запросов к хранилищам
+var storageBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+var (
+	// результаты входа, обновления и проверки токенов
+	operations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "operations_total",
+		Help:      "Количество операций входа, обновления и проверки токенов по результатам.",
+	}, []string{"operation", "result"})
+
+	// время запросов к PostgreSQL
+	postgresDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "postgres_query_duration_seconds",
+		Help:      "Время запросов к PostgreSQL по операциям репозитория.",
+		Buckets:   storageBuckets,
+	}, []string{"operation", "result"})
+
+	// время запросов к Redis
+	redisDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "redis_command_duration_seconds",
+		Help:      "Время запросов к Redis по операциям репозитория.",
+		Buckets:   storageBuckets,
+	}, []string{"operation", "result"})
+)
+
+// CountOperation - middleware, которое учитывает результат операции авторизации по статусу ответа
+// ставится первым в цепочке маршрута, чтобы учитывались и отказы валидации запроса
+func CountOperation(operation string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		status := c.Writer.Status()
+		result := ResultSuccess
+		switch {
+		case status >= 500:
+			result = ResultError
+		case status >= 400:
+			result = ResultRejected
+		}
+		operations.WithLabelValues(operation, result).Inc()
+	}
+}
+
+// ObservePostgres - учёт времени запроса к PostgreSQL
+func ObservePostgres(operation string, start time.Time, err error) {
+	postgresDuration.WithLabelValues(operation, sharedmetrics.Result(err)).Observe(time.Since(start).Seconds())
+}
+
+// ObserveRedis - учёт времени запроса к Redis
+func ObserveRedis(operation string, start time.Time, err error) {
+	redisDuration.WithLabelValues(operation, sharedmetrics.Result(err)).Observe(time.Since(start).Seconds())
+}
@@ -18,6 +18,9 @@ type Cache interface {
 	Expire(ctx context.Context, key string, expiration time.Duration) error
 	TTL(ctx context.Context, key string) (time.Duration, error)
 
+	// Счётчики
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) // атомарно увеличивает счётчик на 1, новому счётчику ставит время жизни
+
 	// Управление соединением
 	Close() error
 }
@@ -6,45 +6,109 @@ import (
 
 // структура конфига для инмемори шардированных кэшэй с TTL
 type CachesConfig struct {
-	NumOfShards               int // количество шардов
-	SearchCacheConfig         SearchCacheConfig
-	VacancyCacheConfig        VacancyCacheConfig
-	VacancyDetailsCacheConfig VacancyDetailsCacheConfig
-	MaxMemoryUsageMB          int
+	NumOfShards               int                       `yaml:"num_of_shards"` // количество шардов
+	SearchCacheConfig         SearchCacheConfig         `yaml:"search_cache"`
+	VacancyCacheConfig        VacancyCacheConfig        `yaml:"vacancy_cache"`
+	VacancyDetailsCacheConfig VacancyDetailsCacheConfig `yaml:"vacancy_details_cache"`
+	DictionaryCacheConfig     DictionaryCacheConfig     `yaml:"dictionary_cache"`
+	SearchJobsCacheConfig     SearchJobsCacheConfig     `yaml:"search_jobs_cache"`
+	SnapshotConfig            CacheSnapshotConfig       `yaml:"snapshot"`
+	RedisConfig               CacheRedisConfig          `yaml:"redis"`
+	MaxEntries                int                       `yaml:"max_entries"`         // сколько элементов может хранить каждый инмэмори кэш (0 - без ограничения)
+	MaxMemoryUsageMB          int                       `yaml:"max_memory_usage_mb"` // примерный объём памяти каждого инмэмори кэша в мегабайтах (0 - без ограничения)
 }
 
+// где хранятся элементы кэша
+const (
+	CacheBackendMemory = "memory" // в памяти реплики (по умолчанию)
+	CacheBackendRedis  = "redis"  // в Redis, общий для всех реплик (настройки подключения - REDIS_* в .env)
+)
+
 // структура конфига для кэша поиска
 type SearchCacheConfig struct {
-	SearchCacheTTL     time.Duration // время жизни элементов кэша поиска
-	SearchCacheCleanUp time.Duration // интервал самоочистки для инмэмори кэша поиска
+	SearchCacheTTL     time.Duration `yaml:"search_cache_ttl"`               // время жизни элементов кэша поиска (пока результаты свежие)
+	SearchCacheStale   time.Duration `yaml:"search_cache_stale"`             // сколько после TTL отдавать устаревшие результаты, пока они обновляются в фоне (0 - не отдавать)
+	SearchCacheCleanUp time.Duration `yaml:"search_cache_clean_up_interval"` // интервал самоочистки для инмэмори кэша поиска
+	SearchCacheBackend string        `yaml:"search_cache_backend"`           // где хранить кэш поиска: memory или redis
 }
 
 // структура конфига для кэша обратных индексов (вакансий)
 type VacancyCacheConfig struct {
-	VacancyCacheTTL     time.Duration // время жизни элементов кэша индекса
-	VacancyCacheCleanUp time.Duration // интервал самоочистки для инмэмори кэша индекса
+	VacancyCacheTTL     time.Duration `yaml:"vacancy_cache_ttl"`               // время жизни элементов кэша индекса
+	VacancyCacheCleanUp time.Duration `yaml:"vacancy_cache_clean_up_interval"` // интервал самоочистки для инмэмори кэша индекса
+	VacancyCacheBackend string        `yaml:"vacancy_cache_backend"`           // где хранить кэш индекса: memory или redis
 }
 
 type VacancyDetailsCacheConfig struct {
-	VacDetCacheTTL     time.Duration // время жизни элементов кэша деталей вакансии
-	VacDetCacheCleanUp time.Duration // интервал самоочистки для инмэмори кэша деталей вакансии
+	VacDetCacheTTL     time.Duration `yaml:"vac_datails_cache_ttl"`               // время жизни элементов кэша деталей вакансии
+	VacDetCacheCleanUp time.Duration `yaml:"vac_datails_cache_clean_up_interval"` // интервал самоочистки для инмэмори кэша деталей вакансии
+	VacDetCacheBackend string        `yaml:"vac_datails_cache_backend"`           // где хранить кэш деталей вакансии: memory или redis
+}
+
+// структура конфига для кэша ответов справочников (значения фильтров, источники, локации)
+type DictionaryCacheConfig struct {
+	DictionaryCacheTTL     time.Duration `yaml:"dictionary_cache_ttl"`               // время жизни ответа справочника (и max-age для клиента)
+	DictionaryCacheCleanUp time.Duration `yaml:"dictionary_cache_clean_up_interval"` // интервал самоочистки для инмэмори кэша справочников
+	DictionaryCacheBackend string        `yaml:"dictionary_cache_backend"`           // где хранить кэш справочников: memory или redis
+}
+
+// структура конфига для кэша асинхронных поисков (состояние и результаты завершённых поисков)
+type SearchJobsCacheConfig struct {
+	SearchJobsTTL     time.Duration `yaml:"search_jobs_ttl"`               // сколько хранить результаты асинхронного поиска после его завершения
+	SearchJobsCleanUp time.Duration `yaml:"search_jobs_clean_up_interval"` // интервал самоочистки для инмэмори кэша асинхронных поисков
+}
+
+// структура конфига снимков кэшей на диске (кэши поиска, обратного индекса и деталей вакансий переживают перезапуск)
+type CacheSnapshotConfig struct {
+	SnapshotDir      string        `yaml:"snapshot_dir"`      // каталог файлов снимков (пусто - снимки не сохраняются)
+	SnapshotInterval time.Duration `yaml:"snapshot_interval"` // как часто сохранять снимки (0 - только при остановке сервиса)
+}
+
+// структура конфига кэшей в Redis
+// кэш асинхронных поисков всегда в памяти: незавершённые поиски живут только в реплике, которая их выполняет
+type CacheRedisConfig struct {
+	KeyPrefix        string        `yaml:"key_prefix"`        // префикс ключей кэшей сервиса в Redis (к нему добавляется имя кэша)
+	OperationTimeout time.Duration `yaml:"operation_timeout"` // таймаут одной операции с Redis
 }
 
 // функция, которая возвращает указатель на дэфолтный конфиг для кэшэй
 func DefaultCacheConfig() *CachesConfig {
 	return &CachesConfig{
-		NumOfShards: 7,
+		NumOfShards:      7,
+		MaxEntries:       10000,
+		MaxMemoryUsageMB: 128,
 		SearchCacheConfig: SearchCacheConfig{
 			SearchCacheTTL:     60 * time.Second,
+			SearchCacheStale:   10 * time.Minute,
 			SearchCacheCleanUp: 30 * time.Second,
+			SearchCacheBackend: CacheBackendMemory,
 		},
 		VacancyCacheConfig: VacancyCacheConfig{
 			VacancyCacheTTL:     60 * time.Second,
 			VacancyCacheCleanUp: 30 * time.Second,
+			VacancyCacheBackend: CacheBackendMemory,
 		},
 		VacancyDetailsCacheConfig: VacancyDetailsCacheConfig{
 			VacDetCacheTTL:     60 * time.Second,
 			VacDetCacheCleanUp: 30 * time.Second,
+			VacDetCacheBackend: CacheBackendMemory,
+		},
+		DictionaryCacheConfig: DictionaryCacheConfig{
+			DictionaryCacheTTL:     time.Hour,
+			DictionaryCacheCleanUp: 30 * time.Minute,
+			DictionaryCacheBackend: CacheBackendMemory,
+		},
+		SearchJobsCacheConfig: SearchJobsCacheConfig{
+			SearchJobsTTL:     10 * time.Minute,
+			SearchJobsCleanUp: 5 * time.Minute,
+		},
+		SnapshotConfig: CacheSnapshotConfig{
+			SnapshotDir:      "cache_snapshots",
+			SnapshotInterval: 5 * time.Minute,
+		},
+		RedisConfig: CacheRedisConfig{
+			KeyPrefix:        "search_service:",
+			OperationTimeout: 500 * time.Millisecond,
 		},
 	}
 }
@@ -0,0 +1,111 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"shared/config"
+)
+
+const cachesConfigPath = "../yml_configs/cachesConfig.yml"
+
+// функция загрузки конфига кэшей из переданного текста (копия cachesConfig.yml с правками)
+func loadCachesConfigFrom(t *testing.T, content string) *CachesConfig {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "cachesConfig.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("не удалось записать конфиг: %v", err)
+	}
+
+	cfg, err := config.LoadYAMLConfig[CachesConfig](path, DefaultCacheConfig)
+	if err != nil {
+		t.Fatalf("ошибка загрузки конфига: %v", err)
+	}
+	return cfg
+}
+
+func readCachesConfig(t *testing.T) string {
+	t.Helper()
+
+	data, err := os.ReadFile(cachesConfigPath)
+	if err != nil {
+		t.Fatalf("не удалось прочитать %s: %v", cachesConfigPath, err)
+	}
+	return string(data)
+}
+
+// TestLoadCachesConfig проверяет, что значения из cachesConfig.yml попадают в конфиг, а не остаются дэфолтными
+func TestLoadCachesConfig(t *testing.T) {
+	cfg, err := config.LoadYAMLConfig[CachesConfig](cachesConfigPath, DefaultCacheConfig)
+	if err != nil {
+		t.Fatalf("ошибка загрузки конфига: %v", err)
+	}
+
+	if cfg.NumOfShards != 5 {
+		t.Errorf("NumOfShards = %d, ожидалось 5", cfg.NumOfShards)
+	}
+	if cfg.MaxEntries != 10000 || cfg.MaxMemoryUsageMB != 128 {
+		t.Errorf("MaxEntries = %d, MaxMemoryUsageMB = %d, ожидалось 10000 и 128", cfg.MaxEntries, cfg.MaxMemoryUsageMB)
+	}
+	if cfg.SearchCacheConfig.SearchCacheTTL != time.Hour {
+		t.Errorf("SearchCacheTTL = %v, ожидалось 1h", cfg.SearchCacheConfig.SearchCacheTTL)
+	}
+	if cfg.SearchCacheConfig.SearchCacheStale != time.Hour {
+		t.Errorf("SearchCacheStale = %v, ожидалось 1h", cfg.SearchCacheConfig.SearchCacheStale)
+	}
+	if cfg.VacancyCacheConfig.VacancyCacheTTL != 2700*time.Second {
+		t.Errorf("VacancyCacheTTL = %v, ожидалось 45m", cfg.VacancyCacheConfig.VacancyCacheTTL)
+	}
+	if cfg.VacancyDetailsCacheConfig.VacDetCacheCleanUp != 3000*time.Second {
+		t.Errorf("VacDetCacheCleanUp = %v, ожидалось 50m", cfg.VacancyDetailsCacheConfig.VacDetCacheCleanUp)
+	}
+	if cfg.SearchJobsCacheConfig.SearchJobsCleanUp != 5*time.Minute {
+		t.Errorf("SearchJobsCleanUp = %v, ожидалось 5m", cfg.SearchJobsCacheConfig.SearchJobsCleanUp)
+	}
+	if cfg.SnapshotConfig.SnapshotDir != "cache_snapshots" || cfg.SnapshotConfig.SnapshotInterval != 5*time.Minute {
+		t.Errorf("SnapshotConfig = %+v", cfg.SnapshotConfig)
+	}
+	if cfg.RedisConfig.KeyPrefix != "search_service:" || cfg.RedisConfig.OperationTimeout != 500*time.Millisecond {
+		t.Errorf("RedisConfig = %+v", cfg.RedisConfig)
+	}
+}
+
+// TestLoadCachesConfigRedisBackend проверяет, что backend redis выбирается для каждого кэша отдельно
+func TestLoadCachesConfigRedisBackend(t *testing.T) {
+	content := strings.Replace(readCachesConfig(t), "search_cache_backend: memory", "search_cache_backend: redis", 1)
+	content = strings.Replace(content, "dictionary_cache_backend: memory", "dictionary_cache_backend: redis", 1)
+
+	cfg := loadCachesConfigFrom(t, content)
+
+	if cfg.SearchCacheConfig.SearchCacheBackend != CacheBackendRedis {
+		t.Errorf("SearchCacheBackend = %q, ожидалось %q", cfg.SearchCacheConfig.SearchCacheBackend, CacheBackendRedis)
+	}
+	if cfg.DictionaryCacheConfig.DictionaryCacheBackend != CacheBackendRedis {
+		t.Errorf("DictionaryCacheBackend = %q, ожидалось %q", cfg.DictionaryCacheConfig.DictionaryCacheBackend, CacheBackendRedis)
+	}
+	if cfg.VacancyCacheConfig.VacancyCacheBackend != CacheBackendMemory {
+		t.Errorf("VacancyCacheBackend = %q, ожидалось %q", cfg.VacancyCacheConfig.VacancyCacheBackend, CacheBackendMemory)
+	}
+	if cfg.VacancyDetailsCacheConfig.VacDetCacheBackend != CacheBackendMemory {
+		t.Errorf("VacDetCacheBackend = %q, ожидалось %q", cfg.VacancyDetailsCacheConfig.VacDetCacheBackend, CacheBackendMemory)
+	}
+}
+
+// TestLoadCachesConfigSnapshotsDisabled проверяет, что пустой snapshot_dir отключает снимки, а не заменяется дэфолтным каталогом
+func TestLoadCachesConfigSnapshotsDisabled(t *testing.T) {
+	content := strings.Replace(readCachesConfig(t), "snapshot_dir: 'cache_snapshots'", "snapshot_dir: ''", 1)
+	content = strings.Replace(content, "snapshot_interval: 300s", "snapshot_interval: 0s", 1)
+
+	cfg := loadCachesConfigFrom(t, content)
+
+	if cfg.SnapshotConfig.SnapshotDir != "" {
+		t.Errorf("SnapshotDir = %q, ожидалась пустая строка", cfg.SnapshotConfig.SnapshotDir)
+	}
+	if cfg.SnapshotConfig.SnapshotInterval != 0 {
+		t.Errorf("SnapshotInterval = %v, ожидалось 0", cfg.SnapshotConfig.SnapshotInterval)
+	}
+}
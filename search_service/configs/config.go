@@ -22,6 +22,9 @@ type SearchServiceConfig struct {
 	Manager     *ParserManagerConfig
 	HealthCheck *HealthCheckConfig
 	ServerConf  *config.ServerConfig
+	Currency    *CurrencyConfig
+	Locations   *LocationsConfig
+	Quotas      *QuotaConfig
 }
 
 type APIConfig struct {
@@ -51,7 +54,7 @@ func LoadConfig() (*SearchServiceConfig, error) {
 		return nil, fmt.Errorf("Error during loading config: %s\n", err.Error())
 	}
 
-	parsersManagerConfig, err := config.LoadYAMLConfig[ParserManagerConfig](os.Getenv("PARSERS_CONFIG_ADDRESS_STRING"), DefaultParsersManagerConfig)
+	parsersManagerConfig, err := config.LoadYAMLConfig[ParserManagerConfig](os.Getenv("PARSERS_MANAGER_ADDRESS_STRING"), DefaultParsersManagerConfig)
 	if err != nil {
 		return nil, fmt.Errorf("Error during loading config: %s\n", err.Error())
 	}
@@ -66,6 +69,21 @@ func LoadConfig() (*SearchServiceConfig, error) {
 		return nil, fmt.Errorf("Error during loading config: %s\n", err.Error())
 	}
 
+	currencyConfig, err := config.LoadYAMLConfig[CurrencyConfig](os.Getenv("CURRENCY_RATES_CONFIG_ADDRESS_STRING"), DefaultCurrencyConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Error during loading config: %s\n", err.Error())
+	}
+
+	locationsConfig, err := config.LoadYAMLConfig[LocationsConfig](os.Getenv("LOCATIONS_CONFIG_ADDRESS_STRING"), DefaultLocationsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Error during loading config: %s\n", err.Error())
+	}
+
+	quotaConfig, err := config.LoadYAMLConfig[QuotaConfig](os.Getenv("QUOTAS_CONFIG_ADDRESS_STRING"), DefaultQuotaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Error during loading config: %s\n", err.Error())
+	}
+
 	return &SearchServiceConfig{
 		API: APIConfig{
 			ConcSearchTimeout: time.Duration(concSearchTimeOut) * time.Second,
@@ -75,5 +93,8 @@ func LoadConfig() (*SearchServiceConfig, error) {
 		Manager:     parsersManagerConfig,
 		HealthCheck: healthCheckConfig,
 		ServerConf:  serverConfig,
+		Currency:    currencyConfig,
+		Locations:   locationsConfig,
+		Quotas:      quotaConfig,
 	}, nil
 }
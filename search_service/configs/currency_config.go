@@ -0,0 +1,24 @@
+package configs
+
+// конфиг курсов валют для сравнения и сортировки зарплат в одной валюте
+// курсы статические (обновляются вместе с файлом конфига), для ранжирования выдачи точность курса не критична
+type CurrencyConfig struct {
+	BaseCurrency string             `yaml:"base_currency"` // валюта, к которой приводятся зарплаты при сравнении
+	Rates        map[string]float64 `yaml:"rates"`         // сколько единиц базовой валюты стоит 1 единица валюты
+}
+
+// конфиг курсов валют по умолчанию
+func DefaultCurrencyConfig() *CurrencyConfig {
+	return &CurrencyConfig{
+		BaseCurrency: "RUB",
+		Rates: map[string]float64{
+			"RUB": 1,
+			"USD": 90,
+			"EUR": 98,
+			"KZT": 0.18,
+			"BYN": 27,
+			"UZS": 0.0071,
+			"UAH": 2.2,
+		},
+	}
+}
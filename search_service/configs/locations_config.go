@@ -0,0 +1,27 @@
+package configs
+
+import "time"
+
+// конфиг справочника локаций: справочники регионов и городов источников загружаются с их API и периодически обновляются
+// до первой успешной загрузки (и если API недоступно) используется снимок справочников, встроенный в сервис
+type LocationsConfig struct {
+	RefreshInterval time.Duration `yaml:"refresh_interval"` // как часто обновлять справочники (0 - загрузить один раз при старте)
+	RequestTimeout  time.Duration `yaml:"request_timeout"`  // таймаут загрузки одного справочника
+
+	HHAreasURL     string `yaml:"hh_areas_url"`     // дерево регионов HH.ru (им же пользуется Зарплата.ру), пусто - только снимок
+	SJTownsURL     string `yaml:"sj_towns_url"`     // города SuperJob, пусто - только снимок
+	SJRegionsURL   string `yaml:"sj_regions_url"`   // регионы SuperJob
+	SJCountriesURL string `yaml:"sj_countries_url"` // страны SuperJob
+}
+
+// конфиг справочника локаций по умолчанию
+func DefaultLocationsConfig() *LocationsConfig {
+	return &LocationsConfig{
+		RefreshInterval: 24 * time.Hour,
+		RequestTimeout:  30 * time.Second,
+		HHAreasURL:      "https://api.hh.ru/areas",
+		SJTownsURL:      "https://api.superjob.ru/2.0/towns/?all=1",
+		SJRegionsURL:    "https://api.superjob.ru/2.0/regions/?all=1",
+		SJCountriesURL:  "https://api.superjob.ru/2.0/countries/",
+	}
+}
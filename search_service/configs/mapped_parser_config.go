@@ -0,0 +1,52 @@
+package configs
+
+// стили пагинации для декларативного (mapped) парсера
+const (
+	PaginationPage   = "page"   // номер страницы, нумерация с 1
+	PaginationPage0  = "page0"  // номер страницы, нумерация с 0
+	PaginationOffset = "offset" // смещение от начала выдачи: (page-1) * per_page
+)
+
+// структура описания декларативного парсера JSON API (всё, что обычно пишется руками в buildURL/parse/convertToUniversal)
+type MappingConfig struct {
+	Query             MappedQueryConfig  `yaml:"query"`               // имена параметров запроса у источника
+	ExtraQuery        map[string]string  `yaml:"extra_query"`         // статические параметры, которые добавляются к каждому запросу
+	Pagination        string             `yaml:"pagination"`          // стиль пагинации: page | page0 | offset
+	DefaultPerPage    int                `yaml:"default_per_page"`    // размер страницы по умолчанию
+	MaxPerPage        int                `yaml:"max_per_page"`        // максимальный размер страницы, который принимает источник
+	ItemsPath         string             `yaml:"items_path"`          // путь до массива вакансий в ответе поиска ("results.vacancies")
+	TotalPath         string             `yaml:"total_path"`          // путь до общего количества найденных вакансий ("meta.total"), необязательно
+	PagesPath         string             `yaml:"pages_path"`          // путь до общего количества страниц, по умолчанию считается из total_path
+	DetailsURL        string             `yaml:"details_url"`         // шаблон URL деталей вакансии с плейсхолдером {id}, по умолчанию base_url/{id}
+	DetailsPath       string             `yaml:"details_path"`        // путь до объекта вакансии в ответе деталей (пусто - корень ответа)
+	PublishedAtLayout string             `yaml:"published_at_layout"` // формат даты публикации (Go layout), по умолчанию RFC3339
+	URLPrefix         string             `yaml:"url_prefix"`          // префикс для относительных ссылок на вакансии
+	Fields            MappedFieldsConfig `yaml:"fields"`              // пути до полей внутри одной вакансии
+}
+
+// структура имён параметров запроса
+type MappedQueryConfig struct {
+	Text string `yaml:"text"`
+	Area string `yaml:"area"`
+	// справочник локаций, по которому город/регион переводится в код источника ("hh" - классификатор HH.ru)
+	// пусто - локация передаётся текстом
+	AreaCatalog string `yaml:"area_catalog"`
+	Page        string `yaml:"page"`
+	PerPage     string `yaml:"per_page"`
+}
+
+// структура путей до полей вакансии (JSONPath-подобный синтаксис: "vacancy.company.name", "addresses.address[0].location")
+type MappedFieldsConfig struct {
+	ID           string `yaml:"id"`
+	Title        string `yaml:"title"`
+	Company      string `yaml:"company"`
+	SalaryFrom   string `yaml:"salary_from"`
+	SalaryTo     string `yaml:"salary_to"`
+	Currency     string `yaml:"currency"`
+	SalaryGross  string `yaml:"salary_gross"`  // признак зарплаты до вычета налогов (true/false), необязательно
+	SalaryPeriod string `yaml:"salary_period"` // период зарплаты (hour, day, month, year), необязательно - по умолчанию месяц
+	City         string `yaml:"city"`
+	URL          string `yaml:"url"`
+	PublishedAt  string `yaml:"published_at"`
+	Description  string `yaml:"description"`
+}
@@ -10,11 +10,33 @@ type ParserManagerConfig struct {
 	MaxConcurrentParsers int                         `yaml:"max_concurrent_parsers"` // глобальный семафор на использование парсеров
 	CircuitBreakerCfg    config.CircuitBreakerConfig `yaml:"circuit_breaker"`        // глобальный circuit breaker
 	HealthCheckInterval  time.Duration               `yaml:"health_check_interval"`  // интервал проверки систояния менеджера парсеров
+	MaxPagesPerSource    int                         `yaml:"max_pages_per_source"`   // сколько страниц максимум можно запросить у одного источника за один поиск
+	Queue                QueueConfig                 `yaml:"queue"`                  // очередь джоб менеджера парсеров
+}
+
+// виды очереди джоб менеджера парсеров
+const (
+	QueueSchedulerFIFO = "fifo" // джобы выполняются строго в порядке поступления
+	QueueSchedulerFair = "fair" // приоритеты по ролям и обслуживание пользователей по кругу
+)
+
+// структура конфига очереди джоб менеджера парсеров
+type QueueConfig struct {
+	Scheduler       string         `yaml:"scheduler"`        // fifo или fair
+	MaxPerUser      int            `yaml:"max_per_user"`     // сколько джоб одного пользователя может ждать в очереди (0 - без ограничения)
+	StarvationLimit int            `yaml:"starvation_limit"` // после стольких джоб подряд с более высоким приоритетом выполняется джоба с более низким (0 - строгий приоритет)
+	RolePriorities  map[string]int `yaml:"role_priorities"`  // приоритет джоб пользователей с ролью (у пользователя берётся наибольший)
 }
 
 // функция, которая возвращает указатель на дэфолтный конфиг мэнеджера парсеров
 func DefaultParsersManagerConfig() *ParserManagerConfig {
 	return &ParserManagerConfig{
+		MaxPagesPerSource: 5,
+		Queue: QueueConfig{
+			Scheduler:       QueueSchedulerFair,
+			StarvationLimit: 5,
+			RolePriorities:  map[string]int{"premium": 1},
+		},
 		CircuitBreakerCfg: config.CircuitBreakerConfig{
 			FailureThreshold:    5,
 			SuccessThreshold:    3,
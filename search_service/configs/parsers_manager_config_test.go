@@ -0,0 +1,45 @@
+package configs
+
+import (
+	"testing"
+	"time"
+
+	"shared/config"
+)
+
+const parsersManagerConfigPath = "../yml_configs/parsersManagerConfig.yml"
+
+// TestLoadParsersManagerConfig проверяет, что значения из parsersManagerConfig.yml (вместе с блоком queue) попадают в конфиг
+func TestLoadParsersManagerConfig(t *testing.T) {
+	cfg, err := config.LoadYAMLConfig[ParserManagerConfig](parsersManagerConfigPath, DefaultParsersManagerConfig)
+	if err != nil {
+		t.Fatalf("ошибка загрузки конфига: %v", err)
+	}
+
+	if cfg.MaxConcurrentParsers != 12 {
+		t.Errorf("MaxConcurrentParsers = %d, ожидалось 12", cfg.MaxConcurrentParsers)
+	}
+	if cfg.HealthCheckInterval != 10*time.Second {
+		t.Errorf("HealthCheckInterval = %v, ожидалось 10s", cfg.HealthCheckInterval)
+	}
+	if cfg.MaxPagesPerSource != 5 {
+		t.Errorf("MaxPagesPerSource = %d, ожидалось 5", cfg.MaxPagesPerSource)
+	}
+	if cfg.CircuitBreakerCfg.FailureThreshold != 5 || cfg.CircuitBreakerCfg.ResetTimeout != 10*time.Second {
+		t.Errorf("CircuitBreakerCfg = %+v", cfg.CircuitBreakerCfg)
+	}
+
+	queue := cfg.Queue
+	if queue.Scheduler != QueueSchedulerFair {
+		t.Errorf("Queue.Scheduler = %q, ожидалось %q", queue.Scheduler, QueueSchedulerFair)
+	}
+	if queue.MaxPerUser != 0 {
+		t.Errorf("Queue.MaxPerUser = %d, ожидалось 0", queue.MaxPerUser)
+	}
+	if queue.StarvationLimit != 5 {
+		t.Errorf("Queue.StarvationLimit = %d, ожидалось 5", queue.StarvationLimit)
+	}
+	if len(queue.RolePriorities) != 1 || queue.RolePriorities["premium"] != 1 {
+		t.Errorf("Queue.RolePriorities = %v, ожидалось map[premium:1]", queue.RolePriorities)
+	}
+}
@@ -8,10 +8,22 @@ import (
 type ParsersConfig struct {
 	HH       *ParserInstanceConfig `yaml:"hh"`
 	SuperJob *ParserInstanceConfig `yaml:"superjob"`
+	Habr     *ParserInstanceConfig `yaml:"habr"`
+	Zarplata *ParserInstanceConfig `yaml:"zarplata"`
+
+	// декларативные парсеры JSON API - описываются целиком в конфиге, без отдельного Go типа
+	Mapped []*ParserInstanceConfig `yaml:"mapped"`
+
+	// парсеры RSS/Atom лент (нишевые площадки и страницы карьеры компаний)
+	Feeds []*ParserInstanceConfig `yaml:"feeds"`
+
+	// парсеры HTML страниц с селекторами из конфига (источники без API)
+	Scrapers []*ParserInstanceConfig `yaml:"scrapers"`
 }
 
 // структура конфига для отдельного парсера
 type ParserInstanceConfig struct {
+	Name                  string                      `yaml:"name"` // имя источника (обязательно для mapped парсеров)
 	Enabled               bool                        `yaml:"enabled"`
 	BaseURL               string                      `yaml:"base_url"`
 	HealthEndPoint        string                      `yaml:"health_endpoint"`
@@ -25,6 +37,10 @@ type ParserInstanceConfig struct {
 	TLSHandshakeTimeout   time.Duration               `yaml:"tls_handshake_timeout"`
 	ResponseHeaderTimeout time.Duration               `yaml:"response_header_timeout"`
 	ExpectContinueTimeout time.Duration               `yaml:"expect_continue_timeout"`
+	Mapping               *MappingConfig              `yaml:"mapping"`   // описание полей для mapped парсера
+	FeedURLs              []string                    `yaml:"feed_urls"` // адреса лент для парсера RSS/Atom
+	Scraping              *ScrapingConfig             `yaml:"scraping"`  // селекторы для парсера HTML страниц
+	Request               *RequestConfig              `yaml:"request"`   // заголовки и ключи, которые добавляются к каждому запросу
 }
 
 // DefaultParsersConfig возвращает конфигурацию по умолчанию
@@ -48,6 +64,10 @@ func DefaultParsersConfig() *ParsersConfig {
 			TLSHandshakeTimeout:   10 * time.Second,
 			ResponseHeaderTimeout: 5 * time.Second,
 			ExpectContinueTimeout: 1 * time.Second,
+			Request: &RequestConfig{
+				// HH.ru отклоняет запросы без HH-User-Agent (название приложения и контактная почта)
+				Headers: map[string]string{"HH-User-Agent": "JobSearchService/1.0 (admin@example.com)"},
+			},
 		},
 		SuperJob: &ParserInstanceConfig{
 			Enabled:       true,
@@ -67,6 +87,50 @@ func DefaultParsersConfig() *ParsersConfig {
 			TLSHandshakeTimeout:   10 * time.Second,
 			ResponseHeaderTimeout: 5 * time.Second,
 			ExpectContinueTimeout: 1 * time.Second,
+			Request: &RequestConfig{
+				APIKeyHeader: "X-Api-App-Id", // SuperJob принимает ключ приложения только в этом заголовке
+			},
+		},
+		Habr: &ParserInstanceConfig{
+			Enabled:       true,
+			BaseURL:       "https://career.habr.com/api/frontend/vacancies",
+			Timeout:       30 * time.Second,
+			RateLimit:     2 * time.Second,
+			MaxConcurrent: 5,
+			CircuitBreaker: config.CircuitBreakerConfig{
+				FailureThreshold:    5,
+				SuccessThreshold:    3,
+				HalfOpenMaxRequests: 2,
+				ResetTimeout:        10 * time.Second,
+				WindowDuration:      10 * time.Second,
+			},
+			MaxIdleConns:          5,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 5 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		Zarplata: &ParserInstanceConfig{
+			Enabled:       true,
+			BaseURL:       "https://api.zarplata.ru/vacancies",
+			Timeout:       30 * time.Second,
+			RateLimit:     2 * time.Second,
+			MaxConcurrent: 5,
+			CircuitBreaker: config.CircuitBreakerConfig{
+				FailureThreshold:    5,
+				SuccessThreshold:    3,
+				HalfOpenMaxRequests: 2,
+				ResetTimeout:        10 * time.Second,
+				WindowDuration:      10 * time.Second,
+			},
+			MaxIdleConns:          5,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 5 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+			Request: &RequestConfig{
+				Headers: map[string]string{"HH-User-Agent": "JobSearchService/1.0 (admin@example.com)"}, // платформа HH
+			},
 		},
 	}
 }
@@ -0,0 +1,39 @@
+package configs
+
+// конфиг квот пользователей: сколько запросов поиска пользователь может сделать за минуту и за сутки
+// лимиты задаются по умолчанию и для ролей (роли приходят от nginx в X-User-Roles), 0 - без ограничения
+type QuotaConfig struct {
+	Enabled   bool                   `yaml:"enabled"`    // включены ли квоты
+	Store     string                 `yaml:"store"`      // где хранить счётчики: memory (одна реплика) или redis (общие для всех реплик)
+	KeyPrefix string                 `yaml:"key_prefix"` // префикс ключей счётчиков в Redis
+	Default   QuotaLimits            `yaml:"default"`    // лимиты пользователя без ролей из списка
+	Roles     map[string]QuotaLimits `yaml:"roles"`      // лимиты по ролям (если ролей несколько - действует самый щедрый лимит)
+}
+
+// лимиты запросов пользователя (0 - без ограничения)
+type QuotaLimits struct {
+	PerMinute int `yaml:"per_minute"`
+	PerDay    int `yaml:"per_day"`
+}
+
+// хранилища счётчиков квот
+const (
+	QuotaStoreMemory = "memory"
+	QuotaStoreRedis  = "redis"
+)
+
+// конфиг квот по умолчанию
+func DefaultQuotaConfig() *QuotaConfig {
+	return &QuotaConfig{
+		Enabled:   true,
+		Store:     QuotaStoreMemory,
+		KeyPrefix: "search_quota",
+		Default: QuotaLimits{
+			PerMinute: 30,
+			PerDay:    1000,
+		},
+		Roles: map[string]QuotaLimits{
+			"premium": {PerMinute: 120, PerDay: 0},
+		},
+	}
+}
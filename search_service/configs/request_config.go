@@ -0,0 +1,12 @@
+package configs
+
+// структура оформления исходящих запросов к источнику: заголовки, авторизация, ключи в параметрах запроса
+// применяется ко всем запросам парсера - поиску, деталям вакансии и health check
+type RequestConfig struct {
+	UserAgent    string            `yaml:"user_agent"`     // значение заголовка User-Agent
+	Headers      map[string]string `yaml:"headers"`        // статические заголовки ("HH-User-Agent" у HH.ru)
+	APIKeyHeader string            `yaml:"api_key_header"` // имя заголовка, в котором передаётся api_key ("X-Api-App-Id" у SuperJob)
+	APIKeyQuery  string            `yaml:"api_key_query"`  // имя параметра запроса, в котором передаётся api_key
+	BearerToken  string            `yaml:"bearer_token"`   // токен для заголовка "Authorization: Bearer ..."
+	Query        map[string]string `yaml:"query"`          // статические параметры, которые добавляются к каждому запросу
+}
@@ -0,0 +1,29 @@
+package configs
+
+// структура описания парсера HTML страниц (для источников без API)
+// селекторы - упрощённый CSS: тег, #id, .class, [attr], [attr=value], потомок через пробел, прямой потомок через ">"
+// суффикс "@attr" означает, что нужно взять значение атрибута, а не текст элемента ("a.title@href", "@data-id")
+type ScrapingConfig struct {
+	Query          MappedQueryConfig    `yaml:"query"`            // имена параметров запроса страницы поиска
+	ExtraQuery     map[string]string    `yaml:"extra_query"`      // статические параметры, которые добавляются к каждому запросу
+	Pagination     string               `yaml:"pagination"`       // стиль пагинации: page | page0 | offset
+	DefaultPerPage int                  `yaml:"default_per_page"` // размер страницы выдачи (нужен для offset пагинации)
+	ItemSelector   string               `yaml:"item_selector"`    // селектор карточки вакансии на странице поиска
+	TotalSelector  string               `yaml:"total_selector"`   // селектор элемента с общим количеством найденного ("Найдено 1 234 вакансии"), необязательно
+	DetailsURL     string               `yaml:"details_url"`      // шаблон URL страницы вакансии с плейсхолдером {id}, по умолчанию base_url/{id}
+	URLPrefix      string               `yaml:"url_prefix"`       // префикс для относительных ссылок на вакансии
+	Fields         ScrapingFieldsConfig `yaml:"fields"`           // селекторы полей внутри карточки вакансии
+	Details        ScrapingFieldsConfig `yaml:"details"`          // селекторы полей на странице вакансии
+}
+
+// структура селекторов полей вакансии
+// если селектор ID не указан - ID берётся из последнего сегмента ссылки на вакансию
+type ScrapingFieldsConfig struct {
+	ID          string `yaml:"id"`
+	Title       string `yaml:"title"`
+	Company     string `yaml:"company"`
+	Salary      string `yaml:"salary"`
+	City        string `yaml:"city"`
+	URL         string `yaml:"url"`
+	Description string `yaml:"description"`
+}
@@ -0,0 +1,40 @@
+// инмэмори кэш сервиса поиска поверх обобщённого шардированного кэша
+package caches
+
+import (
+	"search_service/internal/domain/models"
+	"shared/inmemory_cache"
+)
+
+// Inmemory - инмэмори кэш значений типа V (LRU внутри шарда, устаревшие значения, снимки на диске)
+// статистику отдаёт в модели сервиса, чтобы интерфейсы кэшей не зависели от пакета инмэмори кэша
+type Inmemory[V any] struct {
+	*inmemory_cache.ShardedCache[string, V]
+}
+
+// конструктор для инмэмори кэша значений типа V
+func NewInmemory[V any](cfg inmemory_cache.ShardedCacheConfig[string, V]) (*Inmemory[V], error) {
+	cache, err := inmemory_cache.NewShardedCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Inmemory[V]{ShardedCache: cache}, nil
+}
+
+// метод получения статистики кэша (имя кэша заполняет тот, кто собирает статистику)
+func (c *Inmemory[V]) Stats() models.CacheStats {
+	stats := c.ShardedCache.Stats()
+
+	return models.CacheStats{
+		Available:  true,
+		Hits:       stats.Hits,
+		StaleHits:  stats.StaleHits,
+		Misses:     stats.Misses,
+		HitRatio:   stats.HitRatio(),
+		Evictions:  stats.Evictions,
+		Expired:    stats.Expired,
+		Entries:    stats.Entries,
+		Bytes:      stats.Bytes,
+		ShardSizes: stats.ShardSizes,
+	}
+}
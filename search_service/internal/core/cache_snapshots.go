@@ -0,0 +1,40 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"search_service/configs"
+	"time"
+)
+
+// кэш, который умеет сохранять снимки на диск и восстанавливаться из них (инмэмори кэши)
+type snapshotCache interface {
+	LoadSnapshot(path string) (int, error)
+	EnableSnapshots(path string, interval time.Duration)
+}
+
+// функция восстановления кэшей из снимков и включения снимков (имя кэша -> имя файла снимка)
+// снимки нужны только инмэмори кэшам (кэши в Redis и так переживают перезапуск)
+// если снимок не загрузился - кэш просто начинает с пустого, сервис продолжает работу
+func restoreCacheSnapshots(cfg configs.CacheSnapshotConfig, caches map[string]interface{}) {
+	if cfg.SnapshotDir == "" {
+		return
+	}
+
+	for name, c := range caches {
+		cache, ok := c.(snapshotCache)
+		if !ok {
+			continue
+		}
+		path := filepath.Join(cfg.SnapshotDir, name+".gob")
+
+		loaded, err := cache.LoadSnapshot(path)
+		if err != nil {
+			fmt.Printf("⚠️  Снимок кэша %s не загружен: %v\n", name, err)
+		} else if loaded > 0 {
+			fmt.Printf("✅ Кэш %s восстановлен из снимка: %d элементов\n", name, loaded)
+		}
+
+		cache.EnableSnapshots(path, cfg.SnapshotInterval)
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"fmt"
+	"global_models/global_cache"
+	"search_service/configs"
+	"search_service/internal/caches"
+	"search_service/internal/search_interfaces"
+	"shared/config"
+	"shared/inmemory_cache"
+	"shared/redis"
+	"time"
+)
+
+// общее подключение к Redis для кэшей и квот (подключаемся, только если Redis кому-то нужен)
+type redisConnection struct {
+	cache global_cache.Cache
+}
+
+// метод получения подключения к Redis (настройки подключения - REDIS_* в .env)
+func (r *redisConnection) get() (global_cache.Cache, error) {
+	if r.cache != nil {
+		return r.cache, nil
+	}
+
+	redisConfig, err := config.NewRedisConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load redis config: %w", err)
+	}
+
+	redisCache, err := redis.NewRedisCacheRepository(redisConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	r.cache = redisCache
+	return redisCache, nil
+}
+
+// параметры создания одного кэша
+type cacheSpec struct {
+	name    string        // имя кэша (префикс ключей в Redis и имя файла снимка)
+	backend string        // memory или redis
+	cleanUp time.Duration // интервал самоочистки инмэмори кэша
+}
+
+// функция создания кэша значений типа V по конфигу: инмэмори кэш реплики или кэш в Redis, общий для всех реплик
+// в Redis значения хранятся в JSON
+func newCache[V any](conf *configs.CachesConfig, spec cacheSpec, conn *redisConnection) (search_interfaces.CacheInterface[V], error) {
+	switch spec.backend {
+	case "", configs.CacheBackendMemory:
+		return caches.NewInmemory(inmemory_cache.ShardedCacheConfig[string, V]{
+			NumShards:       conf.NumOfShards,
+			CleanUpInterval: spec.cleanUp,
+			MaxEntries:      conf.MaxEntries,
+			MaxBytes:        int64(conf.MaxMemoryUsageMB) << 20,
+		})
+	case configs.CacheBackendRedis:
+		redisCache, err := conn.get()
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Кэш %s хранится в Redis\n", spec.name)
+		return redis.NewItemCache(redisCache, redis.NewJSONCodec[V](), conf.RedisConfig.KeyPrefix+spec.name+":", conf.RedisConfig.OperationTimeout), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", spec.backend)
+	}
+}
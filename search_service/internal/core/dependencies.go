@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"runtime"
 	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/internal/locations"
+	"search_service/internal/metrics"
 	"search_service/internal/parser"
 	"search_service/internal/parsers_manager"
 	"search_service/internal/parsers_status_manager"
+	"search_service/internal/quota"
 	"search_service/internal/search_interfaces"
+	"search_service/internal/search_server/dto"
 	"search_service/internal/search_server/handlers"
 	"search_service/internal/search_server/service"
 	"shared/inmemory_cache"
@@ -17,10 +22,12 @@ import (
 // SearchServiceDependencies содержит все общие зависимости
 type SearchServiceDependencies struct {
 	Config              *configs.SearchServiceConfig
-	SearchCache         search_interfaces.CacheInterface
-	VacancyIndex        search_interfaces.CacheInterface
-	VacancyDetails      search_interfaces.CacheInterface
+	SearchCache         search_interfaces.CacheInterface[[]models.SearchVacanciesResult]
+	VacancyIndex        search_interfaces.CacheInterface[models.VacancyIndex]
+	VacancyDetails      search_interfaces.CacheInterface[models.SearchVacancyDetailesResult]
+	SearchJobs          search_interfaces.CacheInterface[models.SearchJobInfo]
 	ParserFactory       *parser.ParserFactory
+	Locations           *locations.Dictionary
 	ParserStatusManager *parsers_status_manager.ParserStatusManager
 	ParserManager       *parsers_manager.ParsersManager
 	SearchHandler       *handlers.SearchHandler
@@ -38,34 +45,92 @@ func InitDependencies() (*SearchServiceDependencies, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	//создаём экземпляр inmemory cache для результатов поиска вакансий
-	searchCache, err := inmemory_cache.NewInmemoryShardedCache(conf.Cache.NumOfShards, conf.Cache.SearchCacheConfig.SearchCacheCleanUp)
+	// подключение к Redis - только если в нём хранится какой-то из кэшей или счётчики квот
+	redisConn := &redisConnection{}
+
+	//создаём кэш для результатов поиска вакансий
+	searchCache, err := newCache[[]models.SearchVacanciesResult](conf.Cache, cacheSpec{
+		name:    parsers_manager.CacheNameSearch,
+		backend: conf.Cache.SearchCacheConfig.SearchCacheBackend,
+		cleanUp: conf.Cache.SearchCacheConfig.SearchCacheCleanUp,
+	}, redisConn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create search cache: %w", err)
 	}
 
-	//создаём экземпляр inmemory cache для обратного индекса для вакансий
-	vacancyIndex, err := inmemory_cache.NewInmemoryShardedCache(conf.Cache.NumOfShards, conf.Cache.VacancyCacheConfig.VacancyCacheCleanUp)
+	//создаём кэш для обратного индекса для вакансий
+	vacancyIndex, err := newCache[models.VacancyIndex](conf.Cache, cacheSpec{
+		name:    parsers_manager.CacheNameVacancyIndex,
+		backend: conf.Cache.VacancyCacheConfig.VacancyCacheBackend,
+		cleanUp: conf.Cache.VacancyCacheConfig.VacancyCacheCleanUp,
+	}, redisConn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create vacancy index cache: %w", err)
 	}
 
-	// создаём экземпляр inmemory cache для деталей конкретной вакансии (ключ: ID вакансии)
-	vacancyDetails, err := inmemory_cache.NewInmemoryShardedCache(conf.Cache.NumOfShards, conf.Cache.VacancyCacheConfig.VacancyCacheCleanUp)
+	// создаём кэш для деталей конкретной вакансии (ключ: составной индекс источника и ID вакансии)
+	vacancyDetails, err := newCache[models.SearchVacancyDetailesResult](conf.Cache, cacheSpec{
+		name:    parsers_manager.CacheNameVacancyDetails,
+		backend: conf.Cache.VacancyDetailsCacheConfig.VacDetCacheBackend,
+		cleanUp: conf.Cache.VacancyCacheConfig.VacancyCacheCleanUp,
+	}, redisConn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create vacancy details cache: %w", err)
 	}
 
+	// восстанавливаем инмэмори кэши из снимков на диске, чтобы после перезапуска не идти сразу в источники
+	restoreCacheSnapshots(conf.Cache.SnapshotConfig, map[string]interface{}{
+		parsers_manager.CacheNameSearch:         searchCache,
+		parsers_manager.CacheNameVacancyIndex:   vacancyIndex,
+		parsers_manager.CacheNameVacancyDetails: vacancyDetails,
+	})
+
+	// создаём экземпляр inmemory cache для состояния и результатов асинхронных поисков (ключ: ID джобы)
+	// без лимитов размера: вытеснение незавершённого поиска потеряло бы его состояние, а размер и так ограничен очередью и TTL
+	searchJobs, err := inmemory_cache.NewShardedCache(inmemory_cache.ShardedCacheConfig[string, models.SearchJobInfo]{
+		NumShards:       conf.Cache.NumOfShards,
+		CleanUpInterval: conf.Cache.SearchJobsCacheConfig.SearchJobsCleanUp,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search jobs cache: %w", err)
+	}
+
+	// создаём справочник локаций (до загрузки с API источников работает встроенный снимок)
+	locationDictionary, err := locations.NewDictionary(conf.Locations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create locations dictionary: %w", err)
+	}
+
+	// создаём кэш для готовых ответов справочников
+	dictionaryCache, err := newCache[dto.DictionaryResponse](conf.Cache, cacheSpec{
+		name:    "dictionary_cache",
+		backend: conf.Cache.DictionaryCacheConfig.DictionaryCacheBackend,
+		cleanUp: conf.Cache.DictionaryCacheConfig.DictionaryCacheCleanUp,
+	}, redisConn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dictionary cache: %w", err)
+	}
+
 	//создаём фабрику парсеров
 	parserFactory := parser.NewParserFactory()
 
+	// все парсеры переводят локацию из запроса в свои коды через общий справочник
+	parserFactory.SetLocationResolver(locationDictionary)
+
 	// регистрируем парсеры в фабрике
 	// НЕ ВЫЗЫВАЕМ функцию, а передаем ее как значение!
 	parserFactory.Register("hh", conf.Parsers.HH, parser.NewHHParser)
 	parserFactory.Register("superjob", conf.Parsers.SuperJob, parser.NewSJParser)
+	parserFactory.Register("habr", conf.Parsers.Habr, parser.NewHabrParser)
+	parserFactory.Register("zarplata", conf.Parsers.Zarplata, parser.NewZarplataParser)
 
 	// создаём список парсеров для создания (пока хард-код, но в будущем это будут переменные)
-	enabledParsers := []parser.ParserType{"hh", "superjob"}
+	enabledParsers := []parser.ParserType{"hh", "superjob", "habr", "zarplata"}
+
+	// регистрируем декларативные парсеры, парсеры лент и HTML страниц из конфига и добавляем включённые в список для создания
+	enabledParsers = append(enabledParsers, parserFactory.RegisterMapped(conf.Parsers.Mapped)...)
+	enabledParsers = append(enabledParsers, parserFactory.RegisterFeeds(conf.Parsers.Feeds)...)
+	enabledParsers = append(enabledParsers, parserFactory.RegisterScrapers(conf.Parsers.Scrapers)...)
 
 	// создаём только те парсеры, у которых в конфиге указано Enabled
 	parsers, err := parserFactory.CreateEnabled(enabledParsers)
@@ -73,20 +138,34 @@ func InitDependencies() (*SearchServiceDependencies, error) {
 		return nil, fmt.Errorf("failed to create enabled parsers: %w", err)
 	}
 
+	// запускаем загрузку и обновление справочников локаций (с ключами и заголовками парсеров)
+	locationDictionary.Start(parsers...)
+
 	// создаём мэнеджера состояния парсеров и инициализируем начальными значениями
 	parserStatusManager := parsers_status_manager.NewParserStatusManager(conf, parsers...)
 
 	// Создаём менеджер парсеров
-	parserManager, err := parsers_manager.NewParserManager(conf, currentMaxProcs, searchCache, vacancyIndex, vacancyDetails, parserStatusManager, parsers...)
+	parserManager, err := parsers_manager.NewParserManager(conf, currentMaxProcs, searchCache, vacancyIndex, vacancyDetails, searchJobs, parserStatusManager, parsers...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create parser manager: %w", err)
 	}
 
+	// текущая загрузка менеджера парсеров и статистика кэшей снимаются при каждом сборе метрик
+	if err := metrics.Register(parserManager); err != nil {
+		return nil, fmt.Errorf("failed to register metrics: %w", err)
+	}
+
 	// создаём поисковый сервис
-	searchService := service.NewSearchService(parserManager)
+	searchService := service.NewSearchService(parserManager, locationDictionary)
+
+	// создаём проверку квот пользователей (счётчики в памяти или в Redis)
+	quotaLimiter, err := newQuotaLimiter(conf.Quotas, redisConn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quota limiter: %w", err)
+	}
 
 	// создаём хэндлер поиска
-	searchHandler := handlers.NewSearchHandler(searchService)
+	searchHandler := handlers.NewSearchHandler(searchService, dictionaryCache, conf.Cache.DictionaryCacheConfig.DictionaryCacheTTL, quotaLimiter)
 
 	// возвращаем указатель на структуру зависимостей
 	return &SearchServiceDependencies{
@@ -94,9 +173,30 @@ func InitDependencies() (*SearchServiceDependencies, error) {
 		SearchCache:         searchCache,
 		VacancyIndex:        vacancyIndex,
 		VacancyDetails:      vacancyDetails,
+		SearchJobs:          searchJobs,
 		ParserFactory:       parserFactory,
+		Locations:           locationDictionary,
 		ParserStatusManager: parserStatusManager,
 		ParserManager:       parserManager,
 		SearchHandler:       searchHandler,
 	}, nil
 }
+
+// функция создания проверки квот пользователей
+// для нескольких реплик счётчики хранятся в Redis, иначе - в памяти
+func newQuotaLimiter(cfg *configs.QuotaConfig, redisConn *redisConnection) (*quota.Limiter, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	if cfg.Store != configs.QuotaStoreRedis {
+		return quota.NewLimiter(cfg, quota.NewMemoryCounter()), nil
+	}
+
+	redisCache, err := redisConn.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return quota.NewLimiter(cfg, redisCache), nil
+}
@@ -0,0 +1,114 @@
+// конвертер валют по статическим курсам из конфига: приводит зарплаты разных источников к одной валюте и периоду
+package currency
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/pkg"
+)
+
+// во сколько раз зарплата за месяц больше зарплаты за период (40-часовая неделя, 21 рабочий день)
+var periodsPerMonth = map[string]float64{
+	models.SalaryPeriodHour:  164,
+	models.SalaryPeriodDay:   21,
+	models.SalaryPeriodMonth: 1,
+	models.SalaryPeriodYear:  1.0 / 12,
+}
+
+// структура конвертера валют
+type Converter struct {
+	base  string
+	rates map[string]float64 // сколько единиц базовой валюты стоит 1 единица валюты
+}
+
+// конструктор конвертера валют
+func NewConverter(cfg *configs.CurrencyConfig) (*Converter, error) {
+	if cfg == nil {
+		cfg = configs.DefaultCurrencyConfig()
+	}
+
+	base := pkg.NormalizeCurrency(cfg.BaseCurrency)
+	if base == "" {
+		return nil, fmt.Errorf("currency config: base_currency is required")
+	}
+
+	rates := make(map[string]float64, len(cfg.Rates)+1)
+	for code, rate := range cfg.Rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("currency config: rate for %s must be positive, got %v", code, rate)
+		}
+		rates[pkg.NormalizeCurrency(code)] = rate
+	}
+
+	// базовая валюта всегда конвертируется сама в себя
+	if rate, ok := rates[base]; ok && rate != 1 {
+		return nil, fmt.Errorf("currency config: rate for base currency %s must be 1, got %v", base, rate)
+	}
+	rates[base] = 1
+
+	return &Converter{
+		base:  base,
+		rates: rates,
+	}, nil
+}
+
+// метод получения базовой валюты
+func (c *Converter) Base() string {
+	return c.base
+}
+
+// метод получения кодов всех известных валют: базовая - первая, остальные - по алфавиту
+func (c *Converter) Currencies() []string {
+	codes := make([]string, 0, len(c.rates))
+	for code := range c.rates {
+		if code != c.base {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return append([]string{c.base}, codes...)
+}
+
+// метод перевода суммы в базовую валюту; false - курс валюты неизвестен
+func (c *Converter) ToBase(amount int, currency string) (int, bool) {
+	currency = pkg.NormalizeCurrency(currency)
+	if currency == "" {
+		// источники, которые не указывают валюту, работают в базовой валюте
+		currency = c.base
+	}
+
+	rate, ok := c.rates[currency]
+	if !ok {
+		return 0, false
+	}
+	return int(math.Round(float64(amount) * rate)), true
+}
+
+// метод получения середины вилки зарплаты за месяц в базовой валюте (0 - зарплата не указана или курс неизвестен)
+// для nil конвертера пересчёт не делается - сравниваются исходные суммы
+func (c *Converter) MonthlyInBase(salary models.Salary) int {
+	middle := salary.Middle()
+	if middle == 0 {
+		return 0
+	}
+
+	perMonth, ok := periodsPerMonth[salary.Period]
+	if !ok {
+		perMonth = 1
+	}
+	monthly := int(math.Round(float64(middle) * perMonth))
+
+	if c == nil {
+		return monthly
+	}
+
+	converted, ok := c.ToBase(monthly, salary.Currency)
+	if !ok {
+		return 0
+	}
+	return converted
+}
@@ -0,0 +1,85 @@
+package currency
+
+import (
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"testing"
+)
+
+func TestNewConverter_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *configs.CurrencyConfig
+	}{
+		{name: "без базовой валюты", cfg: &configs.CurrencyConfig{Rates: map[string]float64{"USD": 90}}},
+		{name: "отрицательный курс", cfg: &configs.CurrencyConfig{BaseCurrency: "RUB", Rates: map[string]float64{"USD": -1}}},
+		{name: "курс базовой валюты не 1", cfg: &configs.CurrencyConfig{BaseCurrency: "RUB", Rates: map[string]float64{"RUB": 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewConverter(tt.cfg); err == nil {
+				t.Error("ожидалась ошибка конфига")
+			}
+		})
+	}
+
+	// без конфига используются курсы по умолчанию
+	converter, err := NewConverter(nil)
+	if err != nil || converter.Base() != "RUB" {
+		t.Fatalf("ожидался конвертер по умолчанию с базой RUB, получено %v, %v", converter, err)
+	}
+}
+
+func TestConverter_MonthlyInBase(t *testing.T) {
+	converter, err := NewConverter(&configs.CurrencyConfig{
+		BaseCurrency: "rur",
+		Rates:        map[string]float64{"usd": 90, "EUR": 100},
+	})
+	if err != nil {
+		t.Fatalf("ошибка создания конвертера: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		salary models.Salary
+		want   int
+	}{
+		{name: "рубли за месяц", salary: models.Salary{From: 100000, To: 200000, Currency: "RUR", Period: models.SalaryPeriodMonth}, want: 150000},
+		{name: "доллары", salary: models.Salary{From: 2000, Currency: "USD"}, want: 180000},
+		{name: "евро в год", salary: models.Salary{To: 120000, Currency: "eur", Period: models.SalaryPeriodYear}, want: 1000000},
+		{name: "рубли в час", salary: models.Salary{From: 1000, Currency: "RUB", Period: models.SalaryPeriodHour}, want: 164000},
+		{name: "валюта не указана - базовая", salary: models.Salary{From: 50000}, want: 50000},
+		{name: "неизвестная валюта", salary: models.Salary{From: 50000, Currency: "JPY"}, want: 0},
+		{name: "зарплата не указана", salary: models.Salary{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := converter.MonthlyInBase(tt.salary); got != tt.want {
+				t.Errorf("ожидалось %d, получено %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConverter_Currencies(t *testing.T) {
+	converter, err := NewConverter(&configs.CurrencyConfig{
+		BaseCurrency: "RUB",
+		Rates:        map[string]float64{"usd": 90, "EUR": 98, "KZT": 0.18},
+	})
+	if err != nil {
+		t.Fatalf("ошибка создания конвертера: %v", err)
+	}
+
+	got := converter.Currencies()
+	want := []string{"RUB", "EUR", "KZT", "USD"}
+	if len(got) != len(want) {
+		t.Fatalf("ожидалось %v, получено %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ожидалось %v, получено %v", want, got)
+		}
+	}
+}
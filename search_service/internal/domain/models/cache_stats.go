@@ -0,0 +1,22 @@
+package models
+
+// CacheStats - статистика одного кэша сервиса
+type CacheStats struct {
+	Name       string
+	Available  bool // false - кэш не ведёт статистику (например, кэш в Redis)
+	Hits       int64
+	StaleHits  int64
+	Misses     int64
+	HitRatio   float64 // доля обращений, на которые нашлось значение (вместе с устаревшими)
+	Evictions  int64   // вытеснено из-за лимитов размера
+	Expired    int64   // удалено истёкших элементов
+	Entries    int
+	Bytes      int64 // примерный объём элементов (0 - объём не ограничен и не считается)
+	ShardSizes []int
+}
+
+// CachePurgeResult - результат очистки кэшей
+type CachePurgeResult struct {
+	Removed     map[string]int // сколько элементов удалено из каждого кэша
+	Unsupported []string       // кэши, которые не умеют такую очистку (например, кэши в Redis)
+}
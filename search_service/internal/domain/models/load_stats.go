@@ -0,0 +1,21 @@
+package models
+
+// LoadStats - текущая загрузка менеджера парсеров
+type LoadStats struct {
+	QueueDepth     int    // джоб в очереди
+	QueueCapacity  int    // ёмкость очереди
+	Workers        int    // всего воркеров
+	BusyWorkers    int    // воркеров, которые обрабатывают джобу
+	SemaphoreInUse int    // занятых слотов глобального семафора
+	SemaphoreSize  int    // размер глобального семафора
+	CircuitBreaker string // состояние глобального circuit breaker (closed, open, half_open)
+	Parsers        []ParserLoadStats
+}
+
+// ParserLoadStats - текущая загрузка парсера источника
+type ParserLoadStats struct {
+	Name           string
+	SemaphoreInUse int    // занятых слотов семафора парсера
+	SemaphoreSize  int    // размер семафора парсера
+	CircuitBreaker string // состояние circuit breaker парсера (closed, open, half_open)
+}
@@ -0,0 +1,16 @@
+package models
+
+// виды локаций в справочниках источников
+const (
+	LocationCountry = "country"
+	LocationRegion  = "region"
+	LocationCity    = "city"
+)
+
+// Location - локация (страна, регион или город) в справочнике одного источника
+type Location struct {
+	Code       string `json:"code"`             // код локации у источника
+	Name       string `json:"name"`             // название локации
+	Kind       string `json:"kind"`             // country, region, city
+	ParentCode string `json:"parent,omitempty"` // код родительской локации (для региона - страна, для города - регион или страна)
+}
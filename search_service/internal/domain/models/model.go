@@ -4,10 +4,22 @@ import "time"
 
 // общая структура поиска
 type SearchParams struct {
-	Text    string
-	Country string
-	PerPage int
-	Page    int
+	Text     string
+	Country  string
+	PerPage  int
+	Page     int
+	MaxPages int // сколько страниц подряд (начиная с Page) запросить у каждого источника, 0 или 1 - одна страница
+
+	// фильтры поиска (коды - в search_filters.go), пустое значение - фильтр не задан
+	SalaryFrom          int    // зарплата не ниже
+	OnlyWithSalary      bool   // только вакансии с указанной зарплатой
+	Experience          string // опыт работы
+	Schedule            string // график работы
+	Employment          string // тип занятости
+	PublishedWithinDays int    // опубликованы не раньше, чем N дней назад
+	OrderBy             string // сортировка выдачи
+
+	DisableDedup bool // не объединять одинаковые вакансии из разных источников
 }
 
 // Стуктура общей вакансии для всех ответов
@@ -15,24 +27,59 @@ type Vacancy struct {
 	ID          string
 	Job         string
 	Company     string
-	Salary      *string
-	Currency    string
+	Salary      Salary
 	Location    string
 	Experience  string
 	Schedule    string
+	Employment  string
 	URL         string
 	Source      string // "hh", "superjob", ...
 	Description string
 	PublishedAt time.Time
+
+	// все источники, где найдена эта вакансия (заполняется только при объединении дублей, первым идёт сама вакансия)
+	Sources []VacancySource
+}
+
+// Структура ссылки на вакансию в одном из источников
+type VacancySource struct {
+	Source string
+	ID     string
+	URL    string
+}
+
+// метод проверки, что вакансия (или один из объединённых с ней дублей) имеет заданный источник и ID
+func (v Vacancy) HasSourceID(source, id string) bool {
+	if v.Source == source && v.ID == id {
+		return true
+	}
+	for _, s := range v.Sources {
+		if s.Source == source && s.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Структура одной страницы результатов поиска у источника
+type SearchPage struct {
+	Vacancies []Vacancy
+	Found     int // сколько всего вакансий нашёл источник (0 - источник не сообщает)
+	Pages     int // сколько всего страниц доступно у источника (0 - источник не сообщает)
 }
 
 // Структура для определния результатов поиска списка вакансий по всем доступным парсерам
 type SearchVacanciesResult struct {
-	Vacancies  []Vacancy
-	ParserName string
-	SearchHash string
-	Error      error
-	Duration   time.Duration
+	Vacancies    []Vacancy
+	ParserName   string
+	SearchHash   string
+	Error        error
+	Duration     time.Duration
+	Found        int // сколько всего вакансий нашёл источник
+	Pages        int // сколько всего страниц доступно у источника
+	Page         int // номер первой полученной страницы
+	PagesFetched int // сколько страниц подряд получено за этот поиск
+	Merged       int // сколько вакансий источника объединено с такими же вакансиями других источников
 }
 
 // Employer представляет информацию о работодателе
@@ -47,14 +94,6 @@ type Area struct {
 	Name string
 }
 
-// Salary представляет информацию о зарплате
-type Salary struct {
-	From     int
-	To       int
-	Currency string
-	Gross    bool
-}
-
 // Структура для определния результатов поиска деталей конкретной вакансии
 type SearchVacancyDetailesResult struct {
 	Employer    Employer
@@ -65,3 +104,22 @@ type SearchVacancyDetailesResult struct {
 	ID          string
 	Url         string
 }
+
+// Структура вакансии в общем списке со всех источников вместе с её оценкой релевантности
+type RankedVacancy struct {
+	Vacancy
+	Score float64 // оценка релевантности от 0 до 1
+}
+
+// Структура общего (объединённого по всем источникам) списка вакансий с пагинацией
+type MergedSearchResult struct {
+	Vacancies []RankedVacancy // вакансии запрошенной страницы общего списка
+	Total     int             // сколько вакансий в общем списке
+	Page      int             // номер страницы общего списка (с 1)
+	PerPage   int             // размер страницы общего списка
+	Pages     int             // сколько всего страниц в общем списке
+	OrderBy   string          // по какому правилу отсортирован список
+
+	// результаты источников, по которым строился список (для сводки: время, ошибки, количество найденного)
+	Sources []SearchVacanciesResult
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"context"
+	"strings"
+)
+
+// роль администратора: открывает служебные эндпоинты (статистика и очистка кэшей)
+const RoleAdmin = "admin"
+
+// Requester - пользователь, от имени которого выполняется запрос (заголовки X-User-ID и X-User-Roles от nginx)
+type Requester struct {
+	UserID string
+	Roles  []string
+}
+
+// метод проверки наличия роли у пользователя
+func (r Requester) HasRole(role string) bool {
+	for _, userRole := range r.Roles {
+		if strings.EqualFold(userRole, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// функция разбора заголовка ролей пользователя ("user, premium")
+func ParseRoles(header string) []string {
+	var roles []string
+	for _, role := range strings.Split(header, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// ключ пользователя в контексте запроса
+type requesterKey struct{}
+
+// функция сохранения пользователя в контексте запроса
+func WithRequester(ctx context.Context, requester Requester) context.Context {
+	return context.WithValue(ctx, requesterKey{}, requester)
+}
+
+// функция получения пользователя из контекста запроса (пустой Requester - пользователь неизвестен)
+func RequesterFromContext(ctx context.Context) Requester {
+	if ctx == nil {
+		return Requester{}
+	}
+	requester, _ := ctx.Value(requesterKey{}).(Requester)
+	return requester
+}
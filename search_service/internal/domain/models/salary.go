@@ -0,0 +1,51 @@
+package models
+
+import "strings"
+
+// период, за который указана зарплата
+const (
+	SalaryPeriodHour  = "hour"
+	SalaryPeriodDay   = "day"
+	SalaryPeriodMonth = "month"
+	SalaryPeriodYear  = "year"
+)
+
+// Salary представляет информацию о зарплате
+type Salary struct {
+	From     int    // нижняя граница (0 - не указана)
+	To       int    // верхняя граница (0 - не указана)
+	Currency string // код валюты ISO 4217 ("RUB", "USD", ...)
+	Gross    bool   // true - до вычета налогов, false - на руки (или источник не сообщает)
+	Period   string // за какой период указана зарплата (SalaryPeriod*), пусто - за месяц
+}
+
+// метод проверки, что зарплата указана
+func (s Salary) Specified() bool {
+	return s.From > 0 || s.To > 0
+}
+
+// метод получения середины вилки зарплаты (0 - зарплата не указана)
+func (s Salary) Middle() int {
+	switch {
+	case s.From > 0 && s.To > 0:
+		return (s.From + s.To) / 2
+	case s.From > 0:
+		return s.From
+	default:
+		return s.To
+	}
+}
+
+// функция приведения периода зарплаты из ответа источника к общему виду (неизвестный период - месяц)
+func NormalizeSalaryPeriod(period string) string {
+	switch strings.ToLower(strings.TrimSpace(period)) {
+	case "hour", "hourly", "час", "в час":
+		return SalaryPeriodHour
+	case "day", "daily", "shift", "день", "в день", "смена", "за смену":
+		return SalaryPeriodDay
+	case "year", "yearly", "annual", "annually", "год", "в год":
+		return SalaryPeriodYear
+	default:
+		return SalaryPeriodMonth
+	}
+}
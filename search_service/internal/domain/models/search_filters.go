@@ -0,0 +1,37 @@
+package models
+
+// универсальные коды фильтров поиска (совпадают со справочниками HH.ru, остальные источники переводят их в свои параметры)
+
+// опыт работы
+const (
+	ExperienceNone      = "noExperience"
+	Experience1To3      = "between1And3"
+	Experience3To6      = "between3And6"
+	ExperienceMoreThan6 = "moreThan6"
+)
+
+// график работы
+const (
+	ScheduleFullDay     = "fullDay"
+	ScheduleShift       = "shift"
+	ScheduleFlexible    = "flexible"
+	ScheduleRemote      = "remote"
+	ScheduleFlyInFlyOut = "flyInFlyOut"
+)
+
+// тип занятости
+const (
+	EmploymentFull      = "full"
+	EmploymentPart      = "part"
+	EmploymentProject   = "project"
+	EmploymentVolunteer = "volunteer"
+	EmploymentProbation = "probation"
+)
+
+// сортировка выдачи
+const (
+	OrderRelevance  = "relevance"
+	OrderDate       = "date"
+	OrderSalaryDesc = "salary_desc"
+	OrderSalaryAsc  = "salary_asc"
+)
@@ -19,3 +19,30 @@ type JobSearchVacanciesResult struct {
 func (s *SearchVacanciesJob) GetID() string {
 	return s.ID
 }
+
+// статусы асинхронного поиска вакансий
+const (
+	SearchJobQueued   = "queued"   // джоба ждёт воркера в очереди
+	SearchJobRunning  = "running"  // воркер ищет вакансии
+	SearchJobDone     = "done"     // поиск завершён, результаты готовы
+	SearchJobFailed   = "failed"   // поиск не удался
+	SearchJobCanceled = "canceled" // поиск отменён клиентом
+)
+
+// SearchJobInfo - состояние асинхронного поиска вакансий
+type SearchJobInfo struct {
+	ID         string
+	UserID     string // пользователь, поставивший поиск (состояние и результаты доступны только ему)
+	Status     string
+	Params     SearchParams
+	CreatedAt  time.Time
+	StartedAt  time.Time               // когда воркер взял джобу (нулевое время - ещё в очереди)
+	FinishedAt time.Time               // когда поиск завершился (нулевое время - ещё не завершился)
+	Results    []SearchVacanciesResult // результаты поиска (только для done)
+	Error      error                   // причина неудачи (только для failed и canceled)
+}
+
+// метод проверки, завершён ли поиск (дальше состояние не меняется)
+func (i SearchJobInfo) Finished() bool {
+	return i.Status == SearchJobDone || i.Status == SearchJobFailed || i.Status == SearchJobCanceled
+}
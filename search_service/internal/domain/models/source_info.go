@@ -0,0 +1,7 @@
+package models
+
+// SourceInfo - описание источника вакансий для справочника источников
+type SourceInfo struct {
+	Name            string // имя источника (как в результатах поиска)
+	LocationCatalog string // справочник локаций источника ("" - локация передаётся текстом)
+}
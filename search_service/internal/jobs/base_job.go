@@ -19,6 +19,8 @@ type BaseJob struct {
 	ID         string
 	ResultChan chan *JobOutput // обязательно при создании экземплярар джобы нужно делать буферизированный канал, 1
 	CreatedAt  time.Time
+	UserID     string // пользователь, поставивший джобу (очередь обслуживает пользователей по кругу)
+	Priority   int    // приоритет джобы в очереди (чем больше, тем раньше)
 	notified   sync.Once
 }
 
@@ -31,8 +33,11 @@ func (j *BaseJob) Complete(data interface{}, err error) {
 				log.Printf("job %s: канал закрыт при отправке результата", j.ID)
 			}
 		}()
+		// в канал отправляется ровно один результат: второй не поместился бы в буфер,
+		// и воркер завис бы, если ожидающий уже ушёл по таймауту
 		if err == nil {
 			j.ResultChan <- &JobOutput{Success: true, Data: data, Error: err}
+			return
 		}
 
 		j.ResultChan <- &JobOutput{Success: false, Data: data, Error: err}
@@ -43,3 +48,13 @@ func (j *BaseJob) Complete(data interface{}, err error) {
 func (j *BaseJob) GetID() string {
 	return j.ID
 }
+
+// возвращает пользователя, поставившего джобу
+func (j *BaseJob) GetUserID() string {
+	return j.UserID
+}
+
+// возвращает приоритет джобы в очереди
+func (j *BaseJob) GetPriority() int {
+	return j.Priority
+}
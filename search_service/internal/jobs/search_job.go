@@ -1,9 +1,30 @@
 package jobs
 
-import "search_service/internal/domain/models"
+import (
+	"context"
+	"search_service/internal/domain/models"
+)
 
 // SearchJob - джоба для поиска вакансий
 type SearchJob struct {
 	BaseJob
 	Params models.SearchParams
+
+	// необязательный канал для потокового поиска: сюда отправляется результат каждого источника, как только он готов
+	// канал должен быть буферизирован на число источников - воркер не ждёт читателя и пропускает результат, если буфер полон
+	Progress chan<- models.SearchVacanciesResult
+
+	// фоновое обновление устаревших результатов в кэше: поиск идёт в источники мимо кэша, результат никто не ждёт
+	Refresh bool
+
+	// необязательный контекст выполнения (асинхронный поиск): отмена прерывает поиск или снимает джобу, которая ещё в очереди
+	Ctx context.Context
+}
+
+// метод получения контекста выполнения джобы (без контекста - context.Background())
+func (j *SearchJob) Context() context.Context {
+	if j.Ctx == nil {
+		return context.Background()
+	}
+	return j.Ctx
 }
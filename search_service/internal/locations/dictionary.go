@@ -0,0 +1,345 @@
+// справочник локаций: переводит город, регион или страну из запроса в коды каждого источника
+// справочники загружаются с API источников и периодически обновляются, до первой загрузки используется встроенный снимок
+package locations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+	"search_service/pkg"
+)
+
+// имена справочников локаций (несколько источников могут пользоваться одним справочником)
+const (
+	CatalogHH       = "hh"       // дерево регионов HH.ru, им же пользуется Зарплата.ру
+	CatalogSuperJob = "superjob" // страны, регионы и города SuperJob
+	CatalogHabr     = "habr"     // города Habr Career (только встроенный снимок, публичного API справочника нет)
+)
+
+// сокращения и разговорные названия, которые пишут в запросах (ключ и значение - в нормализованном виде)
+var locationAliases = map[string]string{
+	"мск":        "москва",
+	"спб":        "санкт петербург",
+	"питер":      "санкт петербург",
+	"екб":        "екатеринбург",
+	"нск":        "новосибирск",
+	"нн":         "нижний новгород",
+	"рф":         "россия",
+	"белоруссия": "беларусь",
+	"киргизия":   "кыргызстан",
+	"кыргызтан":  "кыргызстан",
+}
+
+// слова, которые не влияют на поиск локации ("г. Москва", "город Казань")
+var locationNoiseWords = map[string]bool{
+	"г":     true,
+	"гор":   true,
+	"город": true,
+}
+
+// сокращения внутри названий ("Московская обл." -> "московская область")
+var locationWordAliases = map[string]string{
+	"обл":  "область",
+	"респ": "республика",
+}
+
+// ранг вида локации: при совпадении названий предпочитаем более крупную локацию
+var locationKindRank = map[string]int{
+	models.LocationCountry: 0,
+	models.LocationRegion:  1,
+	models.LocationCity:    2,
+}
+
+// справочник локаций одного источника
+type catalog struct {
+	locations []models.Location          // все локации справочника в порядке источника
+	index     map[string]models.Location // нормализованное название -> локация
+	updatedAt time.Time                  // когда справочник загружен с API источника (нулевое время - встроенный снимок)
+}
+
+// функция загрузки справочника с API источника
+type catalogLoader func(ctx context.Context, fetch fetchFunc) ([]models.Location, error)
+
+// функция получения JSON ответа источника в target
+type fetchFunc func(ctx context.Context, url string, target interface{}) error
+
+// Dictionary - справочник локаций всех источников
+type Dictionary struct {
+	catalogs   map[string]*catalog                           // справочники по имени
+	loaders    map[string]catalogLoader                      // загрузчики справочников с API источников
+	decorators map[string]search_interfaces.RequestDecorator // оформление запросов к API (ключи, заголовки) по имени справочника
+	client     *http.Client                                  // клиент для загрузки справочников
+	timeout    time.Duration                                 // таймаут загрузки одного справочника
+	interval   time.Duration                                 // интервал обновления справочников
+	stopChan   chan struct{}
+	stopOnce   sync.Once
+	wg         sync.WaitGroup
+	mu         sync.RWMutex
+}
+
+// конструктор справочника локаций: сразу готов к работе на встроенном снимке, загрузка с API - после Start
+func NewDictionary(cfg *configs.LocationsConfig) (*Dictionary, error) {
+	if cfg == nil {
+		cfg = configs.DefaultLocationsConfig()
+	}
+
+	snapshot, err := loadSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load locations snapshot: %w", err)
+	}
+
+	d := &Dictionary{
+		catalogs:   make(map[string]*catalog, len(snapshot)),
+		loaders:    newLoaders(cfg),
+		decorators: make(map[string]search_interfaces.RequestDecorator),
+		client:     &http.Client{Timeout: cfg.RequestTimeout},
+		timeout:    cfg.RequestTimeout,
+		interval:   cfg.RefreshInterval,
+		stopChan:   make(chan struct{}),
+	}
+	for name, locations := range snapshot {
+		d.catalogs[name] = newCatalog(locations, time.Time{})
+	}
+
+	return d, nil
+}
+
+var (
+	snapshotDictionary *Dictionary
+	snapshotOnce       sync.Once
+)
+
+// Snapshot - справочник только на встроенном снимке (без загрузки с API)
+// используется парсерами, которым не передали общий справочник (например, в тестах)
+func Snapshot() *Dictionary {
+	snapshotOnce.Do(func() {
+		d, err := NewDictionary(&configs.LocationsConfig{})
+		if err != nil {
+			fmt.Printf("⚠️ Справочник локаций недоступен: %v\n", err)
+			d = &Dictionary{catalogs: make(map[string]*catalog), stopChan: make(chan struct{})}
+		}
+		snapshotDictionary = d
+	})
+	return snapshotDictionary
+}
+
+// функция построения справочника с индексом по нормализованным названиям
+func newCatalog(locations []models.Location, updatedAt time.Time) *catalog {
+	c := &catalog{
+		locations: locations,
+		index:     make(map[string]models.Location, len(locations)),
+		updatedAt: updatedAt,
+	}
+
+	for _, location := range locations {
+		key := normalizeLocation(location.Name)
+		if key == "" {
+			continue
+		}
+		// одноимённые локации: оставляем более крупную, при равенстве - первую по порядку источника
+		if existing, ok := c.index[key]; ok && locationKindRank[existing.Kind] <= locationKindRank[location.Kind] {
+			continue
+		}
+		c.index[key] = location
+	}
+
+	return c
+}
+
+// Resolve - метод поиска локации в справочнике источника по тексту запроса ("Москва", "г. Казань", "спб", "Кировск, Мурманская обл.")
+func (d *Dictionary) Resolve(catalogName, text string) (models.Location, bool) {
+	d.mu.RLock()
+	c, ok := d.catalogs[catalogName]
+	d.mu.RUnlock()
+	if !ok {
+		return models.Location{}, false
+	}
+
+	if location, ok := c.lookup(text); ok {
+		return location, true
+	}
+
+	// "Кировск, Мурманская обл." - если целиком не нашли, ищем по первой части
+	if first, _, found := strings.Cut(text, ","); found {
+		return c.lookup(first)
+	}
+	return models.Location{}, false
+}
+
+// метод поиска локации по нормализованному названию с учётом сокращений
+func (c *catalog) lookup(text string) (models.Location, bool) {
+	key := normalizeLocation(text)
+	if alias, ok := locationAliases[key]; ok {
+		key = alias
+	}
+	location, ok := c.index[key]
+	return location, ok
+}
+
+// функция нормализации названия локации для сравнения
+func normalizeLocation(name string) string {
+	words := pkg.NormalizedWords(name)
+	normalized := words[:0]
+	for _, word := range words {
+		if locationNoiseWords[word] {
+			continue
+		}
+		if alias, ok := locationWordAliases[word]; ok {
+			word = alias
+		}
+		normalized = append(normalized, word)
+	}
+	return strings.Join(normalized, " ")
+}
+
+// Start - метод запуска загрузки и периодического обновления справочников с API источников
+// запросы к API оформляются так же, как запросы парсеров, которые пользуются справочником (ключи, заголовки)
+func (d *Dictionary) Start(parsers ...search_interfaces.Parser) {
+	d.mu.Lock()
+	for _, parser := range parsers {
+		aware, ok := parser.(search_interfaces.LocationAwareParser)
+		if !ok || aware.LocationCatalog() == "" {
+			continue
+		}
+		decorator, ok := parser.(search_interfaces.RequestDecorator)
+		if _, exists := d.decorators[aware.LocationCatalog()]; ok && !exists {
+			d.decorators[aware.LocationCatalog()] = decorator
+		}
+	}
+	d.mu.Unlock()
+
+	if len(d.loaders) == 0 {
+		return
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		// остановка прерывает и загрузку, которая идёт в этот момент
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-d.stopChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		// первая загрузка - в фоне, пока она идёт, работает встроенный снимок
+		d.Refresh(ctx)
+
+		if d.interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				d.Refresh(ctx)
+			case <-d.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Refresh - метод загрузки всех справочников с API источников
+// если справочник не загрузился - продолжаем пользоваться предыдущей версией (или снимком)
+func (d *Dictionary) Refresh(ctx context.Context) {
+	for name, load := range d.loaders {
+		if ctx.Err() != nil {
+			return
+		}
+
+		loadCtx, cancel := context.WithTimeout(ctx, d.timeout)
+		locations, err := load(loadCtx, d.fetchFunc(name))
+		cancel()
+
+		if err != nil {
+			fmt.Printf("⚠️ Справочник локаций %s не обновлён: %v\n", name, err)
+			continue
+		}
+		if len(locations) == 0 {
+			fmt.Printf("⚠️ Справочник локаций %s не обновлён: источник вернул пустой справочник\n", name)
+			continue
+		}
+
+		d.mu.Lock()
+		d.catalogs[name] = newCatalog(locations, time.Now())
+		d.mu.Unlock()
+	}
+}
+
+// метод получения функции запроса к API источника для справочника
+func (d *Dictionary) fetchFunc(catalogName string) fetchFunc {
+	d.mu.RLock()
+	decorator := d.decorators[catalogName]
+	d.mu.RUnlock()
+
+	return func(ctx context.Context, url string, target interface{}) error {
+		return fetchJSON(ctx, d.client, decorator, url, target)
+	}
+}
+
+// Search - метод поиска локаций справочника для выпадающих списков клиента
+// query - начало названия (пусто - все), kind и parent - необязательные фильтры, limit <= 0 - без ограничения
+// false - справочника с таким именем нет
+func (d *Dictionary) Search(catalogName, query, kind, parent string, limit int) ([]models.Location, bool) {
+	d.mu.RLock()
+	c, ok := d.catalogs[catalogName]
+	d.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	prefix := normalizeLocation(query)
+	found := []models.Location{}
+	for _, location := range c.locations {
+		if kind != "" && location.Kind != kind {
+			continue
+		}
+		if parent != "" && location.ParentCode != parent {
+			continue
+		}
+		if prefix != "" && !strings.HasPrefix(normalizeLocation(location.Name), prefix) {
+			continue
+		}
+
+		found = append(found, location)
+		if limit > 0 && len(found) >= limit {
+			break
+		}
+	}
+	return found, true
+}
+
+// UpdatedAt - метод получения времени последней загрузки справочника с API (нулевое время - работает встроенный снимок)
+func (d *Dictionary) UpdatedAt(catalogName string) time.Time {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if c, ok := d.catalogs[catalogName]; ok {
+		return c.updatedAt
+	}
+	return time.Time{}
+}
+
+// Stop - метод остановки периодического обновления справочников
+func (d *Dictionary) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopChan)
+	})
+	d.wg.Wait()
+}
@@ -0,0 +1,161 @@
+package locations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"testing"
+	"time"
+)
+
+// проверяем поиск локаций по встроенному снимку
+func TestDictionary_ResolveSnapshot(t *testing.T) {
+	d := Snapshot()
+
+	tests := []struct {
+		catalog string
+		text    string
+		code    string
+		kind    string
+	}{
+		{CatalogHH, "Казахстан", "40", models.LocationCountry},
+		{CatalogHH, "г. Москва", "1", models.LocationCity},
+		{CatalogHH, "спб", "2", models.LocationCity},
+		{CatalogHH, "Московская обл.", "2019", models.LocationRegion},
+		{CatalogHH, "Кыргызтан", "115", models.LocationCountry}, // старое написание из запросов
+		{CatalogHH, "Москва, Россия", "1", models.LocationCity},
+		{CatalogHH, "ростов на дону", "76", models.LocationCity},
+		{CatalogSuperJob, "Санкт-Петербург", "14", models.LocationCity},
+		{CatalogHabr, "москва", "c_678", models.LocationCity},
+	}
+
+	for _, tt := range tests {
+		location, ok := d.Resolve(tt.catalog, tt.text)
+		if !ok {
+			t.Errorf("%s: локация %q не найдена", tt.catalog, tt.text)
+			continue
+		}
+		if location.Code != tt.code || location.Kind != tt.kind {
+			t.Errorf("%s: %q - ожидалось %s (%s), получено %s (%s)", tt.catalog, tt.text, tt.code, tt.kind, location.Code, location.Kind)
+		}
+	}
+
+	if _, ok := d.Resolve(CatalogHH, "Атлантида"); ok {
+		t.Errorf("неизвестная локация не должна находиться")
+	}
+	if _, ok := d.Resolve("unknown", "Москва"); ok {
+		t.Errorf("в неизвестном справочнике ничего не должно находиться")
+	}
+}
+
+// декоратор запросов для проверки, что справочники запрашиваются с ключами источника
+type testDecorator struct{}
+
+func (testDecorator) DecorateRequest(req *http.Request) {
+	req.Header.Set("X-Api-App-Id", "test-key")
+}
+
+// проверяем загрузку справочников с API источников
+func TestDictionary_Refresh(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/areas", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "113", "name": "Россия", "areas": [
+			{"id": "1", "name": "Москва", "areas": []},
+			{"id": "1620", "name": "Республика Марий Эл", "areas": [{"id": "1621", "name": "Йошкар-Ола", "areas": []}]}
+		]}]`))
+	})
+	mux.HandleFunc("/towns", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-App-Id") != "test-key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"objects": [{"id": 4, "title": "Москва", "id_region": 0, "id_country": 1}, {"id": 2167, "title": "Тверь", "id_region": 38, "id_country": 1}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	d, err := NewDictionary(&configs.LocationsConfig{
+		RequestTimeout: 5 * time.Second,
+		HHAreasURL:     server.URL + "/areas",
+		SJTownsURL:     server.URL + "/towns",
+	})
+	if err != nil {
+		t.Fatalf("ошибка создания справочника: %v", err)
+	}
+	d.decorators[CatalogSuperJob] = testDecorator{}
+
+	d.Refresh(context.Background())
+
+	location, ok := d.Resolve(CatalogHH, "Йошкар-Ола")
+	if !ok || location.Code != "1621" || location.Kind != models.LocationCity || location.ParentCode != "1620" {
+		t.Errorf("неверная локация из дерева HH.ru: %+v", location)
+	}
+	if location, _ := d.Resolve(CatalogHH, "Марий Эл"); location.Code != "" {
+		t.Errorf("частичное совпадение названия не должно находиться: %+v", location)
+	}
+	if location, _ := d.Resolve(CatalogHH, "Республика Марий Эл"); location.Kind != models.LocationRegion {
+		t.Errorf("ожидался регион, получено %+v", location)
+	}
+	if location, _ := d.Resolve(CatalogSuperJob, "Тверь"); location.Code != "2167" || location.ParentCode != "38" {
+		t.Errorf("неверный город SuperJob: %+v", location)
+	}
+	if d.UpdatedAt(CatalogHH).IsZero() || d.UpdatedAt(CatalogSuperJob).IsZero() {
+		t.Errorf("после загрузки справочники должны быть отмечены как обновлённые")
+	}
+}
+
+// проверяем, что при недоступном API продолжает работать встроенный снимок
+func TestDictionary_RefreshErrorKeepsSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	d, err := NewDictionary(&configs.LocationsConfig{
+		RequestTimeout: 5 * time.Second,
+		HHAreasURL:     server.URL + "/areas",
+	})
+	if err != nil {
+		t.Fatalf("ошибка создания справочника: %v", err)
+	}
+
+	d.Refresh(context.Background())
+
+	if location, ok := d.Resolve(CatalogHH, "Казахстан"); !ok || location.Code != "40" {
+		t.Errorf("ожидалась локация из снимка, получено %+v", location)
+	}
+	if !d.UpdatedAt(CatalogHH).IsZero() {
+		t.Errorf("справочник не должен считаться обновлённым")
+	}
+}
+
+// проверяем поиск локаций для выпадающих списков
+func TestDictionary_Search(t *testing.T) {
+	d := Snapshot()
+
+	found, ok := d.Search(CatalogHH, "моск", "", "", 0)
+	if !ok || len(found) != 2 || found[0].Name != "Москва" || found[1].Name != "Московская область" {
+		t.Errorf("ожидались Москва и Московская область, получено %+v", found)
+	}
+
+	found, _ = d.Search(CatalogHH, "", models.LocationCountry, "", 3)
+	if len(found) != 3 || found[0].Code != "113" {
+		t.Errorf("ожидались первые 3 страны, получено %+v", found)
+	}
+
+	found, _ = d.Search(CatalogHH, "", "", "40", 0)
+	for _, location := range found {
+		if location.ParentCode != "40" {
+			t.Errorf("ожидались только локации Казахстана, получено %+v", location)
+		}
+	}
+	if len(found) == 0 {
+		t.Errorf("ожидались города Казахстана")
+	}
+
+	if _, ok := d.Search("unknown", "", "", "", 0); ok {
+		t.Errorf("неизвестный справочник должен возвращать false")
+	}
+}
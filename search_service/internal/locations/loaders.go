@@ -0,0 +1,162 @@
+package locations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+)
+
+// функция создания загрузчиков справочников по конфигу (справочник без адреса работает только на снимке)
+func newLoaders(cfg *configs.LocationsConfig) map[string]catalogLoader {
+	loaders := make(map[string]catalogLoader)
+
+	if cfg.HHAreasURL != "" {
+		loaders[CatalogHH] = hhAreasLoader(cfg.HHAreasURL)
+	}
+	if cfg.SJTownsURL != "" {
+		loaders[CatalogSuperJob] = sjLoader(cfg.SJCountriesURL, cfg.SJRegionsURL, cfg.SJTownsURL)
+	}
+
+	return loaders
+}
+
+// структура узла дерева регионов HH.ru
+type hhArea struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Areas []hhArea `json:"areas"`
+}
+
+// загрузчик дерева регионов HH.ru: страны -> регионы -> города
+func hhAreasLoader(url string) catalogLoader {
+	return func(ctx context.Context, fetch fetchFunc) ([]models.Location, error) {
+		var tree []hhArea
+		if err := fetch(ctx, url, &tree); err != nil {
+			return nil, err
+		}
+
+		var locations []models.Location
+		var walk func(areas []hhArea, parent string, depth int)
+		walk = func(areas []hhArea, parent string, depth int) {
+			for _, area := range areas {
+				locations = append(locations, models.Location{
+					Code:       area.ID,
+					Name:       area.Name,
+					Kind:       hhAreaKind(depth, len(area.Areas) > 0),
+					ParentCode: parent,
+				})
+				walk(area.Areas, area.ID, depth+1)
+			}
+		}
+		walk(tree, "", 0)
+
+		return locations, nil
+	}
+}
+
+// функция определения вида локации HH.ru по глубине в дереве
+// города федерального значения (Москва, Санкт-Петербург) - регионы без вложенных локаций, считаем их городами
+func hhAreaKind(depth int, hasChildren bool) string {
+	switch {
+	case depth == 0:
+		return models.LocationCountry
+	case depth == 1 && hasChildren:
+		return models.LocationRegion
+	default:
+		return models.LocationCity
+	}
+}
+
+// структура ответа справочников SuperJob (страны, регионы и города - одинаковый формат)
+type sjDictionaryResponse struct {
+	Objects []struct {
+		ID        int    `json:"id"`
+		Title     string `json:"title"`
+		IDRegion  int    `json:"id_region"`
+		IDCountry int    `json:"id_country"`
+	} `json:"objects"`
+}
+
+// загрузчик справочников SuperJob: страны, регионы, города (адреса стран и регионов необязательны)
+func sjLoader(countriesURL, regionsURL, townsURL string) catalogLoader {
+	return func(ctx context.Context, fetch fetchFunc) ([]models.Location, error) {
+		var locations []models.Location
+
+		parts := []struct {
+			url  string
+			kind string
+		}{
+			{countriesURL, models.LocationCountry},
+			{regionsURL, models.LocationRegion},
+			{townsURL, models.LocationCity},
+		}
+
+		for _, part := range parts {
+			if part.url == "" {
+				continue
+			}
+
+			var resp sjDictionaryResponse
+			if err := fetch(ctx, part.url, &resp); err != nil {
+				return nil, err
+			}
+
+			for _, object := range resp.Objects {
+				location := models.Location{
+					Code: strconv.Itoa(object.ID),
+					Name: object.Title,
+					Kind: part.kind,
+				}
+				switch {
+				case part.kind == models.LocationCity && object.IDRegion > 0:
+					location.ParentCode = strconv.Itoa(object.IDRegion)
+				case part.kind != models.LocationCountry && object.IDCountry > 0:
+					location.ParentCode = strconv.Itoa(object.IDCountry)
+				}
+				locations = append(locations, location)
+			}
+		}
+
+		return locations, nil
+	}
+}
+
+// функция запроса справочника у API источника
+func fetchJSON(ctx context.Context, client *http.Client, decorator search_interfaces.RequestDecorator, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+
+	// справочники запрашиваются с теми же ключами и заголовками, что и вакансии
+	if decorator != nil {
+		decorator.DecorateRequest(req)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response failed: %w", err)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("parse response failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package locations
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"search_service/internal/domain/models"
+)
+
+// встроенный снимок справочников: по одному файлу на справочник (имя файла - имя справочника)
+// снимок содержит основные страны, регионы и крупные города, полный справочник загружается с API источника
+//
+//go:embed snapshot/*.json
+var snapshotFS embed.FS
+
+// функция загрузки встроенного снимка всех справочников
+func loadSnapshot() (map[string][]models.Location, error) {
+	files, err := snapshotFS.ReadDir("snapshot")
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := make(map[string][]models.Location, len(files))
+	for _, file := range files {
+		data, err := snapshotFS.ReadFile(path.Join("snapshot", file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var locations []models.Location
+		if err := json.Unmarshal(data, &locations); err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", file.Name(), err)
+		}
+		catalogs[strings.TrimSuffix(file.Name(), ".json")] = locations
+	}
+
+	return catalogs, nil
+}
@@ -0,0 +1,22 @@
+package locations
+
+import "testing"
+
+// проверяем, что коды локаций в каждом справочнике снимка уникальны: по дублю код разрешается в чужую локацию
+func TestSnapshot_UniqueCodes(t *testing.T) {
+	catalogs, err := loadSnapshot()
+	if err != nil {
+		t.Fatalf("ошибка загрузки снимка: %v", err)
+	}
+
+	for catalog, locations := range catalogs {
+		seen := make(map[string]string, len(locations))
+		for _, location := range locations {
+			if name, ok := seen[location.Code]; ok {
+				t.Errorf("справочник %s: код %s у %q и %q", catalog, location.Code, name, location.Name)
+				continue
+			}
+			seen[location.Code] = location.Name
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"search_service/internal/domain/models"
+	"shared/circuitbreaker"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatsSource - источник текущей загрузки и статистики кэшей (менеджер парсеров)
+type StatsSource interface {
+	LoadStats() models.LoadStats
+	CacheStats() []models.CacheStats
+}
+
+// состояния circuit breaker, по которым выдаётся метрика состояния (1 - текущее, 0 - остальные)
+var breakerStates = []string{
+	circuitbreaker.StateClosed.String(),
+	circuitbreaker.StateOpen.String(),
+	circuitbreaker.StateHalfOpen.String(),
+}
+
+// Collector - коллектор Prometheus, который при каждом сборе метрик снимает текущую загрузку и статистику кэшей
+type Collector struct {
+	source StatsSource
+
+	queueDepth           *prometheus.Desc
+	queueCapacity        *prometheus.Desc
+	workers              *prometheus.Desc
+	workersBusy          *prometheus.Desc
+	semaphoreInUse       *prometheus.Desc
+	semaphoreSize        *prometheus.Desc
+	breakerState         *prometheus.Desc
+	cacheHits            *prometheus.Desc
+	cacheStaleHits       *prometheus.Desc
+	cacheMisses          *prometheus.Desc
+	cacheHitRatio        *prometheus.Desc
+	cacheEvictions       *prometheus.Desc
+	cacheExpired         *prometheus.Desc
+	cacheEntries         *prometheus.Desc
+	cacheBytes           *prometheus.Desc
+	sourceSemaphoreInUse *prometheus.Desc
+	sourceSemaphoreSize  *prometheus.Desc
+}
+
+// конструктор коллектора
+func NewCollector(source StatsSource) *Collector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, nil)
+	}
+
+	return &Collector{
+		source:               source,
+		queueDepth:           desc("queue_depth", "Количество джоб в очереди менеджера парсеров."),
+		queueCapacity:        desc("queue_capacity", "Ёмкость очереди менеджера парсеров."),
+		workers:              desc("workers", "Количество воркеров менеджера парсеров."),
+		workersBusy:          desc("workers_busy", "Количество воркеров, которые обрабатывают джобу."),
+		semaphoreInUse:       desc("semaphore_in_use", "Занятые слоты глобального семафора менеджера парсеров."),
+		semaphoreSize:        desc("semaphore_size", "Размер глобального семафора менеджера парсеров."),
+		sourceSemaphoreInUse: desc("source_semaphore_in_use", "Занятые слоты семафора парсера источника.", "source"),
+		sourceSemaphoreSize:  desc("source_semaphore_size", "Размер семафора парсера источника.", "source"),
+		breakerState:         desc("circuit_breaker_state", "Состояние circuit breaker: 1 - текущее состояние, 0 - остальные.", "breaker", "state"),
+		cacheHits:            desc("cache_hits_total", "Обращения к кэшу, на которые нашлось свежее значение.", "cache"),
+		cacheStaleHits:       desc("cache_stale_hits_total", "Обращения к кэшу, на которые нашлось устаревшее значение.", "cache"),
+		cacheMisses:          desc("cache_misses_total", "Обращения к кэшу, на которые значения не нашлось.", "cache"),
+		cacheHitRatio:        desc("cache_hit_ratio", "Доля обращений к кэшу, на которые нашлось значение (вместе с устаревшими).", "cache"),
+		cacheEvictions:       desc("cache_evictions_total", "Элементы, вытесненные из кэша из-за лимитов размера.", "cache"),
+		cacheExpired:         desc("cache_expired_total", "Истёкшие элементы, удалённые из кэша.", "cache"),
+		cacheEntries:         desc("cache_entries", "Количество элементов в кэше.", "cache"),
+		cacheBytes:           desc("cache_bytes", "Примерный объём элементов кэша в байтах (0 - объём не ограничен и не считается).", "cache"),
+	}
+}
+
+// Describe - отдаёт описания всех метрик коллектора
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.queueDepth, c.queueCapacity, c.workers, c.workersBusy, c.semaphoreInUse, c.semaphoreSize,
+		c.sourceSemaphoreInUse, c.sourceSemaphoreSize, c.breakerState,
+		c.cacheHits, c.cacheStaleHits, c.cacheMisses, c.cacheHitRatio, c.cacheEvictions, c.cacheExpired, c.cacheEntries, c.cacheBytes,
+	} {
+		ch <- d
+	}
+}
+
+// Collect - снимает текущие значения метрик
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	gauge := func(d *prometheus.Desc, value float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, value, labels...)
+	}
+	counter := func(d *prometheus.Desc, value float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, value, labels...)
+	}
+
+	load := c.source.LoadStats()
+	gauge(c.queueDepth, float64(load.QueueDepth))
+	gauge(c.queueCapacity, float64(load.QueueCapacity))
+	gauge(c.workers, float64(load.Workers))
+	gauge(c.workersBusy, float64(load.BusyWorkers))
+	gauge(c.semaphoreInUse, float64(load.SemaphoreInUse))
+	gauge(c.semaphoreSize, float64(load.SemaphoreSize))
+	c.collectBreakerState(gauge, BreakerManager, load.CircuitBreaker)
+
+	for _, p := range load.Parsers {
+		gauge(c.sourceSemaphoreInUse, float64(p.SemaphoreInUse), p.Name)
+		gauge(c.sourceSemaphoreSize, float64(p.SemaphoreSize), p.Name)
+		c.collectBreakerState(gauge, p.Name, p.CircuitBreaker)
+	}
+
+	// кэши, которые не ведут статистику (например, кэши в Redis), пропускаем
+	for _, stats := range c.source.CacheStats() {
+		if !stats.Available {
+			continue
+		}
+		counter(c.cacheHits, float64(stats.Hits), stats.Name)
+		counter(c.cacheStaleHits, float64(stats.StaleHits), stats.Name)
+		counter(c.cacheMisses, float64(stats.Misses), stats.Name)
+		gauge(c.cacheHitRatio, stats.HitRatio, stats.Name)
+		counter(c.cacheEvictions, float64(stats.Evictions), stats.Name)
+		counter(c.cacheExpired, float64(stats.Expired), stats.Name)
+		gauge(c.cacheEntries, float64(stats.Entries), stats.Name)
+		gauge(c.cacheBytes, float64(stats.Bytes), stats.Name)
+	}
+}
+
+// метод выдачи состояния circuit breaker: по серии на каждое состояние, у текущего - 1
+func (c *Collector) collectBreakerState(gauge func(d *prometheus.Desc, value float64, labels ...string), breaker, current string) {
+	for _, state := range breakerStates {
+		value := 0.0
+		if state == current {
+			value = 1
+		}
+		gauge(c.breakerState, value, breaker, state)
+	}
+}
+
+// Register - регистрирует коллектор в реестре Prometheus по умолчанию (один раз при старте сервиса)
+func Register(source StatsSource) error {
+	return prometheus.Register(NewCollector(source))
+}
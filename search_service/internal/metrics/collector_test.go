@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"search_service/internal/domain/models"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// тестовый источник загрузки и статистики кэшей
+type fakeStatsSource struct {
+	load   models.LoadStats
+	caches []models.CacheStats
+}
+
+func (f *fakeStatsSource) LoadStats() models.LoadStats     { return f.load }
+func (f *fakeStatsSource) CacheStats() []models.CacheStats { return f.caches }
+
+// TestCollector проверяет, что коллектор отдаёт текущую загрузку, состояния circuit breaker и статистику кэшей
+func TestCollector(t *testing.T) {
+	source := &fakeStatsSource{
+		load: models.LoadStats{
+			QueueDepth:     3,
+			QueueCapacity:  30,
+			Workers:        8,
+			BusyWorkers:    2,
+			SemaphoreInUse: 1,
+			SemaphoreSize:  6,
+			CircuitBreaker: "closed",
+			Parsers: []models.ParserLoadStats{
+				{Name: "hh", SemaphoreInUse: 2, SemaphoreSize: 5, CircuitBreaker: "open"},
+			},
+		},
+		caches: []models.CacheStats{
+			{Name: "search_cache", Available: true, Hits: 3, Misses: 1, HitRatio: 0.75, Entries: 10},
+			{Name: "vacancy_details"}, // кэш в Redis без статистики
+		},
+	}
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(NewCollector(source)); err != nil {
+		t.Fatalf("Не удалось зарегистрировать коллектор: %v", err)
+	}
+
+	expected := `
+# HELP search_queue_depth Количество джоб в очереди менеджера парсеров.
+# TYPE search_queue_depth gauge
+search_queue_depth 3
+# HELP search_workers_busy Количество воркеров, которые обрабатывают джобу.
+# TYPE search_workers_busy gauge
+search_workers_busy 2
+# HELP search_source_semaphore_in_use Занятые слоты семафора парсера источника.
+# TYPE search_source_semaphore_in_use gauge
+search_source_semaphore_in_use{source="hh"} 2
+# HELP search_circuit_breaker_state Состояние circuit breaker: 1 - текущее состояние, 0 - остальные.
+# TYPE search_circuit_breaker_state gauge
+search_circuit_breaker_state{breaker="hh",state="closed"} 0
+search_circuit_breaker_state{breaker="hh",state="half_open"} 0
+search_circuit_breaker_state{breaker="hh",state="open"} 1
+search_circuit_breaker_state{breaker="parsers_manager",state="closed"} 1
+search_circuit_breaker_state{breaker="parsers_manager",state="half_open"} 0
+search_circuit_breaker_state{breaker="parsers_manager",state="open"} 0
+# HELP search_cache_hit_ratio Доля обращений к кэшу, на которые нашлось значение (вместе с устаревшими).
+# TYPE search_cache_hit_ratio gauge
+search_cache_hit_ratio{cache="search_cache"} 0.75
+# HELP search_cache_hits_total Обращения к кэшу, на которые нашлось свежее значение.
+# TYPE search_cache_hits_total counter
+search_cache_hits_total{cache="search_cache"} 3
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"search_queue_depth", "search_workers_busy", "search_source_semaphore_in_use",
+		"search_circuit_breaker_state", "search_cache_hit_ratio", "search_cache_hits_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
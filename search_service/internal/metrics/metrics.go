@@ -0,0 +1,85 @@
+// метрики Prometheus сервиса поиска вакансий
+// события (запросы к источникам, ожидание rate limiter, смены состояний circuit breaker) учитываются в момент события,
+// текущая загрузка (очередь, воркеры, семафоры, состояния circuit breaker, кэши) снимается при каждом сборе метрик (Collector)
+package metrics
+
+import (
+	"shared/circuitbreaker"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "search"
+
+// BreakerManager - имя глобального circuit breaker менеджера парсеров в метках (у парсеров - имя источника)
+const BreakerManager = "parsers_manager"
+
+// операции с источником (метка operation)
+const (
+	OperationSearch  = "search"
+	OperationDetails = "details"
+)
+
+// результаты операций с источником (метка result)
+const (
+	ResultSuccess     = "success"
+	ResultError       = "error"
+	ResultCircuitOpen = "circuit_open" // запрос не выполнялся: circuit breaker открыт или исчерпан лимит пробных запросов
+	ResultCanceled    = "canceled"     // поиск отменён или истёк его таймаут
+)
+
+var (
+	// время HTTP запросов к источникам (до получения заголовков ответа)
+	sourceRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "source_request_duration_seconds",
+		Help:      "Время HTTP запросов к источникам вакансий до получения ответа, по источникам и статусам ответа (error - ответ не получен).",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+	}, []string{"source", "status"})
+
+	// результаты операций с источниками
+	sourceOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "source_operations_total",
+		Help:      "Количество операций с источниками вакансий (поиск и детали вакансии) по результатам.",
+	}, []string{"source", "operation", "result"})
+
+	// время ожидания rate limiter перед запросом к источнику
+	rateLimiterWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rate_limiter_wait_seconds",
+		Help:      "Время ожидания rate limiter перед запросом к источнику.",
+		Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5},
+	}, []string{"source"})
+
+	// смены состояний circuit breaker
+	circuitBreakerTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_transitions_total",
+		Help:      "Количество смен состояний circuit breaker.",
+	}, []string{"breaker", "from", "to"})
+)
+
+// ObserveSourceRequest - учёт времени HTTP запроса к источнику (status - код ответа или error, если ответ не получен)
+func ObserveSourceRequest(source, status string, duration time.Duration) {
+	sourceRequestDuration.WithLabelValues(source, status).Observe(duration.Seconds())
+}
+
+// CountSourceOperation - учёт результата операции с источником
+func CountSourceOperation(source, operation, result string) {
+	sourceOperations.WithLabelValues(source, operation, result).Inc()
+}
+
+// ObserveRateLimiterWait - учёт времени ожидания rate limiter источника
+func ObserveRateLimiterWait(source string, duration time.Duration) {
+	rateLimiterWait.WithLabelValues(source).Observe(duration.Seconds())
+}
+
+// CircuitBreakerTransitions - функция для circuit breaker OnStateChange, которая учитывает смены его состояний
+func CircuitBreakerTransitions(breaker string) func(from, to circuitbreaker.State) {
+	return func(from, to circuitbreaker.State) {
+		circuitBreakerTransitions.WithLabelValues(breaker, from.String(), to.String()).Inc()
+	}
+}
@@ -7,12 +7,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"search_service/configs"
 	"search_service/internal/domain/models"
+	"search_service/internal/locations"
+	"search_service/internal/metrics"
 	"search_service/internal/search_interfaces"
 	"search_service/pkg"
 	"shared/circuitbreaker"
 	"shared/config"
 	"shared/rate_limiter"
+	"strconv"
 	"time"
 )
 
@@ -31,19 +35,22 @@ type BaseConfig struct {
 	TLSHandshakeTimeout   time.Duration               // максимальное время ожидания завершения TLS handshake
 	ResponseHeaderTimeout time.Duration               // интервал, сколько ждать ответа сервера после отправки запроса
 	ExpectContinueTimeout time.Duration               // интервал, оптимизация для сценариев загрузки больших данных
+	Request               *configs.RequestConfig      // заголовки и ключи, которые добавляются к каждому запросу (необязательно)
 }
 
 // BaseParser базовая реализация парсера
 type BaseParser struct {
-	name           string                        // имя парсера (к какому источнику будет привязан)
-	baseURL        string                        // базовый URL, через который бдет осуществляться парсинг
-	healthEndPoint string                        // URL, через который бдет осуществляться health check
-	apiKey         string                        // API ключ, если предусмотрен сервисом
-	httpClient     *http.Client                  // экземпляр клиента, через который будем проводить парсинг на внешнем источнике
-	rateLimiter    search_interfaces.RateLimiter // интерфейс rate limiter (ограничение частоты обращения к ресурсу)
-	circuitBreaker search_interfaces.CBInterface // интерфейс для circuit breaker (отказоустойчивость)
-	semaphore      chan struct{}                 // семафор (ограничение конкурентности)
-	maxConcurrent  int                           // размер буфера для семафора
+	name           string                             // имя парсера (к какому источнику будет привязан)
+	baseURL        string                             // базовый URL, через который бдет осуществляться парсинг
+	healthEndPoint string                             // URL, через который бдет осуществляться health check
+	apiKey         string                             // API ключ, если предусмотрен сервисом
+	httpClient     *http.Client                       // экземпляр клиента, через который будем проводить парсинг на внешнем источнике
+	rateLimiter    search_interfaces.RateLimiter      // интерфейс rate limiter (ограничение частоты обращения к ресурсу)
+	circuitBreaker search_interfaces.CBInterface      // интерфейс для circuit breaker (отказоустойчивость)
+	semaphore      chan struct{}                      // семафор (ограничение конкурентности)
+	maxConcurrent  int                                // размер буфера для семафора
+	decorator      *RequestDecorator                  // оформление исходящих запросов (nil - запросы уходят как есть)
+	locations      search_interfaces.LocationResolver // справочник локаций для перевода города/региона в коды источника
 }
 
 // Конструктор, который создает базовый парсер
@@ -54,6 +61,10 @@ func NewBaseParser(config BaseConfig) (*BaseParser, error) {
 		return nil, err
 	}
 
+	// смены состояний circuit breaker учитываем в метриках
+	circuitBreaker := circuitbreaker.NewCircutBreaker(config.CircuitBreakerCfg)
+	circuitBreaker.OnStateChange(metrics.CircuitBreakerTransitions(config.Name))
+
 	return &BaseParser{
 		name:           config.Name,
 		baseURL:        config.BaseURL,
@@ -61,9 +72,11 @@ func NewBaseParser(config BaseConfig) (*BaseParser, error) {
 		apiKey:         config.APIKey,
 		httpClient:     createHTTPClient(config),
 		rateLimiter:    rateLimiter,
-		circuitBreaker: circuitbreaker.NewCircutBreaker(config.CircuitBreakerCfg),
+		circuitBreaker: circuitBreaker,
 		semaphore:      make(chan struct{}, config.MaxConcurrent),
 		maxConcurrent:  config.MaxConcurrent,
+		decorator:      NewRequestDecorator(config.APIKey, config.Request),
+		locations:      locations.Snapshot(), // до подключения общего справочника - встроенный снимок
 	}, nil
 }
 
@@ -99,22 +112,30 @@ func createHTTPClient(config BaseConfig) *http.Client {
 
 // ParserFuncs определяет типы специфичных функций парсера
 type ParserFuncs struct {
-	BuildURL       func(models.SearchParams) (string, error)
-	Parse          func([]byte) (interface{}, error)
-	Convert        func(interface{}) ([]models.Vacancy, error)
-	ConvertDetails func(interface{}) (models.SearchVacancyDetailesResult, error)
+	BuildURL        func(models.SearchParams) (string, error)
+	BuildDetailsURL func(vacancyID string) (string, error) // необязательная, по умолчанию: baseURL/vacancyID
+	Parse           func([]byte) (interface{}, error)
+	Convert         func(interface{}) ([]models.Vacancy, error)
+	ConvertDetails  func(interface{}) (models.SearchVacancyDetailesResult, error)
+	Totals          func(interface{}) (found, pages int) // необязательная: сколько всего вакансий и страниц нашёл источник
 }
 
 // SearchVacancies общий метод для поиска вакансий
 func (p *BaseParser) SearchVacancies(ctx context.Context, params models.SearchParams, funcs ParserFuncs) ([]models.Vacancy, error) {
+	page, err := p.SearchVacanciesPage(ctx, params, funcs)
+	return page.Vacancies, err
+}
+
+// SearchVacanciesPage общий метод для поиска одной страницы вакансий вместе с общим количеством найденного у источника
+func (p *BaseParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams, funcs ParserFuncs) (models.SearchPage, error) {
 	// Строим URL с параметрами
 	apiURL, err := funcs.BuildURL(params)
 	if err != nil {
-		return nil, fmt.Errorf("build URL failed: %w", err)
+		return models.SearchPage{}, fmt.Errorf("build URL failed: %w", err)
 	}
 
 	// заводим переменную, в которую будем складывать результат
-	var vacancies []models.Vacancy
+	var page models.SearchPage
 
 	// Используем Circuit Breaker для выполнения запроса к внешнему сервису
 	//---------------------------------------------------------------------------------------------------
@@ -126,7 +147,7 @@ func (p *BaseParser) SearchVacancies(ctx context.Context, params models.SearchPa
 		defer p.releaseSemaphore() // после завершения вызова функции, освобождаем семафор
 
 		// Перед осуществлением запроса проверяем rate limiter
-		err := p.rateLimiter.Wait(ctx)
+		err := p.waitRateLimiter(ctx)
 		if err != nil {
 			return err
 		}
@@ -164,18 +185,26 @@ func (p *BaseParser) SearchVacancies(ctx context.Context, params models.SearchPa
 			return fmt.Errorf("convert to universal failed: %w", err)
 		}
 
-		vacancies = converted
+		page.Vacancies = converted
+
+		// если источник сообщает общее количество найденного - сохраняем его для честной пагинации
+		if funcs.Totals != nil {
+			page.Found, page.Pages = funcs.Totals(parsedData)
+		}
 
 		return nil
 	})
 	//---------------------------------------------------------------------------------------------------
 
+	// учитываем результат в метриках
+	p.countOperation(ctx, metrics.OperationSearch, err)
+
 	// если ошибки есть, определяем какого они рода
 	if err != nil {
 		return p.handleCircuitBreakerErrorVacanciesSearch(err)
 	}
 
-	return vacancies, nil
+	return page, nil
 }
 
 // SearchVacancyDetailes общий метод для поиска деталей по конкретной вакансии
@@ -183,6 +212,15 @@ func (p *BaseParser) SearchVacancyDetailes(ctx context.Context, vacancyID string
 	// формируем url поиска для базового парсера
 	searchUrl := pkg.UrlBuilder(p.baseURL, vacancyID)
 
+	// если у парсера свой формат ссылки на детали вакансии - используем его
+	if funcs.BuildDetailsURL != nil {
+		detailsURL, err := funcs.BuildDetailsURL(vacancyID)
+		if err != nil {
+			return models.SearchVacancyDetailesResult{}, fmt.Errorf("build details URL failed: %w", err)
+		}
+		searchUrl = detailsURL
+	}
+
 	// заводим переменную, в которую будем складывать результат
 	var vacancyDetails models.SearchVacancyDetailesResult
 
@@ -196,7 +234,7 @@ func (p *BaseParser) SearchVacancyDetailes(ctx context.Context, vacancyID string
 		defer p.releaseSemaphore() // после завершения вызова функции, освобождаем семафор
 
 		// Перед осуществлением запроса проверяем rate limiter
-		err := p.rateLimiter.Wait(ctx)
+		err := p.waitRateLimiter(ctx)
 		if err != nil {
 			return err
 		}
@@ -238,6 +276,9 @@ func (p *BaseParser) SearchVacancyDetailes(ctx context.Context, vacancyID string
 		return nil
 	})
 
+	// учитываем результат в метриках
+	p.countOperation(ctx, metrics.OperationDetails, err)
+
 	// если ошибки есть, определяем какого они рода
 	if err != nil {
 		return p.handleCircuitBreakerErrorVacancyDetails(err)
@@ -264,6 +305,30 @@ func (p *BaseParser) releaseSemaphore() {
 	<-p.semaphore
 }
 
+// метод ожидания rate limiter с учётом времени ожидания в метриках
+func (p *BaseParser) waitRateLimiter(ctx context.Context) error {
+	start := time.Now()
+	err := p.rateLimiter.Wait(ctx)
+	metrics.ObserveRateLimiterWait(p.name, time.Since(start))
+	return err
+}
+
+// метод учёта результата операции с источником в метриках
+func (p *BaseParser) countOperation(ctx context.Context, operation string, err error) {
+	var result string
+	switch {
+	case err == nil:
+		result = metrics.ResultSuccess
+	case errors.Is(err, circuitbreaker.ErrCircuitOpen), errors.Is(err, circuitbreaker.ErrTooManyRequests):
+		result = metrics.ResultCircuitOpen
+	case ctx.Err() != nil:
+		result = metrics.ResultCanceled
+	default:
+		result = metrics.ResultError
+	}
+	metrics.CountSourceOperation(p.name, operation, result)
+}
+
 // метод для выполнения HTTP запроса через клиент
 func (p *BaseParser) executeRequest(ctx context.Context, url string) (*http.Response, error) {
 	//формируем запрос
@@ -272,11 +337,17 @@ func (p *BaseParser) executeRequest(ctx context.Context, url string) (*http.Resp
 		return nil, fmt.Errorf("create request failed: %w", err)
 	}
 
-	// делаем запрос, получаем ответ
+	// добавляем заголовки и ключи источника
+	p.decorator.Decorate(req)
+
+	// делаем запрос, получаем ответ (время до получения ответа учитываем в метриках)
+	start := time.Now()
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
+		metrics.ObserveSourceRequest(p.name, metrics.ResultError, time.Since(start))
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
+	metrics.ObserveSourceRequest(p.name, strconv.Itoa(resp.StatusCode), time.Since(start))
 	return resp, nil
 }
 
@@ -305,6 +376,16 @@ func (p *BaseParser) checkResponseStatus(resp *http.Response) error {
 	return nil
 }
 
+// LoadStats возвращает занятость семафора парсера и состояние его circuit breaker
+func (p *BaseParser) LoadStats() models.ParserLoadStats {
+	return models.ParserLoadStats{
+		Name:           p.name,
+		SemaphoreInUse: len(p.semaphore),
+		SemaphoreSize:  p.maxConcurrent,
+		CircuitBreaker: p.circuitBreaker.State().String(),
+	}
+}
+
 // GetName возвращает имя парсера
 func (p *BaseParser) GetName() string {
 	return p.name
@@ -319,6 +400,30 @@ func (p *BaseParser) GetHealthEndPoint() string {
 	return p.healthEndPoint
 }
 
+// SetLocationResolver подключает общий справочник локаций (обновляемый с API источников)
+func (p *BaseParser) SetLocationResolver(resolver search_interfaces.LocationResolver) {
+	if resolver != nil {
+		p.locations = resolver
+	}
+}
+
+// метод перевода локации из запроса в код источника по справочнику catalog
+// если справочник не задан или локация в нём не найдена - передаём текст как есть
+func (p *BaseParser) locationCode(catalog, text string) string {
+	if catalog == "" {
+		return text
+	}
+	if location, ok := p.locations.Resolve(catalog, text); ok {
+		return location.Code
+	}
+	return text
+}
+
+// DecorateRequest добавляет к запросу заголовки и ключи источника (нужен для health check, который ходит своим клиентом)
+func (p *BaseParser) DecorateRequest(req *http.Request) {
+	p.decorator.Decorate(req)
+}
+
 // Отдельная функция с дженериками для определния : обычная ошибка или ошибка circuitBreaker
 func handleCircuitBreakerErrorUniversal[T any](name string, cb search_interfaces.CBInterface, err error) (T, error) {
 	var zero T
@@ -334,8 +439,8 @@ func handleCircuitBreakerErrorUniversal[T any](name string, cb search_interfaces
 
 // метод - обёртка для обработки ошибок. Выясняем это ошибки circuit breaker или внешние ошибки.
 // для поиска списка вакнсий
-func (p *BaseParser) handleCircuitBreakerErrorVacanciesSearch(err error) ([]models.Vacancy, error) {
-	return handleCircuitBreakerErrorUniversal[[]models.Vacancy](p.name, p.circuitBreaker, err)
+func (p *BaseParser) handleCircuitBreakerErrorVacanciesSearch(err error) (models.SearchPage, error) {
+	return handleCircuitBreakerErrorUniversal[models.SearchPage](p.name, p.circuitBreaker, err)
 }
 
 // метод - обёртка для обработки ошибок. Выясняем это ошибки circuit breaker или внешние ошибки.
@@ -343,3 +448,11 @@ func (p *BaseParser) handleCircuitBreakerErrorVacanciesSearch(err error) ([]mode
 func (p *BaseParser) handleCircuitBreakerErrorVacancyDetails(err error) (models.SearchVacancyDetailesResult, error) {
 	return handleCircuitBreakerErrorUniversal[models.SearchVacancyDetailesResult](p.name, p.circuitBreaker, err)
 }
+
+// функция расчёта количества страниц по общему числу найденных вакансий (для источников, которые не сообщают число страниц)
+func pagesCount(found, perPage int) int {
+	if found <= 0 || perPage <= 0 {
+		return 0
+	}
+	return (found + perPage - 1) / perPage
+}
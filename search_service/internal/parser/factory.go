@@ -16,8 +16,17 @@ import (
 type ParserType string
 
 const (
-	ParserTypeHH ParserType = "hh"
-	ParserTypeSJ ParserType = "superjob"
+	ParserTypeHH       ParserType = "hh"
+	ParserTypeSJ       ParserType = "superjob"
+	ParserTypeHabr     ParserType = "habr"
+	ParserTypeZarplata ParserType = "zarplata"
+
+	// префикс типа для декларативных парсеров, полный тип: "mapped:<имя источника>"
+	ParserTypeMappedPrefix = "mapped:"
+	// префикс типа для парсеров RSS/Atom лент, полный тип: "feed:<имя источника>"
+	ParserTypeFeedPrefix = "feed:"
+	// префикс типа для парсеров HTML страниц, полный тип: "scraper:<имя источника>"
+	ParserTypeScraperPrefix = "scraper:"
 	// можно добавить: ParserTypeRabotaRu ParserType = "rabota.ru"
 )
 
@@ -28,6 +37,7 @@ type ParserConstructor func(config *configs.ParserInstanceConfig) (search_interf
 type ParserFactory struct {
 	constructors map[ParserType]ParserConstructor
 	configs      map[ParserType]*configs.ParserInstanceConfig
+	locations    search_interfaces.LocationResolver // общий справочник локаций для создаваемых парсеров (nil - встроенный снимок)
 	mu           sync.RWMutex
 }
 
@@ -49,6 +59,52 @@ func (f *ParserFactory) Register(parserType ParserType, config *configs.ParserIn
 	f.configs[parserType] = config
 }
 
+// SetLocationResolver задаёт справочник локаций, который получат все создаваемые парсеры
+func (f *ParserFactory) SetLocationResolver(resolver search_interfaces.LocationResolver) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.locations = resolver
+}
+
+// RegisterMapped регистрирует декларативные парсеры, описанные в конфиге.
+// возвращает типы тех парсеров, у которых в конфиге указано Enabled
+func (f *ParserFactory) RegisterMapped(mapped []*configs.ParserInstanceConfig) []ParserType {
+	return f.registerConfigured(ParserTypeMappedPrefix, mapped, NewMappedParser)
+}
+
+// RegisterFeeds регистрирует парсеры RSS/Atom лент, описанные в конфиге.
+// возвращает типы тех парсеров, у которых в конфиге указано Enabled
+func (f *ParserFactory) RegisterFeeds(feeds []*configs.ParserInstanceConfig) []ParserType {
+	return f.registerConfigured(ParserTypeFeedPrefix, feeds, NewFeedParser)
+}
+
+// RegisterScrapers регистрирует парсеры HTML страниц, описанные в конфиге.
+// возвращает типы тех парсеров, у которых в конфиге указано Enabled
+func (f *ParserFactory) RegisterScrapers(scrapers []*configs.ParserInstanceConfig) []ParserType {
+	return f.registerConfigured(ParserTypeScraperPrefix, scrapers, NewScraperParser)
+}
+
+// registerConfigured регистрирует список однотипных парсеров из конфига под типами "<prefix><имя источника>"
+func (f *ParserFactory) registerConfigured(prefix string, cfgs []*configs.ParserInstanceConfig, constructor ParserConstructor) []ParserType {
+	var enabled []ParserType
+
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			continue
+		}
+
+		parserType := ParserType(prefix + cfg.Name)
+		f.Register(parserType, cfg, constructor)
+
+		if cfg.Enabled {
+			enabled = append(enabled, parserType)
+		}
+	}
+
+	return enabled
+}
+
 // Create - создает парсер, если вся инфа до этого была зарегестрирована в фабрике
 func (f *ParserFactory) Create(parserType ParserType) (search_interfaces.Parser, error) {
 	f.mu.RLock()
@@ -56,6 +112,7 @@ func (f *ParserFactory) Create(parserType ParserType) (search_interfaces.Parser,
 	constructor, ok := f.constructors[parserType]
 	// под защитой мьютекса проверяем, есть ли в фабрике зарегестрированный конфиг для данного типа парсера
 	config, configOk := f.configs[parserType]
+	resolver := f.locations
 	f.mu.RUnlock()
 	if !ok {
 		return nil, fmt.Errorf("parser type not registered: %s", parserType)
@@ -63,7 +120,17 @@ func (f *ParserFactory) Create(parserType ParserType) (search_interfaces.Parser,
 	if !configOk {
 		return nil, fmt.Errorf("config not found for parser: %s", parserType)
 	}
-	return constructor(config)
+
+	parser, err := constructor(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// парсеры, которые переводят локацию в свои коды, подключаем к общему справочнику
+	if aware, ok := parser.(search_interfaces.LocationAwareParser); ok && resolver != nil {
+		aware.SetLocationResolver(resolver)
+	}
+	return parser, nil
 }
 
 // CreateEnabled создает только включенные парсеры (причем логика такая, что должны создаться только те у которых флаг enabled: true)
@@ -0,0 +1,359 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/internal/parser/model"
+
+	"search_service/internal/search_interfaces"
+)
+
+// максимальное количество записей лент, которые парсер помнит для выдачи деталей вакансии
+const maxRememberedFeedEntries = 2000
+
+// создаём стркутуру парсера RSS/Atom лент на базе общего парсера
+// у лент нет поиска на стороне источника, поэтому ленты скачиваются целиком и фильтруются по тексту запроса
+type FeedParser struct {
+	*BaseParser
+	feedURLs []string // адреса лент, которые агрегирует парсер
+
+	// последние полученные записи лент (ключ - ID вакансии), у лент нет отдельного API деталей
+	remembered map[string]models.Vacancy
+	mu         sync.RWMutex
+}
+
+// конструктор для парсера RSS/Atom лент
+func NewFeedParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, error) {
+	// для парсера лент нет конфига по умолчанию - адреса лент должны быть описаны в yml
+	if cfg == nil {
+		return nil, errors.New("feed parser requires config")
+	}
+	if cfg.Name == "" {
+		return nil, errors.New("feed parser: name is required")
+	}
+	if len(cfg.FeedURLs) == 0 {
+		return nil, fmt.Errorf("feed parser %s: feed_urls is required", cfg.Name)
+	}
+
+	// если health endpoint не указан - проверяем доступность первой ленты
+	healthEndPoint := cfg.HealthEndPoint
+	if healthEndPoint == "" {
+		healthEndPoint = cfg.FeedURLs[0]
+	}
+
+	baseCfg := BaseConfig{
+		Name:                  cfg.Name,
+		BaseURL:               cfg.FeedURLs[0],
+		HealthEndPoint:        healthEndPoint,
+		APIKey:                cfg.APIKey,
+		Timeout:               cfg.Timeout,
+		RateLimit:             cfg.RateLimit,
+		MaxConcurrent:         cfg.MaxConcurrent,
+		CircuitBreakerCfg:     cfg.CircuitBreaker,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		Request:               cfg.Request,
+	}
+
+	baseParser, err := NewBaseParser(baseCfg)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка в конфигурации rate limiter для парсера %s\n", baseCfg.Name)
+	}
+
+	return &FeedParser{
+		BaseParser: baseParser,
+		feedURLs:   cfg.FeedURLs,
+		remembered: make(map[string]models.Vacancy),
+	}, nil
+}
+
+// метод парсера для поиска списка вакансий во всех лентах
+func (p *FeedParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
+	page, err := p.SearchVacanciesPage(ctx, params)
+	return page.Vacancies, err
+}
+
+// метод парсера для поиска одной страницы вакансий во всех лентах вместе с общим количеством найденного
+// каждая лента запрашивается через BaseParser (rate limiter, семафор, circuit breaker)
+func (p *FeedParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	var (
+		vacancies []models.Vacancy
+		errs      []error
+	)
+	seen := make(map[string]struct{})
+
+	for _, feedURL := range p.feedURLs {
+		feedVacancies, err := p.BaseParser.SearchVacancies(
+			ctx,
+			params,
+			ParserFuncs{
+				BuildURL: func(models.SearchParams) (string, error) { return feedURL, nil },
+				Parse:    p.parseFeed,
+				Convert: func(data interface{}) ([]models.Vacancy, error) {
+					return p.convertToUniversal(data, params.Text)
+				},
+			},
+		)
+		if err != nil {
+			// одна недоступная лента не должна ломать выдачу остальных
+			errs = append(errs, fmt.Errorf("feed %s: %w", feedURL, err))
+			continue
+		}
+
+		// одна и та же вакансия может быть опубликована в нескольких лентах
+		for _, vacancy := range feedVacancies {
+			if _, ok := seen[vacancy.ID]; ok {
+				continue
+			}
+			seen[vacancy.ID] = struct{}{}
+			vacancies = append(vacancies, vacancy)
+		}
+	}
+
+	// ошибка - только если не ответила ни одна лента
+	if len(errs) == len(p.feedURLs) {
+		return models.SearchPage{}, errors.Join(errs...)
+	}
+
+	p.remember(vacancies)
+
+	// свежие записи - первыми
+	sort.SliceStable(vacancies, func(i, j int) bool {
+		return vacancies[i].PublishedAt.After(vacancies[j].PublishedAt)
+	})
+
+	return models.SearchPage{
+		Vacancies: paginateVacancies(vacancies, params.Page, params.PerPage),
+		Found:     len(vacancies),
+		Pages:     pagesCount(len(vacancies), params.PerPage),
+	}, nil
+}
+
+// метод парсера для поиска деталей по конкретной вакансии
+// у лент нет API деталей, поэтому отдаём данные из последних полученных записей
+func (p *FeedParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string) (models.SearchVacancyDetailesResult, error) {
+	p.mu.RLock()
+	vacancy, ok := p.remembered[vacancyID]
+	p.mu.RUnlock()
+
+	if !ok {
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[Parser name: %s] vacancy %s not found, repeat the search", p.name, vacancyID)
+	}
+
+	return models.SearchVacancyDetailesResult{
+		Employer:    models.Employer{Name: vacancy.Company},
+		Description: vacancy.Description,
+		Name:        vacancy.Job,
+		ID:          vacancy.ID,
+		Url:         vacancy.URL,
+	}, nil
+}
+
+// метод парсера обработки тела ответа: определяем формат ленты (RSS или Atom) и приводим записи к общему виду
+func (p *FeedParser) parseFeed(body []byte) (interface{}, error) {
+	root, err := feedRootElement(body)
+	if err != nil {
+		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+	}
+
+	switch root {
+	case "rss":
+		var feed model.RSSFeed
+		if err := xml.Unmarshal(body, &feed); err != nil {
+			return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+		}
+
+		entries := make([]model.FeedEntry, len(feed.Channel.Items))
+		for i, item := range feed.Channel.Items {
+			author := item.Creator
+			if author == "" {
+				author = item.Author
+			}
+			if author == "" {
+				author = feed.Channel.Title // лента страницы карьеры компании - автор это сама компания
+			}
+
+			entries[i] = model.FeedEntry{
+				ID:          item.GUID,
+				Title:       item.Title,
+				Link:        item.Link,
+				Description: item.Description,
+				Author:      author,
+				PublishedAt: item.PubDate,
+			}
+		}
+		return entries, nil
+
+	case "feed":
+		var feed model.AtomFeed
+		if err := xml.Unmarshal(body, &feed); err != nil {
+			return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+		}
+
+		entries := make([]model.FeedEntry, len(feed.Entries))
+		for i, entry := range feed.Entries {
+			description := entry.Summary
+			if description == "" {
+				description = entry.Content
+			}
+			published := entry.Published
+			if published == "" {
+				published = entry.Updated
+			}
+			author := entry.Author.Name
+			if author == "" {
+				author = feed.Title
+			}
+
+			entries[i] = model.FeedEntry{
+				ID:          entry.ID,
+				Title:       entry.Title,
+				Link:        entry.GetLink(),
+				Description: description,
+				Author:      author,
+				PublishedAt: published,
+			}
+		}
+		return entries, nil
+
+	default:
+		return nil, fmt.Errorf("[Parser name: %s] unsupported feed format: <%s>", p.name, root)
+	}
+}
+
+// метод приведения записей ленты к унифицированной структуре с фильтрацией по тексту запроса
+func (p *FeedParser) convertToUniversal(data interface{}, text string) ([]models.Vacancy, error) {
+	// Проводим type assertion
+	entries, ok := data.([]model.FeedEntry)
+	if !ok {
+
+		// Для более детальной информации можно использовать reflect
+		fmt.Printf("----------------->>>[Parser name: %s] DEBUG: Type details: %v\n", p.name, reflect.TypeOf(data))
+		return nil, fmt.Errorf("[Parser name: %s], wrong data type in the response body\n", p.name)
+	}
+
+	terms := strings.Fields(strings.ToLower(text))
+	universalVacancies := make([]models.Vacancy, 0, len(entries))
+
+	for _, entry := range entries {
+		if !matchesAllTerms(entry.Title+" "+entry.Description, terms) {
+			continue
+		}
+
+		universalVacancies = append(universalVacancies, models.Vacancy{
+			ID:          feedEntryID(entry),
+			Job:         strings.TrimSpace(entry.Title),
+			Company:     strings.TrimSpace(entry.Author),
+			URL:         strings.TrimSpace(entry.Link),
+			Source:      p.GetName(),
+			Description: entry.Description,
+			PublishedAt: parseFeedDate(entry.PublishedAt),
+		})
+	}
+
+	return universalVacancies, nil
+}
+
+// метод для сохранения записей лент, чтобы потом отдавать по ним детали
+func (p *FeedParser) remember(vacancies []models.Vacancy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// защищаемся от бесконечного роста - при переполнении начинаем заново
+	if len(p.remembered)+len(vacancies) > maxRememberedFeedEntries {
+		p.remembered = make(map[string]models.Vacancy, len(vacancies))
+	}
+
+	for _, vacancy := range vacancies {
+		p.remembered[vacancy.ID] = vacancy
+	}
+}
+
+// функция определения корневого элемента XML документа ("rss" или "feed")
+func feedRootElement(body []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// функция проверки, что текст содержит все слова запроса (без учёта регистра)
+func matchesAllTerms(text string, terms []string) bool {
+	lowered := strings.ToLower(text)
+	for _, term := range terms {
+		if !strings.Contains(lowered, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// функция получения стабильного короткого ID записи ленты (guid бывает длинной ссылкой)
+func feedEntryID(entry model.FeedEntry) string {
+	key := strings.TrimSpace(entry.ID)
+	if key == "" {
+		key = strings.TrimSpace(entry.Link)
+	}
+	if key == "" {
+		key = entry.Title + entry.PublishedAt
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:8])
+}
+
+// функция разбора даты публикации записи ленты (RSS использует RFC1123, Atom - RFC3339)
+func parseFeedDate(date string) time.Time {
+	date = strings.TrimSpace(date)
+	layouts := []string{time.RFC1123Z, time.RFC1123, time.RFC3339, "Mon, 2 Jan 2006 15:04:05 -0700", "2006-01-02"}
+
+	for _, layout := range layouts {
+		if publishedAt, err := time.Parse(layout, date); err == nil {
+			return publishedAt
+		}
+	}
+	return time.Time{}
+}
+
+// функция выдачи нужной страницы из полного списка вакансий (нумерация страниц с 1)
+func paginateVacancies(vacancies []models.Vacancy, page, perPage int) []models.Vacancy {
+	if perPage <= 0 {
+		return vacancies
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(vacancies) {
+		return []models.Vacancy{}
+	}
+
+	end := start + perPage
+	if end > len(vacancies) {
+		end = len(vacancies)
+	}
+	return vacancies[start:end]
+}
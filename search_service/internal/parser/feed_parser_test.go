@@ -0,0 +1,197 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"shared/config"
+	"testing"
+	"time"
+)
+
+// функция создания парсера лент, который ходит в тестовый сервер с RSS и Atom фикстурами
+func newTestFeedParser(t *testing.T, extraFeeds ...string) *FeedParser {
+	t.Helper()
+
+	rss := loadFixture(t, "feed_rss.xml")
+	atom := loadFixture(t, "feed_atom.xml")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/backend.rss", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write(rss)
+	})
+	mux.HandleFunc("/careers.atom", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/atom+xml")
+		w.Write(atom)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cfg := newTestParserConfig("")
+	cfg.Name = "Feeds"
+	cfg.FeedURLs = []string{server.URL + "/backend.rss", server.URL + "/careers.atom"}
+	for _, feed := range extraFeeds {
+		cfg.FeedURLs = append(cfg.FeedURLs, server.URL+feed)
+	}
+
+	p, err := NewFeedParser(cfg)
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+	return p.(*FeedParser)
+}
+
+func TestNewFeedParser_Validation(t *testing.T) {
+	if _, err := NewFeedParser(nil); err == nil {
+		t.Error("ожидалась ошибка для пустого конфига")
+	}
+
+	cfg := newTestParserConfig("")
+	cfg.Name = "Feeds"
+	if _, err := NewFeedParser(cfg); err == nil {
+		t.Error("ожидалась ошибка для конфига без feed_urls")
+	}
+}
+
+func TestFeedParser_ParseFeed(t *testing.T) {
+	p := newTestFeedParser(t)
+
+	data, err := p.parseFeed(loadFixture(t, "feed_rss.xml"))
+	if err != nil {
+		t.Fatalf("ошибка разбора RSS: %v", err)
+	}
+	vacancies, err := p.convertToUniversal(data, "")
+	if err != nil {
+		t.Fatalf("ошибка конвертации RSS: %v", err)
+	}
+	if len(vacancies) != 3 {
+		t.Fatalf("ожидалось 3 записи RSS, получено %d", len(vacancies))
+	}
+
+	first := vacancies[0]
+	if first.Job != "Senior Golang Developer" || first.Company != "Acme" || first.Source != "Feeds" {
+		t.Errorf("неверно сконвертирована запись RSS: %+v", first)
+	}
+	if !first.PublishedAt.Equal(time.Date(2024, 10, 14, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("неверная дата публикации: %v", first.PublishedAt)
+	}
+	// без dc:creator и author компанией считается название канала
+	if vacancies[1].Company != "Remote Jobs: Back-End Programming" {
+		t.Errorf("неверная компания для записи без автора: %s", vacancies[1].Company)
+	}
+
+	data, err = p.parseFeed(loadFixture(t, "feed_atom.xml"))
+	if err != nil {
+		t.Fatalf("ошибка разбора Atom: %v", err)
+	}
+	vacancies, err = p.convertToUniversal(data, "")
+	if err != nil {
+		t.Fatalf("ошибка конвертации Atom: %v", err)
+	}
+	if len(vacancies) != 2 {
+		t.Fatalf("ожидалось 2 записи Atom, получено %d", len(vacancies))
+	}
+	if vacancies[0].URL != "https://careers.umbrella.example/jobs/101" || vacancies[0].Company != "Umbrella Careers" {
+		t.Errorf("неверно сконвертирована запись Atom: %+v", vacancies[0])
+	}
+	if vacancies[1].Company != "Umbrella Digital" || vacancies[1].Description != "React, TypeScript" {
+		t.Errorf("неверно сконвертирована запись Atom с автором: %+v", vacancies[1])
+	}
+
+	if _, err := p.parseFeed([]byte(`<html><body>not a feed</body></html>`)); err == nil {
+		t.Error("ожидалась ошибка для документа, который не является лентой")
+	}
+}
+
+func TestFeedParser_SearchVacancies(t *testing.T) {
+	p := newTestFeedParser(t)
+
+	vacancies, err := p.SearchVacancies(context.Background(), models.SearchParams{Text: "golang"})
+	if err != nil {
+		t.Fatalf("ошибка поиска: %v", err)
+	}
+
+	// golang встречается в двух записях RSS (в названии и в описании) и в одной записи Atom
+	if len(vacancies) != 3 {
+		t.Fatalf("ожидалось 3 вакансии, получено %d: %+v", len(vacancies), vacancies)
+	}
+	// свежие записи - первыми
+	if vacancies[0].Job != "Golang Backend Engineer (Platform)" {
+		t.Errorf("ожидалась самая свежая запись первой, получено %s", vacancies[0].Job)
+	}
+
+	page, err := p.SearchVacancies(context.Background(), models.SearchParams{Text: "golang", Page: 2, PerPage: 2})
+	if err != nil {
+		t.Fatalf("ошибка поиска: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != vacancies[2].ID {
+		t.Errorf("неверная вторая страница: %+v", page)
+	}
+
+	// детали отдаются из последних полученных записей
+	details, err := p.SearchVacanciesDetailes(context.Background(), vacancies[1].ID)
+	if err != nil {
+		t.Fatalf("ошибка получения деталей: %v", err)
+	}
+	if details.Name != vacancies[1].Job || details.Url != vacancies[1].URL {
+		t.Errorf("неверные детали вакансии: %+v", details)
+	}
+	if _, err := p.SearchVacanciesDetailes(context.Background(), "unknown"); err == nil {
+		t.Error("ожидалась ошибка для неизвестной вакансии")
+	}
+}
+
+func TestFeedParser_PartialFailure(t *testing.T) {
+	// недоступная лента не должна ломать выдачу остальных
+	p := newTestFeedParser(t, "/missing.rss")
+
+	vacancies, err := p.SearchVacancies(context.Background(), models.SearchParams{Text: "python"})
+	if err != nil {
+		t.Fatalf("ошибка поиска при частично недоступных лентах: %v", err)
+	}
+	if len(vacancies) != 1 || vacancies[0].Job != "Python Engineer" {
+		t.Errorf("неверный результат поиска: %+v", vacancies)
+	}
+}
+
+func TestFeedParser_LoadFromYAML(t *testing.T) {
+	yml := `
+feeds:
+  - name: 'Example Feeds'
+    enabled: true
+    rate_limit: 1s
+    max_concurrent: 2
+    feed_urls:
+      - 'https://example.com/jobs.rss'
+      - 'https://example.com/careers.atom'
+`
+	path := filepath.Join(t.TempDir(), "parsers.yml")
+	if err := os.WriteFile(path, []byte(yml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadYAMLConfig(path, func() *configs.ParsersConfig { return &configs.ParsersConfig{} })
+	if err != nil {
+		t.Fatalf("ошибка загрузки конфига: %v", err)
+	}
+
+	factory := NewParserFactory()
+	enabled := factory.RegisterFeeds(cfg.Feeds)
+	if len(enabled) != 1 || enabled[0] != "feed:Example Feeds" {
+		t.Fatalf("неверный список включённых парсеров: %v", enabled)
+	}
+
+	parser, err := factory.Create(enabled[0])
+	if err != nil {
+		t.Fatalf("фабрика не смогла создать парсер: %v", err)
+	}
+	if parser.GetName() != "Example Feeds" || len(parser.(*FeedParser).feedURLs) != 2 {
+		t.Errorf("парсер создан неверно: %s", parser.GetName())
+	}
+}
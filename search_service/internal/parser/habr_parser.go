@@ -0,0 +1,285 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/internal/locations"
+	"search_service/internal/parser/model"
+
+	"search_service/internal/search_interfaces"
+)
+
+// создаём стркутуру парсера для Habr Career на базе общего парсера
+type HabrParser struct {
+	*BaseParser
+	siteURL string // адрес сайта (scheme://host), нужен для построения абсолютных ссылок на вакансии
+}
+
+// конструктор для парсера Habr Career
+func NewHabrParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, error) {
+	if cfg == nil {
+		cfg = configs.DefaultParsersConfig().Habr
+	}
+
+	baseCfg := BaseConfig{
+		Name:                  "Habr.Career",
+		BaseURL:               cfg.BaseURL,
+		HealthEndPoint:        cfg.HealthEndPoint,
+		APIKey:                cfg.APIKey,
+		Timeout:               cfg.Timeout,
+		RateLimit:             cfg.RateLimit,
+		MaxConcurrent:         cfg.MaxConcurrent,
+		CircuitBreakerCfg:     cfg.CircuitBreaker,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		Request:               cfg.Request,
+	}
+
+	baseParser, err := NewBaseParser(baseCfg)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка в конфигурации rate limiter для парсера %s\n", baseCfg.Name)
+	}
+
+	// вычисляем адрес сайта из базового URL API
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("некорректный base_url для парсера %s: %w", baseCfg.Name, err)
+	}
+
+	return &HabrParser{
+		BaseParser: baseParser,
+		siteURL:    u.Scheme + "://" + u.Host,
+	}, nil
+}
+
+// метод парсера для поиска списка вакансий
+func (p *HabrParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
+	page, err := p.SearchVacanciesPage(ctx, params)
+	return page.Vacancies, err
+}
+
+// метод парсера для поиска одной страницы вакансий вместе с общим количеством найденного
+func (p *HabrParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	return p.BaseParser.SearchVacanciesPage(
+		ctx,
+		params,
+		ParserFuncs{
+			BuildURL: p.buildURL,
+			Parse:    p.parseResponseSearchVacancies,
+			Convert:  p.convertToUniversal,
+			Totals:   habrTotals,
+		},
+	)
+}
+
+// метод парсера для поиска деталей по конкретной вакансии
+func (p *HabrParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string) (models.SearchVacancyDetailesResult, error) {
+	return p.BaseParser.SearchVacancyDetailes(
+		ctx,
+		vacancyID,
+		ParserFuncs{
+			Parse:          p.parseResponseSearchDetails,
+			ConvertDetails: p.convertDetails,
+		},
+	)
+}
+
+// справочник локаций, которым пользуется источник
+func (p *HabrParser) LocationCatalog() string {
+	return locations.CatalogHabr
+}
+
+// buildURL строит URL для API запроса для поиска списка вакансий
+func (p *HabrParser) buildURL(params models.SearchParams) (string, error) {
+	// преобразуем строку запроса в структуру URL
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	// заводим переменную, где будут хнаниться значения
+	query := u.Query()
+
+	// добавляем основной параметр поиска
+	if params.Text != "" {
+		query.Set("q", params.Text)
+	}
+
+	// добавляем параметр - локация (Habr Career принимает только коды городов из своего справочника)
+	if params.Country != "" {
+		if location, ok := p.locations.Resolve(locations.CatalogHabr, params.Country); ok {
+			query.Set("locations[]", location.Code)
+		}
+	}
+
+	// добавляем параетры страниц
+	perPage := params.PerPage
+	if perPage <= 0 || perPage > 100 {
+		perPage = 25 // Значение по умолчанию для Habr Career
+	}
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	// Habr Career использует 1-based нумерацию страниц, как и наш API
+	if params.Page > 0 {
+		query.Set("page", strconv.Itoa(params.Page))
+	}
+
+	// формируем строку эндпоинта для запроса
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// метод парсера обработки тела запроса при поиске списка вакансий
+func (p *HabrParser) parseResponseSearchVacancies(body []byte) (interface{}, error) {
+	var searchResponse model.HabrSearchResponse
+	if err := json.Unmarshal(body, &searchResponse); err != nil {
+		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+	}
+	return &searchResponse, nil
+}
+
+// метод парсера обработки тела запроса при поиске деталей вакансии
+func (p *HabrParser) parseResponseSearchDetails(body []byte) (interface{}, error) {
+	var detailsResponse model.HabrVacancyDetails
+	if err := json.Unmarshal(body, &detailsResponse); err != nil {
+		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+	}
+	return &detailsResponse, nil
+}
+
+// метод приведения результатов поиска у унифицированной структуре + проверка данных их интерфейса
+func (p *HabrParser) convertToUniversal(searchResponse interface{}) ([]models.Vacancy, error) {
+	// проверка интерфейса на nil
+	if searchResponse == nil {
+		return []models.Vacancy{}, fmt.Errorf("[%s] searchResponse is nil", p.name)
+	}
+
+	// Проводим type assertion
+	searchResp, ok := searchResponse.(*model.HabrSearchResponse)
+	if !ok {
+
+		// Для более детальной информации можно использовать reflect
+		fmt.Printf("----------------->>>[Parser name: %s] DEBUG: Type details: %v\n", p.name, reflect.TypeOf(searchResponse))
+		return []models.Vacancy{}, fmt.Errorf("[Parser name: %s], wrong data type in the response body\n", p.name)
+	}
+
+	// сразу инициализируем слайс универсальных вакансий, чтобы уменьшить количество переаалокаций, если выйдем за размер базового массива слайса
+	universalVacancies := make([]models.Vacancy, len(searchResp.Items))
+
+	for i, hv := range searchResp.Items {
+		universalVacancies[i] = models.Vacancy{
+			ID:          strconv.Itoa(hv.ID),
+			Job:         hv.Title,
+			Company:     hv.Company.Title,
+			Salary:      habrSalary(hv.Salary),
+			Location:    hv.GetLocation(),
+			Experience:  hv.Qualification.Title,
+			Schedule:    p.convertSchedule(hv.RemoteWork),
+			URL:         p.absoluteURL(hv.Href),
+			Source:      p.GetName(),
+			PublishedAt: p.parsePublishedAt(hv.PublishedDate.Date),
+		}
+	}
+
+	return universalVacancies, nil
+}
+
+// метод приведения результатов поиска по конкретной ваансии к нужному типу + проверка данных интерфейса
+func (p *HabrParser) convertDetails(detailsResponse interface{}) (models.SearchVacancyDetailesResult, error) {
+	// проверка интерфейса на nil
+	if detailsResponse == nil {
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[%s] searchResponse is nil", p.name)
+	}
+
+	// Проводим type assertion
+	details, ok := detailsResponse.(*model.HabrVacancyDetails)
+	if !ok {
+
+		// Для более детальной информации можно использовать reflect
+		fmt.Printf("----------------->>>[Parser name: %s] DEBUG: Type details: %v\n", p.name, reflect.TypeOf(detailsResponse))
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[Parser name: %s], wrong data type in the response body\n", p.name)
+	}
+
+	var location models.Area
+	if len(details.Locations) > 0 {
+		location.Name = details.Locations[0].Title
+	}
+
+	vacDetails := models.SearchVacancyDetailesResult{
+		Employer: models.Employer{
+			ID:   details.Company.Href,
+			Name: details.Company.Title,
+		},
+		Location:    location,
+		Salary:      habrSalary(details.Salary),
+		Description: details.Description,
+		Name:        details.Title,
+		ID:          strconv.Itoa(details.ID),
+		Url:         p.absoluteURL(details.Href),
+	}
+
+	return vacDetails, nil
+}
+
+// метод для построения абсолютной ссылки на вакансию (API Habr Career отдаёт относительные ссылки)
+func (p *HabrParser) absoluteURL(href string) string {
+	if href == "" {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil || u.IsAbs() {
+		return href
+	}
+	return p.siteURL + href
+}
+
+// метод для конвертации признака удалённой работы в код графика работы
+func (p *HabrParser) convertSchedule(remote bool) string {
+	if remote {
+		return "remote"
+	}
+	return ""
+}
+
+// метод для разбора даты публикации вакансии
+func (p *HabrParser) parsePublishedAt(date string) time.Time {
+	publishedAt, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}
+	}
+	return publishedAt
+}
+
+// функция получения общего количества найденных вакансий и страниц из ответа Habr Career
+func habrTotals(searchResponse interface{}) (int, int) {
+	searchResp, ok := searchResponse.(*model.HabrSearchResponse)
+	if !ok {
+		return 0, 0
+	}
+	return searchResp.Meta.TotalResults, searchResp.Meta.TotalPages
+}
+
+// функция приведения зарплаты Habr Career к общей структуре (Habr Career указывает зарплату за месяц)
+func habrSalary(salary model.HabrSalary) models.Salary {
+	if salary.From == 0 && salary.To == 0 {
+		return models.Salary{}
+	}
+
+	return models.Salary{
+		From:     salary.From,
+		To:       salary.To,
+		Currency: salary.NormalizedCurrency(),
+		Period:   models.SalaryPeriodMonth,
+	}
+}
@@ -0,0 +1,221 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"testing"
+	"time"
+)
+
+// функция загрузки записанного ответа внешнего API из каталога testdata
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("не удалось прочитать фикстуру %s: %v", name, err)
+	}
+	return data
+}
+
+// функция создания конфига парсера, который смотрит на тестовый сервер
+func newTestParserConfig(baseURL string) *configs.ParserInstanceConfig {
+	return &configs.ParserInstanceConfig{
+		Enabled:       true,
+		BaseURL:       baseURL,
+		Timeout:       5 * time.Second,
+		RateLimit:     10 * time.Millisecond,
+		MaxConcurrent: 2,
+	}
+}
+
+// функция создания парсера Habr Career, который ходит в тестовый сервер с фикстурами
+func newTestHabrParser(t *testing.T) (*HabrParser, *httptest.Server) {
+	t.Helper()
+
+	search := loadFixture(t, "habr_search.json")
+	details := loadFixture(t, "habr_details.json")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/frontend/vacancies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(search)
+	})
+	mux.HandleFunc("/api/frontend/vacancies/1000145101", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(details)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	p, err := NewHabrParser(newTestParserConfig(server.URL + "/api/frontend/vacancies"))
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+
+	return p.(*HabrParser), server
+}
+
+// проверяем формирование URL поиска
+func TestHabrParser_BuildURL(t *testing.T) {
+	p, _ := newTestHabrParser(t)
+
+	rawURL, err := p.buildURL(models.SearchParams{Text: "golang", PerPage: 10, Page: 2})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("некорректный URL: %v", err)
+	}
+
+	query := u.Query()
+	if query.Get("q") != "golang" {
+		t.Errorf("ожидался q=golang, получено %q", query.Get("q"))
+	}
+	if query.Get("per_page") != "10" {
+		t.Errorf("ожидался per_page=10, получено %q", query.Get("per_page"))
+	}
+	if query.Get("page") != "2" {
+		t.Errorf("ожидался page=2, получено %q", query.Get("page"))
+	}
+
+	// проверяем значение per_page по умолчанию
+	rawURL, _ = p.buildURL(models.SearchParams{Text: "golang"})
+	u, _ = url.Parse(rawURL)
+	if u.Query().Get("per_page") != "25" {
+		t.Errorf("ожидался per_page=25 по умолчанию, получено %q", u.Query().Get("per_page"))
+	}
+}
+
+// проверяем разбор и конвертацию записанного ответа поиска
+func TestHabrParser_ParseAndConvert(t *testing.T) {
+	p, server := newTestHabrParser(t)
+
+	parsed, err := p.parseResponseSearchVacancies(loadFixture(t, "habr_search.json"))
+	if err != nil {
+		t.Fatalf("ошибка разбора: %v", err)
+	}
+
+	vacancies, err := p.convertToUniversal(parsed)
+	if err != nil {
+		t.Fatalf("ошибка конвертации: %v", err)
+	}
+
+	if len(vacancies) != 2 {
+		t.Fatalf("ожидалось 2 вакансии, получено %d", len(vacancies))
+	}
+
+	first := vacancies[0]
+	if first.ID != "1000145101" {
+		t.Errorf("неверный ID: %s", first.ID)
+	}
+	if first.Job != "Backend-разработчик (Go)" || first.Company != "Ozon Tech" {
+		t.Errorf("неверные название/компания: %s / %s", first.Job, first.Company)
+	}
+	wantSalary := models.Salary{From: 250000, To: 400000, Currency: "RUB", Period: models.SalaryPeriodMonth}
+	if first.Salary != wantSalary {
+		t.Errorf("неверная зарплата: %+v", first.Salary)
+	}
+	if first.Location != "Москва" {
+		t.Errorf("ожидался город Москва, получено %s", first.Location)
+	}
+	if first.Schedule != "remote" {
+		t.Errorf("ожидался график remote, получено %s", first.Schedule)
+	}
+	if first.Experience != "Middle" {
+		t.Errorf("ожидалась квалификация Middle, получено %s", first.Experience)
+	}
+	if first.URL != server.URL+"/vacancies/1000145101" {
+		t.Errorf("неверная ссылка: %s", first.URL)
+	}
+	if first.Source != "Habr.Career" {
+		t.Errorf("неверный источник: %s", first.Source)
+	}
+	if first.PublishedAt.IsZero() {
+		t.Error("дата публикации должна быть заполнена")
+	}
+
+	second := vacancies[1]
+	if second.Salary.Specified() {
+		t.Errorf("для вакансии без зарплаты ожидалась пустая зарплата, получено %+v", second.Salary)
+	}
+	if second.Location != "" {
+		t.Errorf("для вакансии без локаций ожидался пустой город, получено %s", second.Location)
+	}
+
+	// неверный тип данных должен приводить к ошибке
+	if _, err := p.convertToUniversal(&models.Vacancy{}); err == nil {
+		t.Error("ожидалась ошибка при неверном типе данных")
+	}
+}
+
+// проверяем полный цикл поиска через BaseParser (rate limiter, семафор, circuit breaker)
+func TestHabrParser_SearchVacancies(t *testing.T) {
+	p, _ := newTestHabrParser(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	vacancies, err := p.SearchVacancies(ctx, models.SearchParams{Text: "golang", PerPage: 2, Page: 1})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка поиска: %v", err)
+	}
+	if len(vacancies) != 2 {
+		t.Errorf("ожидалось 2 вакансии, получено %d", len(vacancies))
+	}
+}
+
+func TestHabrParser_SearchVacanciesPage(t *testing.T) {
+	p, _ := newTestHabrParser(t)
+
+	page, err := p.SearchVacanciesPage(context.Background(), models.SearchParams{Text: "golang", PerPage: 2, Page: 1})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка поиска: %v", err)
+	}
+
+	// общее количество найденного берётся из meta ответа
+	if page.Found != 137 || page.Pages != 69 || len(page.Vacancies) != 2 {
+		t.Errorf("неверные данные страницы: found=%d pages=%d vacancies=%d", page.Found, page.Pages, len(page.Vacancies))
+	}
+}
+
+// проверяем получение деталей вакансии
+func TestHabrParser_SearchVacanciesDetailes(t *testing.T) {
+	p, server := newTestHabrParser(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	details, err := p.SearchVacanciesDetailes(ctx, "1000145101")
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	if details.ID != "1000145101" || details.Name != "Backend-разработчик (Go)" {
+		t.Errorf("неверные данные вакансии: %+v", details)
+	}
+	if details.Employer.Name != "Ozon Tech" {
+		t.Errorf("неверный работодатель: %s", details.Employer.Name)
+	}
+	if details.Salary.From != 250000 || details.Salary.To != 400000 || details.Salary.Currency != "RUB" {
+		t.Errorf("неверная зарплата: %+v", details.Salary)
+	}
+	if details.Location.Name != "Москва" {
+		t.Errorf("неверный город: %s", details.Location.Name)
+	}
+	if details.Url != server.URL+"/vacancies/1000145101" {
+		t.Errorf("неверная ссылка: %s", details.Url)
+	}
+	if details.Description == "" {
+		t.Error("описание должно быть заполнено")
+	}
+}
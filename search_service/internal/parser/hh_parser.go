@@ -12,10 +12,13 @@ import (
 
 	"search_service/configs"
 	"search_service/internal/domain/models"
+	"search_service/internal/locations"
 	"search_service/internal/parser/model"
+	"search_service/pkg"
 
 	"search_service/internal/search_interfaces"
 	"strconv"
+	"time"
 )
 
 // создаём стркутуру парсера для HH.ru на базе общего парсера
@@ -33,6 +36,7 @@ func NewHHParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, e
 		Name:                  "HH.ru",
 		BaseURL:               cfg.BaseURL,
 		HealthEndPoint:        cfg.HealthEndPoint,
+		APIKey:                cfg.APIKey,
 		Timeout:               cfg.Timeout,
 		RateLimit:             cfg.RateLimit,
 		MaxConcurrent:         cfg.MaxConcurrent,
@@ -42,6 +46,7 @@ func NewHHParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, e
 		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
 		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
 		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		Request:               cfg.Request,
 	}
 
 	baseParser, err := NewBaseParser(baseCfg)
@@ -56,13 +61,20 @@ func NewHHParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, e
 
 // метод парсера для поиска списка вакансий
 func (p *HHParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
-	return p.BaseParser.SearchVacancies(
+	page, err := p.SearchVacanciesPage(ctx, params)
+	return page.Vacancies, err
+}
+
+// метод парсера для поиска одной страницы вакансий вместе с общим количеством найденного
+func (p *HHParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	return p.BaseParser.SearchVacanciesPage(
 		ctx,
 		params,
 		ParserFuncs{
 			BuildURL: p.buildURL,
 			Parse:    p.parseResponseSearchVacancies,
 			Convert:  p.convertToUniversal,
+			Totals:   hhTotals,
 		},
 	)
 }
@@ -88,22 +100,6 @@ func (p *HHParser) buildURL(params models.SearchParams) (string, error) {
 		return "", err
 	}
 
-	// мапа кодов стран в классификторе HH.ru
-	presavedCountries := map[string]int{
-		"Россия":       113,
-		"Украина":      5,
-		"Беларусь":     16,
-		"Казахстан":    40,
-		"Азербайджан":  97,
-		"Армения":      4,
-		"Грузия":       28,
-		"Кыргызтан":    115,
-		"Таджикистан":  1396,
-		"Туркменистан": 100,
-		"Узбекистан":   99,
-		"Молдова":      11,
-	}
-
 	// заводим переменную, где будут хнаниться значения
 	query := u.Query()
 
@@ -114,11 +110,7 @@ func (p *HHParser) buildURL(params models.SearchParams) (string, error) {
 
 	// добавляем параметр - локация
 	if params.Country != "" {
-		countryId, ok := presavedCountries[params.Country]
-		if !ok {
-			query.Set("area", strconv.Itoa(113))
-		}
-		query.Set("area", strconv.Itoa(countryId))
+		query.Set("area", hhAreaCode(p.locations, params.Country))
 	}
 
 	// добавляем параетры страниц
@@ -128,10 +120,13 @@ func (p *HHParser) buildURL(params models.SearchParams) (string, error) {
 	}
 	query.Set("per_page", strconv.Itoa(perPage))
 
-	if params.Page > 0 {
-		query.Set("page", strconv.Itoa(params.Page))
+	if params.Page > 1 {
+		query.Set("page", strconv.Itoa(params.Page-1)) // HH.ru использует 0-based
 	}
 
+	// добавляем фильтры поиска
+	setHHFilters(query, params, time.Now())
+
 	// формируем строку эндпоинта для запроса
 	u.RawQuery = query.Encode()
 	return u.String(), nil
@@ -181,16 +176,15 @@ func (p *HHParser) convertToUniversal(searchResponse interface{}) ([]models.Vaca
 	universalVacancies := make([]models.Vacancy, len(searchResp.Items))
 
 	for i, hhvacancy := range searchResp.Items {
-		// получаем строку-описания вилки зарплаты для каждой найденной записи
-		salary := hhvacancy.GetSalaryString()
-
 		universalVacancies[i] = models.Vacancy{
 			ID:          hhvacancy.ID,
 			Job:         hhvacancy.Name,
 			Company:     hhvacancy.Employer.Name,
-			Currency:    hhvacancy.Salary.Currency,
-			Salary:      &salary,
+			Salary:      hhSalary(hhvacancy.Salary, hhvacancy.SalaryRange.Mode.ID),
 			Location:    hhvacancy.Area.Name,
+			Experience:  hhvacancy.Experience.ID,
+			Schedule:    hhvacancy.Schedule.ID,
+			Employment:  hhvacancy.Employment.ID,
 			URL:         hhvacancy.URL,
 			Source:      p.GetName(),
 			Description: hhvacancy.Description,
@@ -219,7 +213,7 @@ func (p *HHParser) convertDetails(detailsResponse interface{}) (models.SearchVac
 	vacDetails := models.SearchVacancyDetailesResult{
 		Employer:    models.Employer(searchResp.Employer),
 		Location:    models.Area(searchResp.Area),
-		Salary:      models.Salary(searchResp.Salary),
+		Salary:      hhSalary(searchResp.Salary, ""),
 		Description: searchResp.Description,
 		Name:        searchResp.Name,
 		ID:          searchResp.ID,
@@ -236,7 +230,7 @@ func (p *HHParser) GetVacancyByID(vacancyID string) (*model.HHVacancy, error) {
 	}
 
 	apiURL := p.baseURL + "/" + vacancyID
-	resp, err := p.httpClient.Get(apiURL)
+	resp, err := p.executeRequest(context.Background(), apiURL)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -265,3 +259,86 @@ func (p *HHParser) GetVacancyByID(vacancyID string) (*model.HHVacancy, error) {
 func (p *HHParser) GetName() string {
 	return "HH.ru"
 }
+
+// справочник локаций, которым пользуется источник
+func (p *HHParser) LocationCatalog() string {
+	return locations.CatalogHH
+}
+
+// код региона HH.ru, в котором ищем, если локация не найдена в справочнике (Россия)
+const hhDefaultArea = "113"
+
+// функция получения кода региона в классификаторе HH.ru (им же пользуются HH-совместимые источники, например Зарплата.ру)
+// город, регион или страна ищутся в справочнике локаций, если локация неизвестна - используем Россию
+func hhAreaCode(resolver search_interfaces.LocationResolver, text string) string {
+	if location, ok := resolver.Resolve(locations.CatalogHH, text); ok {
+		return location.Code
+	}
+	return hhDefaultArea
+}
+
+// функция получения общего количества найденных вакансий и страниц из ответа HH-совместимого API
+func hhTotals(searchResponse interface{}) (int, int) {
+	searchResp, ok := searchResponse.(*model.SearchResponse)
+	if !ok {
+		return 0, 0
+	}
+	return searchResp.Found, searchResp.Pages
+}
+
+// максимальное значение параметра period у HH.ru (в днях), для большего периода используется date_from
+const hhMaxPeriodDays = 30
+
+// сортировка выдачи: универсальный код -> параметр order_by HH.ru
+var hhOrderBy = map[string]string{
+	models.OrderRelevance:  "relevance",
+	models.OrderDate:       "publication_time",
+	models.OrderSalaryDesc: "salary_desc",
+	models.OrderSalaryAsc:  "salary_asc",
+}
+
+// функция перевода фильтров поиска в параметры HH-совместимого API
+// коды опыта, графика и занятости у нас совпадают со справочниками HH.ru, поэтому передаются как есть
+func setHHFilters(query url.Values, params models.SearchParams, now time.Time) {
+	if params.SalaryFrom > 0 {
+		query.Set("salary", strconv.Itoa(params.SalaryFrom))
+	}
+	if params.OnlyWithSalary {
+		query.Set("only_with_salary", "true")
+	}
+	if params.Experience != "" {
+		query.Set("experience", params.Experience)
+	}
+	if params.Schedule != "" {
+		query.Set("schedule", params.Schedule)
+	}
+	if params.Employment != "" {
+		query.Set("employment", params.Employment)
+	}
+
+	switch {
+	case params.PublishedWithinDays > hhMaxPeriodDays:
+		query.Set("date_from", now.AddDate(0, 0, -params.PublishedWithinDays).Format("2006-01-02"))
+	case params.PublishedWithinDays > 0:
+		query.Set("period", strconv.Itoa(params.PublishedWithinDays))
+	}
+
+	if orderBy, ok := hhOrderBy[params.OrderBy]; ok {
+		query.Set("order_by", orderBy)
+	}
+}
+
+// функция приведения зарплаты из ответа HH-совместимого API к общей структуре
+func hhSalary(salary model.Salary, mode string) models.Salary {
+	if salary.From == 0 && salary.To == 0 {
+		return models.Salary{}
+	}
+
+	return models.Salary{
+		From:     salary.From,
+		To:       salary.To,
+		Currency: pkg.NormalizeCurrency(salary.Currency),
+		Gross:    salary.Gross,
+		Period:   models.NormalizeSalaryPeriod(mode),
+	}
+}
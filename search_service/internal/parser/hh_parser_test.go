@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"net/url"
+	"search_service/internal/domain/models"
+	"testing"
+	"time"
+)
+
+func TestHHParser_BuildURLFilters(t *testing.T) {
+	p, err := NewHHParser(newTestParserConfig("https://api.hh.ru/vacancies"))
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+	hh := p.(*HHParser)
+
+	rawURL, err := hh.buildURL(models.SearchParams{
+		Text:                "golang",
+		Page:                2,
+		SalaryFrom:          150000,
+		OnlyWithSalary:      true,
+		Experience:          models.Experience1To3,
+		Schedule:            models.ScheduleRemote,
+		Employment:          models.EmploymentFull,
+		PublishedWithinDays: 7,
+		OrderBy:             models.OrderDate,
+	})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	u, _ := url.Parse(rawURL)
+	want := map[string]string{
+		"text":             "golang",
+		"page":             "1",
+		"salary":           "150000",
+		"only_with_salary": "true",
+		"experience":       "between1And3",
+		"schedule":         "remote",
+		"employment":       "full",
+		"period":           "7",
+		"order_by":         "publication_time",
+	}
+	for key, value := range want {
+		if got := u.Query().Get(key); got != value {
+			t.Errorf("ожидался %s=%s, получено %q", key, value, got)
+		}
+	}
+
+	// без фильтров - лишних параметров нет
+	rawURL, _ = hh.buildURL(models.SearchParams{Text: "golang"})
+	u, _ = url.Parse(rawURL)
+	for _, key := range []string{"salary", "only_with_salary", "experience", "schedule", "employment", "period", "order_by"} {
+		if u.Query().Has(key) {
+			t.Errorf("параметр %s не ожидался без фильтров", key)
+		}
+	}
+}
+
+func TestSetHHFilters_LongPeriod(t *testing.T) {
+	now := time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	query := url.Values{}
+	setHHFilters(query, models.SearchParams{PublishedWithinDays: 90}, now)
+
+	// period у HH.ru не больше 30 дней - для большего периода используется date_from
+	if query.Has("period") || query.Get("date_from") != "2024-07-17" {
+		t.Errorf("неверные параметры периода: %v", query)
+	}
+}
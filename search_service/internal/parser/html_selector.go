@@ -0,0 +1,317 @@
+// упрощённые CSS селекторы для парсеров HTML страниц
+// поддерживается: тег, *, #id, .class, [attr], [attr=value], потомок через пробел и прямой потомок через ">"
+// значения атрибутов с пробелами, псевдоклассы и группы через запятую не поддерживаются
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// одно простое условие селектора (например "div.vacancy-card[data-type=job]") и его связь с предыдущим
+type selectorStep struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []attrMatcher
+	child   bool // связь с предыдущим шагом через ">" (прямой потомок), иначе любой потомок
+}
+
+// условие на атрибут элемента
+type attrMatcher struct {
+	name     string
+	value    string
+	hasValue bool
+}
+
+// скомпилированный селектор - цепочка шагов от внешнего элемента к искомому
+type cssSelector []selectorStep
+
+// селектор поля: откуда взять значение (элемент внутри области поиска или сама область) и какой атрибут
+type fieldSelector struct {
+	selector cssSelector // nil - значение берётся с самого элемента области поиска
+	attr     string      // пусто - берётся текст элемента
+}
+
+// функция разбора селектора в цепочку шагов
+func parseSelector(raw string) (cssSelector, error) {
+	tokens := strings.Fields(strings.ReplaceAll(raw, ">", " > "))
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty selector")
+	}
+
+	var (
+		selector cssSelector
+		child    bool
+	)
+	for _, token := range tokens {
+		if token == ">" {
+			if len(selector) == 0 || child {
+				return nil, fmt.Errorf("selector %q: unexpected '>'", raw)
+			}
+			child = true
+			continue
+		}
+
+		step, err := parseSelectorStep(token)
+		if err != nil {
+			return nil, fmt.Errorf("selector %q: %w", raw, err)
+		}
+		step.child = child
+		child = false
+		selector = append(selector, step)
+	}
+
+	if child {
+		return nil, fmt.Errorf("selector %q: trailing '>'", raw)
+	}
+	return selector, nil
+}
+
+// функция разбора одного шага селектора ("a.title#main[data-id]")
+func parseSelectorStep(token string) (selectorStep, error) {
+	var step selectorStep
+
+	for len(token) > 0 {
+		switch token[0] {
+		case '*':
+			token = token[1:]
+		case '#', '.':
+			name, rest := readSelectorIdent(token[1:])
+			if name == "" {
+				return step, fmt.Errorf("empty name after %q", token[0])
+			}
+			if token[0] == '#' {
+				step.id = name
+			} else {
+				step.classes = append(step.classes, name)
+			}
+			token = rest
+		case '[':
+			end := strings.IndexByte(token, ']')
+			if end < 0 {
+				return step, fmt.Errorf("unclosed '['")
+			}
+			inner := token[1:end]
+			matcher := attrMatcher{name: inner}
+			if eq := strings.IndexByte(inner, '='); eq >= 0 {
+				matcher = attrMatcher{
+					name:     inner[:eq],
+					value:    strings.Trim(inner[eq+1:], `"'`),
+					hasValue: true,
+				}
+			}
+			if matcher.name == "" {
+				return step, fmt.Errorf("empty attribute name")
+			}
+			step.attrs = append(step.attrs, matcher)
+			token = token[end+1:]
+		default:
+			name, rest := readSelectorIdent(token)
+			if name == "" {
+				return step, fmt.Errorf("unexpected symbol %q", token[0])
+			}
+			step.tag = strings.ToLower(name)
+			token = rest
+		}
+	}
+
+	return step, nil
+}
+
+// функция чтения имени (тега, класса, id) до следующего спецсимвола селектора
+func readSelectorIdent(s string) (string, string) {
+	end := strings.IndexAny(s, "#.[*")
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end:]
+}
+
+// функция разбора селектора поля вида "селектор@атрибут", "селектор" или "@атрибут"
+func parseFieldSelector(raw string) (*fieldSelector, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	field := &fieldSelector{}
+	if at := strings.LastIndexByte(raw, '@'); at >= 0 {
+		field.attr = strings.TrimSpace(raw[at+1:])
+		raw = strings.TrimSpace(raw[:at])
+		if field.attr == "" {
+			return nil, fmt.Errorf("field selector %q: empty attribute after '@'", raw)
+		}
+	}
+
+	if raw != "" {
+		selector, err := parseSelector(raw)
+		if err != nil {
+			return nil, err
+		}
+		field.selector = selector
+	}
+	return field, nil
+}
+
+// метод проверки элемента на соответствие одному шагу селектора
+func (s selectorStep) matches(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if s.tag != "" && n.Data != s.tag {
+		return false
+	}
+	if s.id != "" && nodeAttr(n, "id") != s.id {
+		return false
+	}
+
+	if len(s.classes) > 0 {
+		nodeClasses := strings.Fields(nodeAttr(n, "class"))
+		for _, class := range s.classes {
+			if !containsString(nodeClasses, class) {
+				return false
+			}
+		}
+	}
+
+	for _, attr := range s.attrs {
+		value, ok := lookupNodeAttr(n, attr.name)
+		if !ok || (attr.hasValue && value != attr.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// метод поиска всех элементов внутри scope (сам scope не проверяется), подходящих под селектор
+func (s cssSelector) selectAll(scope *html.Node) []*html.Node {
+	var found []*html.Node
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if s.matchStep(c, len(s)-1, scope) {
+				found = append(found, c)
+			}
+			walk(c)
+		}
+	}
+	walk(scope)
+
+	return found
+}
+
+// метод поиска первого элемента внутри scope, подходящего под селектор
+func (s cssSelector) selectFirst(scope *html.Node) *html.Node {
+	var walk func(n *html.Node) *html.Node
+	walk = func(n *html.Node) *html.Node {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if s.matchStep(c, len(s)-1, scope) {
+				return c
+			}
+			if found := walk(c); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return walk(scope)
+}
+
+// метод проверки элемента на шаг i селектора и всех предыдущих шагов на его предках (не выше scope)
+func (s cssSelector) matchStep(n *html.Node, i int, scope *html.Node) bool {
+	if !s[i].matches(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	if n == scope {
+		return false
+	}
+
+	if s[i].child {
+		return n.Parent != nil && s.matchStep(n.Parent, i-1, scope)
+	}
+
+	for p := n.Parent; p != nil; p = p.Parent {
+		if s.matchStep(p, i-1, scope) {
+			return true
+		}
+		if p == scope {
+			break
+		}
+	}
+	return false
+}
+
+// метод извлечения значения поля из области поиска; если элемент не найден - пустая строка
+func (f *fieldSelector) extract(scope *html.Node) string {
+	if f == nil {
+		return ""
+	}
+
+	node := scope
+	if f.selector != nil {
+		node = f.selector.selectFirst(scope)
+		if node == nil {
+			return ""
+		}
+	}
+
+	if f.attr != "" {
+		return strings.TrimSpace(nodeAttr(node, f.attr))
+	}
+	return nodeText(node)
+}
+
+// функция получения текста элемента с нормализацией пробелов (без содержимого script/style)
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style") {
+			return
+		}
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteByte(' ')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// функция получения значения атрибута элемента (пустая строка, если атрибута нет)
+func nodeAttr(n *html.Node, name string) string {
+	value, _ := lookupNodeAttr(n, name)
+	return value
+}
+
+// функция поиска атрибута элемента
+func lookupNodeAttr(n *html.Node, name string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == name {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// функция проверки наличия строки в слайсе
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
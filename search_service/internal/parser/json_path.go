@@ -0,0 +1,119 @@
+// упрощённый JSONPath для декларативных парсеров
+// поддерживается обращение к полям через точку и к элементам массивов через индекс: "results.vacancies", "addresses.address[0].location", "items.0.name"
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// функция разбора пути на сегменты ("a.b[0].c" -> ["a", "b", "0", "c"])
+func splitJSONPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	path = strings.ReplaceAll(path, "[", ".")
+	path = strings.ReplaceAll(path, "]", "")
+
+	var segments []string
+	for _, segment := range strings.Split(path, ".") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// функция получения значения по пути из результата json.Unmarshal (map[string]interface{} / []interface{})
+// пустой путь - возвращает сами данные
+func lookupJSONPath(data interface{}, path string) (interface{}, bool) {
+	current := data
+
+	for _, segment := range splitJSONPath(path) {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, current != nil
+}
+
+// функция получения строкового значения по пути, если значения нет - пустая строка
+func lookupString(data interface{}, path string) string {
+	if path == "" {
+		return ""
+	}
+
+	value, ok := lookupJSONPath(data, path)
+	if !ok {
+		return ""
+	}
+
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// функция получения целочисленного значения по пути, если значения нет или оно не число - 0
+func lookupInt(data interface{}, path string) int {
+	if path == "" {
+		return 0
+	}
+
+	value, ok := lookupJSONPath(data, path)
+	if !ok {
+		return 0
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return int(f)
+		}
+	case float64:
+		return int(v)
+	case string:
+		if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return i
+		}
+	}
+	return 0
+}
+
+// функция декодирования JSON в дерево интерфейсов с сохранением чисел как json.Number (без потери точности ID)
+func decodeJSONTree(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
@@ -0,0 +1,353 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/pkg"
+
+	"search_service/internal/search_interfaces"
+)
+
+// создаём стркутуру декларативного парсера на базе общего парсера
+// параметры запроса, пагинация и пути до полей вакансии описываются в parsersConfig.yml (секция mapped)
+type MappedParser struct {
+	*BaseParser
+	mapping *configs.MappingConfig
+}
+
+// конструктор для декларативного парсера
+func NewMappedParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, error) {
+	// для декларативного парсера нет конфига по умолчанию - всё должно быть описано в yml
+	if cfg == nil {
+		return nil, errors.New("mapped parser requires config")
+	}
+	if err := validateMapping(cfg); err != nil {
+		return nil, err
+	}
+
+	baseCfg := BaseConfig{
+		Name:                  cfg.Name,
+		BaseURL:               cfg.BaseURL,
+		HealthEndPoint:        cfg.HealthEndPoint,
+		APIKey:                cfg.APIKey,
+		Timeout:               cfg.Timeout,
+		RateLimit:             cfg.RateLimit,
+		MaxConcurrent:         cfg.MaxConcurrent,
+		CircuitBreakerCfg:     cfg.CircuitBreaker,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		Request:               cfg.Request,
+	}
+
+	baseParser, err := NewBaseParser(baseCfg)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка в конфигурации rate limiter для парсера %s\n", baseCfg.Name)
+	}
+
+	return &MappedParser{
+		BaseParser: baseParser,
+		mapping:    cfg.Mapping,
+	}, nil
+}
+
+// функция проверки, что в конфиге описано всё необходимое для работы декларативного парсера
+func validateMapping(cfg *configs.ParserInstanceConfig) error {
+	switch {
+	case cfg.Name == "":
+		return errors.New("mapped parser: name is required")
+	case cfg.BaseURL == "":
+		return fmt.Errorf("mapped parser %s: base_url is required", cfg.Name)
+	case cfg.Mapping == nil:
+		return fmt.Errorf("mapped parser %s: mapping section is required", cfg.Name)
+	case cfg.Mapping.Fields.ID == "" || cfg.Mapping.Fields.Title == "":
+		return fmt.Errorf("mapped parser %s: fields.id and fields.title are required", cfg.Name)
+	}
+
+	switch cfg.Mapping.Pagination {
+	case "", configs.PaginationPage, configs.PaginationPage0, configs.PaginationOffset:
+		return nil
+	default:
+		return fmt.Errorf("mapped parser %s: unknown pagination style %q", cfg.Name, cfg.Mapping.Pagination)
+	}
+}
+
+// метод парсера для поиска списка вакансий
+func (p *MappedParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
+	page, err := p.SearchVacanciesPage(ctx, params)
+	return page.Vacancies, err
+}
+
+// метод парсера для поиска одной страницы вакансий вместе с общим количеством найденного
+func (p *MappedParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	return p.BaseParser.SearchVacanciesPage(
+		ctx,
+		params,
+		ParserFuncs{
+			BuildURL: p.buildURL,
+			Parse:    p.parseResponse,
+			Convert:  p.convertToUniversal,
+			Totals: func(searchResponse interface{}) (int, int) {
+				return p.totals(searchResponse, p.perPage(params.PerPage))
+			},
+		},
+	)
+}
+
+// метод парсера для поиска деталей по конкретной вакансии
+func (p *MappedParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string) (models.SearchVacancyDetailesResult, error) {
+	return p.BaseParser.SearchVacancyDetailes(
+		ctx,
+		vacancyID,
+		ParserFuncs{
+			BuildDetailsURL: p.buildDetailsURL,
+			Parse:           p.parseResponse,
+			ConvertDetails:  p.convertDetails,
+		},
+	)
+}
+
+// справочник локаций, которым пользуется источник (задаётся в конфиге, пусто - локация передаётся текстом)
+func (p *MappedParser) LocationCatalog() string {
+	return p.mapping.Query.AreaCatalog
+}
+
+// buildURL строит URL для API запроса для поиска списка вакансий, согласно описанию в конфиге
+func (p *MappedParser) buildURL(params models.SearchParams) (string, error) {
+	// преобразуем строку запроса в структуру URL
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	// заводим переменную, где будут хнаниться значения
+	query := u.Query()
+
+	// статические параметры источника
+	for key, value := range p.mapping.ExtraQuery {
+		query.Set(key, value)
+	}
+
+	// добавляем основной параметр поиска
+	if params.Text != "" && p.mapping.Query.Text != "" {
+		query.Set(p.mapping.Query.Text, params.Text)
+	}
+
+	// добавляем параметр - локация (код из справочника area_catalog, если он задан, иначе - текстом)
+	if params.Country != "" && p.mapping.Query.Area != "" {
+		query.Set(p.mapping.Query.Area, p.locationCode(p.mapping.Query.AreaCatalog, params.Country))
+	}
+
+	// добавляем параетры страниц
+	perPage := p.perPage(params.PerPage)
+	if p.mapping.Query.PerPage != "" {
+		query.Set(p.mapping.Query.PerPage, strconv.Itoa(perPage))
+	}
+
+	if p.mapping.Query.Page != "" {
+		query.Set(p.mapping.Query.Page, strconv.Itoa(p.pageValue(params.Page, perPage)))
+	}
+
+	// формируем строку эндпоинта для запроса
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// метод для построения ссылки на детали вакансии
+func (p *MappedParser) buildDetailsURL(vacancyID string) (string, error) {
+	if p.mapping.DetailsURL == "" {
+		return pkg.UrlBuilder(strings.TrimRight(p.baseURL, "/"), url.PathEscape(vacancyID)), nil
+	}
+	return strings.ReplaceAll(p.mapping.DetailsURL, "{id}", url.PathEscape(vacancyID)), nil
+}
+
+// метод для вычисления размера страницы с учётом ограничений источника
+func (p *MappedParser) perPage(requested int) int {
+	perPage := requested
+	if perPage <= 0 {
+		perPage = p.mapping.DefaultPerPage
+	}
+	if perPage <= 0 {
+		perPage = 20 // Значение по умолчанию
+	}
+	if p.mapping.MaxPerPage > 0 && perPage > p.mapping.MaxPerPage {
+		perPage = p.mapping.MaxPerPage
+	}
+	return perPage
+}
+
+// метод для перевода номера страницы нашего API (1-based) в формат источника
+func (p *MappedParser) pageValue(page, perPage int) int {
+	return paginationValue(p.mapping.Pagination, page, perPage)
+}
+
+// функция перевода номера страницы нашего API (1-based) в значение параметра источника по стилю пагинации
+func paginationValue(style string, page, perPage int) int {
+	if page < 1 {
+		page = 1
+	}
+
+	switch style {
+	case configs.PaginationPage0:
+		return page - 1
+	case configs.PaginationOffset:
+		return (page - 1) * perPage
+	default:
+		return page
+	}
+}
+
+// метод парсера обработки тела ответа (общий для поиска и деталей, структура ответа заранее неизвестна)
+func (p *MappedParser) parseResponse(body []byte) (interface{}, error) {
+	tree, err := decodeJSONTree(body)
+	if err != nil {
+		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+	}
+	return tree, nil
+}
+
+// метод приведения результатов поиска у унифицированной структуре, согласно описанию полей в конфиге
+func (p *MappedParser) convertToUniversal(searchResponse interface{}) ([]models.Vacancy, error) {
+	// проверка интерфейса на nil
+	if searchResponse == nil {
+		return []models.Vacancy{}, fmt.Errorf("[%s] searchResponse is nil", p.name)
+	}
+
+	// если по пути нет данных - значит источник ничего не нашёл
+	rawItems, ok := lookupJSONPath(searchResponse, p.mapping.ItemsPath)
+	if !ok {
+		return []models.Vacancy{}, nil
+	}
+
+	items, ok := rawItems.([]interface{})
+	if !ok {
+		return []models.Vacancy{}, fmt.Errorf("[Parser name: %s], items_path %q does not point to an array\n", p.name, p.mapping.ItemsPath)
+	}
+
+	// сразу инициализируем слайс универсальных вакансий, чтобы уменьшить количество переаалокаций
+	universalVacancies := make([]models.Vacancy, 0, len(items))
+
+	fields := p.mapping.Fields
+	for _, item := range items {
+		id := lookupString(item, fields.ID)
+		if id == "" {
+			// без ID вакансию нельзя будет найти через обратный индекс - пропускаем
+			continue
+		}
+
+		universalVacancies = append(universalVacancies, models.Vacancy{
+			ID:          id,
+			Job:         lookupString(item, fields.Title),
+			Company:     lookupString(item, fields.Company),
+			Salary:      p.salary(item),
+			Location:    lookupString(item, fields.City),
+			URL:         p.absoluteURL(lookupString(item, fields.URL)),
+			Source:      p.GetName(),
+			Description: lookupString(item, fields.Description),
+			PublishedAt: p.parsePublishedAt(lookupString(item, fields.PublishedAt)),
+		})
+	}
+
+	return universalVacancies, nil
+}
+
+// метод получения общего количества найденных вакансий и страниц по путям из конфига
+func (p *MappedParser) totals(searchResponse interface{}, perPage int) (int, int) {
+	found := lookupInt(searchResponse, p.mapping.TotalPath)
+
+	pages := lookupInt(searchResponse, p.mapping.PagesPath)
+	if pages == 0 {
+		pages = pagesCount(found, perPage)
+	}
+	return found, pages
+}
+
+// метод приведения результатов поиска по конкретной ваансии к нужному типу, согласно описанию полей в конфиге
+func (p *MappedParser) convertDetails(detailsResponse interface{}) (models.SearchVacancyDetailesResult, error) {
+	// проверка интерфейса на nil
+	if detailsResponse == nil {
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[%s] searchResponse is nil", p.name)
+	}
+
+	item, ok := lookupJSONPath(detailsResponse, p.mapping.DetailsPath)
+	if !ok {
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[Parser name: %s], details_path %q not found in the response body\n", p.name, p.mapping.DetailsPath)
+	}
+
+	// некоторые API возвращают детали вакансии в виде массива из одного элемента
+	if list, isList := item.([]interface{}); isList {
+		if len(list) == 0 {
+			return models.SearchVacancyDetailesResult{}, fmt.Errorf("[Parser name: %s], vacancy not found\n", p.name)
+		}
+		item = list[0]
+	}
+
+	fields := p.mapping.Fields
+	return models.SearchVacancyDetailesResult{
+		Employer:    models.Employer{Name: lookupString(item, fields.Company)},
+		Location:    models.Area{Name: lookupString(item, fields.City)},
+		Salary:      p.salary(item),
+		Description: lookupString(item, fields.Description),
+		Name:        lookupString(item, fields.Title),
+		ID:          lookupString(item, fields.ID),
+		Url:         p.absoluteURL(lookupString(item, fields.URL)),
+	}, nil
+}
+
+// метод для получения зарплаты по описанным в конфиге полям
+func (p *MappedParser) salary(item interface{}) models.Salary {
+	fields := p.mapping.Fields
+
+	salary := models.Salary{
+		From: lookupInt(item, fields.SalaryFrom),
+		To:   lookupInt(item, fields.SalaryTo),
+	}
+	if !salary.Specified() {
+		return models.Salary{}
+	}
+
+	salary.Currency = pkg.NormalizeCurrency(lookupString(item, fields.Currency))
+	salary.Gross, _ = strconv.ParseBool(lookupString(item, fields.SalaryGross))
+	salary.Period = models.NormalizeSalaryPeriod(lookupString(item, fields.SalaryPeriod))
+	return salary
+}
+
+// метод для построения абсолютной ссылки на вакансию
+func (p *MappedParser) absoluteURL(link string) string {
+	if link == "" || p.mapping.URLPrefix == "" {
+		return link
+	}
+	if u, err := url.Parse(link); err == nil && u.IsAbs() {
+		return link
+	}
+	return strings.TrimRight(p.mapping.URLPrefix, "/") + "/" + strings.TrimLeft(link, "/")
+}
+
+// метод для разбора даты публикации вакансии по формату из конфига
+func (p *MappedParser) parsePublishedAt(date string) time.Time {
+	if date == "" {
+		return time.Time{}
+	}
+
+	layouts := []string{time.RFC3339, hhDateLayout, "2006-01-02"}
+	if p.mapping.PublishedAtLayout != "" {
+		layouts = append([]string{p.mapping.PublishedAtLayout}, layouts...)
+	}
+
+	for _, layout := range layouts {
+		if publishedAt, err := time.Parse(layout, date); err == nil {
+			return publishedAt
+		}
+	}
+	return time.Time{}
+}
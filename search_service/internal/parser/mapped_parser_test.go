@@ -0,0 +1,298 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"shared/config"
+	"testing"
+	"time"
+)
+
+// функция создания описания полей в стиле API "Работа России"
+func newTestMapping() *configs.MappingConfig {
+	return &configs.MappingConfig{
+		Query: configs.MappedQueryConfig{
+			Text:    "text",
+			Page:    "offset",
+			PerPage: "limit",
+		},
+		ExtraQuery:        map[string]string{"format": "json"},
+		Pagination:        configs.PaginationPage0,
+		MaxPerPage:        50,
+		ItemsPath:         "results.vacancies",
+		DetailsPath:       "results.vacancies[0]",
+		PublishedAtLayout: "2006-01-02",
+		URLPrefix:         "https://trudvsem.ru",
+		Fields: configs.MappedFieldsConfig{
+			ID:          "vacancy.id",
+			Title:       "vacancy.job-name",
+			Company:     "vacancy.company.name",
+			SalaryFrom:  "vacancy.salary_min",
+			SalaryTo:    "vacancy.salary_max",
+			Currency:    "vacancy.currency",
+			City:        "vacancy.addresses.address[0].location",
+			URL:         "vacancy.vac_url",
+			PublishedAt: "vacancy.creation-date",
+			Description: "vacancy.duty",
+		},
+	}
+}
+
+// функция создания декларативного парсера для тестов
+func newTestMappedParser(t *testing.T, baseURL string, mapping *configs.MappingConfig) *MappedParser {
+	t.Helper()
+
+	cfg := newTestParserConfig(baseURL)
+	cfg.Name = "Trudvsem.ru"
+	cfg.Mapping = mapping
+
+	p, err := NewMappedParser(cfg)
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+	return p.(*MappedParser)
+}
+
+// проверяем разбор JSONPath-подобных путей
+func TestLookupJSONPath(t *testing.T) {
+	tree, err := decodeJSONTree([]byte(`{"a": {"b": [{"c": "x"}, {"c": 42}]}, "n": 1234567890123}`))
+	if err != nil {
+		t.Fatalf("ошибка разбора: %v", err)
+	}
+
+	if got := lookupString(tree, "a.b[0].c"); got != "x" {
+		t.Errorf("a.b[0].c: ожидалось x, получено %q", got)
+	}
+	if got := lookupInt(tree, "a.b.1.c"); got != 42 {
+		t.Errorf("a.b.1.c: ожидалось 42, получено %d", got)
+	}
+	if got := lookupString(tree, "n"); got != "1234567890123" {
+		t.Errorf("большие числа не должны терять точность, получено %q", got)
+	}
+	if _, ok := lookupJSONPath(tree, "a.b[5].c"); ok {
+		t.Error("индекс за границей массива должен возвращать false")
+	}
+	if _, ok := lookupJSONPath(tree, "a.x"); ok {
+		t.Error("несуществующее поле должно возвращать false")
+	}
+}
+
+// проверяем валидацию конфига декларативного парсера
+func TestNewMappedParser_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *configs.ParserInstanceConfig)
+	}{
+		{"без имени", func(cfg *configs.ParserInstanceConfig) { cfg.Name = "" }},
+		{"без mapping", func(cfg *configs.ParserInstanceConfig) { cfg.Mapping = nil }},
+		{"без поля id", func(cfg *configs.ParserInstanceConfig) { cfg.Mapping.Fields.ID = "" }},
+		{"неизвестная пагинация", func(cfg *configs.ParserInstanceConfig) { cfg.Mapping.Pagination = "cursor" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestParserConfig("https://example.com/api")
+			cfg.Name = "Example"
+			cfg.Mapping = newTestMapping()
+			tt.modify(cfg)
+
+			if _, err := NewMappedParser(cfg); err == nil {
+				t.Error("ожидалась ошибка валидации")
+			}
+		})
+	}
+
+	if _, err := NewMappedParser(nil); err == nil {
+		t.Error("ожидалась ошибка для nil конфига")
+	}
+}
+
+// проверяем формирование URL с разными стилями пагинации
+func TestMappedParser_BuildURL(t *testing.T) {
+	tests := []struct {
+		pagination string
+		page       int
+		perPage    int
+		wantPage   string
+		wantLimit  string
+	}{
+		{configs.PaginationPage, 3, 10, "3", "10"},
+		{configs.PaginationPage0, 3, 10, "2", "10"},
+		{configs.PaginationOffset, 3, 10, "20", "10"},
+		{configs.PaginationPage0, 1, 500, "0", "50"}, // ограничение max_per_page
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pagination, func(t *testing.T) {
+			mapping := newTestMapping()
+			mapping.Pagination = tt.pagination
+			p := newTestMappedParser(t, "https://example.com/api/vacancies", mapping)
+
+			rawURL, err := p.buildURL(models.SearchParams{Text: "инженер", Page: tt.page, PerPage: tt.perPage})
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+
+			u, _ := url.Parse(rawURL)
+			query := u.Query()
+			if query.Get("text") != "инженер" {
+				t.Errorf("ожидался text=инженер, получено %q", query.Get("text"))
+			}
+			if query.Get("offset") != tt.wantPage {
+				t.Errorf("ожидался offset=%s, получено %q", tt.wantPage, query.Get("offset"))
+			}
+			if query.Get("limit") != tt.wantLimit {
+				t.Errorf("ожидался limit=%s, получено %q", tt.wantLimit, query.Get("limit"))
+			}
+			if query.Get("format") != "json" {
+				t.Errorf("статические параметры должны добавляться к запросу, получено %q", query.Get("format"))
+			}
+		})
+	}
+}
+
+// проверяем конвертацию записанного ответа по описанию полей
+func TestMappedParser_Convert(t *testing.T) {
+	p := newTestMappedParser(t, "https://example.com/api/vacancies", newTestMapping())
+
+	parsed, err := p.parseResponse(loadFixture(t, "mapped_search.json"))
+	if err != nil {
+		t.Fatalf("ошибка разбора: %v", err)
+	}
+
+	vacancies, err := p.convertToUniversal(parsed)
+	if err != nil {
+		t.Fatalf("ошибка конвертации: %v", err)
+	}
+
+	// вакансия без ID - пропускается
+	if len(vacancies) != 2 {
+		t.Fatalf("ожидалось 2 вакансии, получено %d", len(vacancies))
+	}
+
+	first := vacancies[0]
+	if first.Job != "Инженер-технолог" || first.Company != "АО «Уралхиммаш»" {
+		t.Errorf("неверные данные вакансии: %+v", first)
+	}
+	if first.Location != "Свердловская область, г. Екатеринбург" {
+		t.Errorf("неверный город: %s", first.Location)
+	}
+	wantSalary := models.Salary{From: 70000, To: 90000, Currency: "RUB", Period: models.SalaryPeriodMonth}
+	if first.Salary != wantSalary {
+		t.Errorf("неверная зарплата: %+v", first.Salary)
+	}
+	if first.URL != "https://trudvsem.ru/vacancy/card/5f0c8a1e-3c4d-11ef-8b1e-bf2c7a6e5a01" {
+		t.Errorf("неверная ссылка: %s", first.URL)
+	}
+	if first.PublishedAt.Format("2006-01-02") != "2024-05-14" {
+		t.Errorf("неверная дата публикации: %v", first.PublishedAt)
+	}
+	if first.Source != "Trudvsem.ru" {
+		t.Errorf("неверный источник: %s", first.Source)
+	}
+
+	second := vacancies[1]
+	if second.ID != "1234567890123" {
+		t.Errorf("числовой ID должен сохраняться без потерь, получено %s", second.ID)
+	}
+	if second.Salary.Specified() {
+		t.Errorf("ожидалась пустая зарплата, получено %+v", second.Salary)
+	}
+	if second.URL != "https://trudvsem.ru/vacancy/card/1234567890123" {
+		t.Errorf("абсолютная ссылка не должна меняться: %s", second.URL)
+	}
+}
+
+// проверяем получение деталей через шаблон details_url
+func TestMappedParser_SearchVacanciesDetailes(t *testing.T) {
+	fixture := loadFixture(t, "mapped_search.json")
+
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	mapping := newTestMapping()
+	mapping.DetailsURL = server.URL + "/api/vacancy/{id}"
+	p := newTestMappedParser(t, server.URL+"/api/vacancies", mapping)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	details, err := p.SearchVacanciesDetailes(ctx, "5f0c8a1e")
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	if requestedPath != "/api/vacancy/5f0c8a1e" {
+		t.Errorf("неверный путь запроса деталей: %s", requestedPath)
+	}
+	if details.Name != "Инженер-технолог" || details.Salary.From != 70000 || details.Salary.To != 90000 {
+		t.Errorf("неверные детали вакансии: %+v", details)
+	}
+	if details.Description != "Разработка технологических процессов" {
+		t.Errorf("неверное описание: %s", details.Description)
+	}
+}
+
+// проверяем, что описание декларативного парсера загружается из yml
+func TestMappedParser_LoadFromYAML(t *testing.T) {
+	yml := `
+mapped:
+  - name: 'Example'
+    enabled: true
+    base_url: 'https://example.com/api/jobs'
+    rate_limit: 1s
+    max_concurrent: 2
+    mapping:
+      query:
+        text: 'q'
+        page: 'p'
+      pagination: 'offset'
+      items_path: 'data.items'
+      fields:
+        id: 'uid'
+        title: 'name'
+        city: 'location.city'
+`
+	path := filepath.Join(t.TempDir(), "parsers.yml")
+	if err := os.WriteFile(path, []byte(yml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadYAMLConfig(path, func() *configs.ParsersConfig { return &configs.ParsersConfig{} })
+	if err != nil {
+		t.Fatalf("ошибка загрузки конфига: %v", err)
+	}
+
+	if len(cfg.Mapped) != 1 {
+		t.Fatalf("ожидался 1 mapped парсер, получено %d", len(cfg.Mapped))
+	}
+
+	mapped := cfg.Mapped[0]
+	if mapped.Mapping == nil || mapped.Mapping.ItemsPath != "data.items" || mapped.Mapping.Fields.City != "location.city" {
+		t.Errorf("mapping загружен неверно: %+v", mapped.Mapping)
+	}
+
+	factory := NewParserFactory()
+	enabled := factory.RegisterMapped(cfg.Mapped)
+	if len(enabled) != 1 || enabled[0] != "mapped:Example" {
+		t.Fatalf("неверный список включённых парсеров: %v", enabled)
+	}
+
+	parser, err := factory.Create(enabled[0])
+	if err != nil {
+		t.Fatalf("фабрика не смогла создать парсер: %v", err)
+	}
+	if parser.GetName() != "Example" {
+		t.Errorf("неверное имя парсера: %s", parser.GetName())
+	}
+}
@@ -0,0 +1,81 @@
+package model
+
+import "encoding/xml"
+
+// RSSFeed представляет RSS 2.0 ленту
+type RSSFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+// RSSChannel - канал RSS ленты
+type RSSChannel struct {
+	Title string    `xml:"title"`
+	Link  string    `xml:"link"`
+	Items []RSSItem `xml:"item"`
+}
+
+// RSSItem - отдельная запись RSS ленты
+type RSSItem struct {
+	GUID        string   `xml:"guid"`
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+}
+
+// AtomFeed представляет Atom ленту
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+// AtomEntry - отдельная запись Atom ленты
+type AtomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []AtomLink `xml:"link"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Author    AtomAuthor `xml:"author"`
+}
+
+// AtomLink - ссылка записи Atom ленты
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// AtomAuthor - автор записи Atom ленты
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// FeedEntry - запись ленты, приведённая к общему виду (независимо от формата RSS/Atom)
+type FeedEntry struct {
+	ID          string
+	Title       string
+	Link        string
+	Description string
+	Author      string
+	PublishedAt string
+}
+
+// GetLink возвращает основную ссылку записи Atom ленты (rel="alternate" или без rel)
+func (e AtomEntry) GetLink() string {
+	for _, link := range e.Links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+	if len(e.Links) > 0 {
+		return e.Links[0].Href
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package model
+
+import "search_service/pkg"
+
+// HabrSearchResponse представляет ответ от API Habr Career при поиске списка вакансий
+type HabrSearchResponse struct {
+	Items []HabrVacancy `json:"list"`
+	Meta  HabrMeta      `json:"meta"`
+}
+
+// HabrMeta - служебная информация о результатах поиска (пагинация)
+type HabrMeta struct {
+	TotalResults int `json:"totalResults"`
+	PerPage      int `json:"perPage"`
+	CurrentPage  int `json:"currentPage"`
+	TotalPages   int `json:"totalPages"`
+}
+
+// HabrVacancy представляет структуру вакансии с Habr Career
+type HabrVacancy struct {
+	ID            int               `json:"id"`
+	Href          string            `json:"href"` // относительная ссылка на вакансию ("/vacancies/1000123456")
+	Title         string            `json:"title"`
+	RemoteWork    bool              `json:"remoteWork"`
+	Employment    string            `json:"employment"`
+	PublishedDate HabrPublishedDate `json:"publishedDate"`
+	Company       HabrCompany       `json:"company"`
+	Salary        HabrSalary        `json:"salary"`
+	Qualification HabrQualification `json:"qualification"`
+	Locations     []HabrLocation    `json:"locations"`
+}
+
+// HabrPublishedDate - дата публикации вакансии
+type HabrPublishedDate struct {
+	Date  string `json:"date"`  // дата в формате RFC3339
+	Title string `json:"title"` // человекочитаемое представление ("1 мая")
+}
+
+// HabrCompany - информация о работодателе
+type HabrCompany struct {
+	Title string `json:"title"`
+	Href  string `json:"href"`
+}
+
+// HabrSalary - информация о зарплате
+type HabrSalary struct {
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Currency  string `json:"currency"` // "rur", "usd", "eur"
+	Formatted string `json:"formatted"`
+}
+
+// HabrQualification - уровень квалификации (Junior, Middle, Senior...)
+type HabrQualification struct {
+	Title string `json:"title"`
+}
+
+// HabrLocation - город размещения вакансии
+type HabrLocation struct {
+	Title string `json:"title"`
+	Href  string `json:"href"`
+}
+
+// HabrVacancyDetails представляет ответ API Habr Career по запросу с ID
+type HabrVacancyDetails struct {
+	ID          int            `json:"id"`
+	Href        string         `json:"href"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Company     HabrCompany    `json:"company"`
+	Salary      HabrSalary     `json:"salary"`
+	Locations   []HabrLocation `json:"locations"`
+}
+
+// GetLocation возвращает первый город из списка локаций вакансии
+func (v HabrVacancy) GetLocation() string {
+	if len(v.Locations) == 0 {
+		return ""
+	}
+	return v.Locations[0].Title
+}
+
+// NormalizedCurrency приводит код валюты Habr Career к виду, который используют остальные источники ("rur" -> "RUB")
+func (s HabrSalary) NormalizedCurrency() string {
+	return pkg.NormalizeCurrency(s.Currency)
+}
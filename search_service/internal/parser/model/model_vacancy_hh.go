@@ -1,16 +1,26 @@
 package model
 
-import "search_service/pkg"
-
 // HHVacancy представляет структуру вакансии с HH.ru
 type HHVacancy struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	Salary      Salary   `json:"salary"`
-	Employer    Employer `json:"employer"`
-	Area        Area     `json:"area"`
-	URL         string   `json:"url"`
-	Description string   `json:"description"`
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	Salary       Salary      `json:"salary"`
+	SalaryRange  SalaryRange `json:"salary_range"` // расширенная информация о зарплате (период выплаты)
+	Employer     Employer    `json:"employer"`
+	Area         Area        `json:"area"`
+	URL          string      `json:"url"`
+	AlternateURL string      `json:"alternate_url"` // ссылка на вакансию на сайте (для HH-совместимых источников)
+	PublishedAt  string      `json:"published_at"`
+	Description  string      `json:"description"`
+	Experience   DictItem    `json:"experience"`
+	Schedule     DictItem    `json:"schedule"`
+	Employment   DictItem    `json:"employment"`
+}
+
+// DictItem представляет элемент справочника HH.ru (опыт, график, занятость)
+type DictItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 // Salary представляет информацию о зарплате
@@ -21,6 +31,11 @@ type Salary struct {
 	Gross    bool   `json:"gross"`
 }
 
+// SalaryRange представляет расширенную информацию о зарплате (mode: MONTH, HOUR, SHIFT, FLY_IN_FLY_OUT)
+type SalaryRange struct {
+	Mode DictItem `json:"mode"`
+}
+
 // Employer представляет информацию о работодателе
 type Employer struct {
 	ID   string `json:"id"`
@@ -50,18 +65,3 @@ type SearchDetails struct {
 	ID          string   `json:"id"`
 	Url         string   `json:"alternate_url"`
 }
-
-// GetSalaryString возвращает форматированную строку зарплаты
-func (v HHVacancy) GetSalaryString() string {
-	if v.Salary.From == 0 && v.Salary.To == 0 {
-		return "не указана"
-	}
-
-	if v.Salary.From > 0 && v.Salary.To > 0 {
-		return pkg.FormatSalary(v.Salary.From, v.Salary.To, v.Salary.Currency)
-	} else if v.Salary.From > 0 {
-		return pkg.FormatSalary(v.Salary.From, 0, v.Salary.Currency)
-	} else {
-		return pkg.FormatSalary(0, v.Salary.To, v.Salary.Currency)
-	}
-}
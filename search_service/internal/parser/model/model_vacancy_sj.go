@@ -1,7 +1,5 @@
 package model
 
-import "search_service/pkg"
-
 // Структуры для SuperJob API
 type SuperJobResponse struct {
 	Items []SJVacancy `json:"objects"`
@@ -9,31 +7,27 @@ type SuperJobResponse struct {
 }
 
 type SJVacancy struct {
-	ID              int    `json:"id"`
-	Profession      string `json:"profession"`
-	FirmName        string `json:"firm_name"`
-	PaymentFrom     int    `json:"payment_from"`
-	PaymentTo       int    `json:"payment_to"`
-	Currency        string `json:"currency"`
-	Town            Town   `json:"town"`
-	Link            string `json:"link"`
-	VacancyRichText string `json:"vacancyRichText"`
+	ID              int        `json:"id"`
+	Profession      string     `json:"profession"`
+	FirmName        string     `json:"firm_name"`
+	PaymentFrom     int        `json:"payment_from"`
+	PaymentTo       int        `json:"payment_to"`
+	Currency        string     `json:"currency"`
+	Town            Town       `json:"town"`
+	Link            string     `json:"link"`
+	VacancyRichText string     `json:"vacancyRichText"`
+	DatePublished   int64      `json:"date_published"` // unix timestamp
+	Experience      SJDictItem `json:"experience"`
+	TypeOfWork      SJDictItem `json:"type_of_work"`
+	PlaceOfWork     SJDictItem `json:"place_of_work"`
 }
 
-type Town struct {
+// SJDictItem - элемент справочника SuperJob (опыт, тип занятости, место работы)
+type SJDictItem struct {
+	ID    int    `json:"id"`
 	Title string `json:"title"`
 }
 
-func (v SJVacancy) GetSalaryString() string {
-	if v.PaymentFrom == 0 && v.PaymentTo == 0 {
-		return "не указана"
-	}
-
-	if v.PaymentFrom > 0 && v.PaymentTo > 0 {
-		return pkg.FormatSalary(v.PaymentFrom, v.PaymentTo, v.Currency)
-	} else if v.PaymentFrom > 0 {
-		return pkg.FormatSalary(v.PaymentFrom, 0, v.Currency)
-	} else {
-		return pkg.FormatSalary(0, v.PaymentTo, v.Currency)
-	}
+type Town struct {
+	Title string `json:"title"`
 }
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"net/http"
+
+	"search_service/configs"
+)
+
+// RequestDecorator дополняет исходящие запросы парсера заголовками и параметрами из конфига источника
+// один и тот же декоратор применяется к поиску, деталям вакансии и health check
+type RequestDecorator struct {
+	headers map[string]string // заголовки, которые выставляются каждому запросу
+	query   map[string]string // параметры, которые добавляются к каждому запросу
+}
+
+// конструктор декоратора запросов: собирает заголовки и параметры из конфига и API ключа источника
+// nil - если источнику нечего добавлять к запросам
+func NewRequestDecorator(apiKey string, cfg *configs.RequestConfig) *RequestDecorator {
+	if cfg == nil {
+		return nil
+	}
+
+	headers := make(map[string]string, len(cfg.Headers)+3)
+	for name, value := range cfg.Headers {
+		headers[name] = value
+	}
+	if cfg.UserAgent != "" {
+		headers["User-Agent"] = cfg.UserAgent
+	}
+	if cfg.BearerToken != "" {
+		headers["Authorization"] = "Bearer " + cfg.BearerToken
+	}
+
+	query := make(map[string]string, len(cfg.Query)+1)
+	for name, value := range cfg.Query {
+		query[name] = value
+	}
+
+	// ключ без имени заголовка или параметра никуда не передаётся - источник сам решает, как его принимать
+	if apiKey != "" {
+		if cfg.APIKeyHeader != "" {
+			headers[cfg.APIKeyHeader] = apiKey
+		}
+		if cfg.APIKeyQuery != "" {
+			query[cfg.APIKeyQuery] = apiKey
+		}
+	}
+
+	if len(headers) == 0 && len(query) == 0 {
+		return nil
+	}
+
+	return &RequestDecorator{
+		headers: headers,
+		query:   query,
+	}
+}
+
+// метод оформления запроса: выставляет заголовки и добавляет параметры (значения из конфига заменяют одноимённые)
+func (d *RequestDecorator) Decorate(req *http.Request) {
+	if d == nil || req == nil {
+		return
+	}
+
+	for name, value := range d.headers {
+		req.Header.Set(name, value)
+	}
+
+	if len(d.query) > 0 {
+		values := req.URL.Query()
+		for name, value := range d.query {
+			values.Set(name, value)
+		}
+		req.URL.RawQuery = values.Encode()
+	}
+}
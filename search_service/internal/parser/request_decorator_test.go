@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"testing"
+	"time"
+)
+
+// проверяем, что заголовки и ключи источника уходят и в поиске, и в деталях вакансии
+func TestRequestDecorator_AppliedToParserRequests(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/2.0/vacancies/42" {
+			w.Write([]byte(`{"id": "42", "profession": "Go разработчик"}`))
+			return
+		}
+		w.Write([]byte(`{"objects": [], "total": 0, "more": false}`))
+	}))
+	defer server.Close()
+
+	cfg := newTestParserConfig(server.URL + "/2.0/vacancies")
+	cfg.APIKey = "v3.test-key"
+	cfg.Request = &configs.RequestConfig{
+		UserAgent:    "JobSearchService/1.0",
+		Headers:      map[string]string{"X-Client": "search"},
+		APIKeyHeader: "X-Api-App-Id",
+		Query:        map[string]string{"lang": "ru"},
+	}
+
+	p, err := NewSJParser(cfg)
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := p.SearchVacancies(ctx, models.SearchParams{Text: "golang", PerPage: 20, Page: 1}); err != nil {
+		t.Fatalf("ошибка поиска: %v", err)
+	}
+	if _, err := p.SearchVacanciesDetailes(ctx, "42"); err != nil {
+		t.Fatalf("ошибка получения деталей: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("ожидалось 2 запроса, получено %d", len(requests))
+	}
+	for _, r := range requests {
+		if r.Header.Get("X-Api-App-Id") != "v3.test-key" {
+			t.Errorf("%s: ключ не передан в X-Api-App-Id", r.URL.Path)
+		}
+		if r.Header.Get("User-Agent") != "JobSearchService/1.0" || r.Header.Get("X-Client") != "search" {
+			t.Errorf("%s: неверные заголовки: %v", r.URL.Path, r.Header)
+		}
+		if r.URL.Query().Get("lang") != "ru" {
+			t.Errorf("%s: статический параметр не добавлен: %s", r.URL.Path, r.URL.RawQuery)
+		}
+	}
+	if requests[0].URL.Query().Get("keyword") != "golang" {
+		t.Errorf("параметры поиска потеряны при добавлении статических: %s", requests[0].URL.RawQuery)
+	}
+}
+
+// проверяем варианты передачи ключа и токена
+func TestNewRequestDecorator(t *testing.T) {
+	if d := NewRequestDecorator("key", nil); d != nil {
+		t.Errorf("без конфига декоратор не нужен")
+	}
+	if d := NewRequestDecorator("key", &configs.RequestConfig{}); d != nil {
+		t.Errorf("ключ без имени заголовка или параметра не должен никуда передаваться")
+	}
+
+	d := NewRequestDecorator("secret", &configs.RequestConfig{
+		APIKeyQuery: "api_key",
+		BearerToken: "token",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/api/health?limit=1", nil)
+	d.Decorate(req)
+
+	if req.URL.Query().Get("api_key") != "secret" || req.URL.Query().Get("limit") != "1" {
+		t.Errorf("неверные параметры запроса: %s", req.URL.RawQuery)
+	}
+	if req.Header.Get("Authorization") != "Bearer token" {
+		t.Errorf("неверный заголовок авторизации: %q", req.Header.Get("Authorization"))
+	}
+
+	// nil декоратор ничего не меняет и не паникует
+	var empty *RequestDecorator
+	empty.Decorate(req)
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"search_service/internal/domain/models"
+)
+
+// число в тексте зарплаты: с разделителями тысяч ("150 000", "150 000") или без них
+var salaryNumberRe = regexp.MustCompile(`\d{1,3}(?:[ \x{00a0}\x{202f}]\d{3})+|\d+`)
+
+// признаки валют в тексте зарплаты (проверяются по порядку, более длинные признаки - раньше)
+var salaryCurrencyMarkers = []struct {
+	marker   string
+	currency string
+}{
+	{"byn", "BYN"}, {"бел. руб", "BYN"},
+	{"₽", "RUB"}, {"руб", "RUB"}, {"rub", "RUB"}, {"rur", "RUB"},
+	{"$", "USD"}, {"usd", "USD"}, {"долл", "USD"},
+	{"€", "EUR"}, {"eur", "EUR"}, {"евро", "EUR"},
+	{"₸", "KZT"}, {"kzt", "KZT"}, {"тенге", "KZT"},
+}
+
+// признаки периода зарплаты в тексте (если ни один не найден - зарплата за месяц)
+var salaryPeriodMarkers = []struct {
+	marker string
+	period string
+}{
+	{"в час", models.SalaryPeriodHour}, {"/час", models.SalaryPeriodHour}, {"per hour", models.SalaryPeriodHour}, {"/hour", models.SalaryPeriodHour},
+	{"в день", models.SalaryPeriodDay}, {"за смену", models.SalaryPeriodDay}, {"per day", models.SalaryPeriodDay}, {"/day", models.SalaryPeriodDay},
+	{"в год", models.SalaryPeriodYear}, {"per year", models.SalaryPeriodYear}, {"/year", models.SalaryPeriodYear},
+}
+
+// функция разбора зарплаты из текста страницы ("от 150 000 до 200 000 ₽", "до 90 000 руб. на руки", "$3000 per month")
+// если в тексте нет чисел ("по договорённости") - зарплата считается не указанной
+func parseSalaryText(text string) models.Salary {
+	lowered := strings.ToLower(strings.TrimSpace(text))
+
+	matches := salaryNumberRe.FindAllStringIndex(lowered, 2)
+	if len(matches) == 0 {
+		return models.Salary{}
+	}
+
+	numbers := make([]int, 0, len(matches))
+	for _, match := range matches {
+		numbers = append(numbers, salaryNumber(lowered, match[0], match[1]))
+	}
+
+	var salary models.Salary
+	switch {
+	case len(numbers) == 2:
+		salary.From, salary.To = numbers[0], numbers[1]
+	case strings.HasSuffix(strings.TrimSpace(lowered[:matches[0][0]]), "до"):
+		salary.To = numbers[0]
+	default:
+		salary.From = numbers[0]
+	}
+	if !salary.Specified() {
+		return models.Salary{}
+	}
+
+	for _, m := range salaryCurrencyMarkers {
+		if strings.Contains(lowered, m.marker) {
+			salary.Currency = m.currency
+			break
+		}
+	}
+
+	salary.Period = models.SalaryPeriodMonth
+	for _, m := range salaryPeriodMarkers {
+		if strings.Contains(lowered, m.marker) {
+			salary.Period = m.period
+			break
+		}
+	}
+
+	salary.Gross = strings.Contains(lowered, "до вычета") || strings.Contains(lowered, "gross")
+	return salary
+}
+
+// функция получения числа из текста зарплаты с учётом сокращений тысяч ("150к", "150 тыс.")
+func salaryNumber(text string, start, end int) int {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, text[start:end])
+
+	number, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+
+	rest := strings.TrimSpace(text[end:])
+	if strings.HasPrefix(rest, "тыс") || isThousandsSuffix(rest, "k") || isThousandsSuffix(rest, "к") {
+		number *= 1000
+	}
+	return number
+}
+
+// функция проверки сокращения "k" после числа (но не начала слова, например "kzt")
+func isThousandsSuffix(rest, suffix string) bool {
+	if !strings.HasPrefix(rest, suffix) {
+		return false
+	}
+	next := []rune(rest[len(suffix):])
+	return len(next) == 0 || !unicode.IsLetter(next[0])
+}
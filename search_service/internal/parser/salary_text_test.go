@@ -0,0 +1,28 @@
+package parser
+
+import (
+	"search_service/internal/domain/models"
+	"testing"
+)
+
+func TestParseSalaryText(t *testing.T) {
+	tests := []struct {
+		text string
+		want models.Salary
+	}{
+		{"от 200 000 до 280 000 ₽", models.Salary{From: 200000, To: 280000, Currency: "RUB", Period: models.SalaryPeriodMonth}},
+		{"до 90 000 руб. на руки", models.Salary{To: 90000, Currency: "RUB", Period: models.SalaryPeriodMonth}},
+		{"от 150 000 ₽ до вычета налогов", models.Salary{From: 150000, Currency: "RUB", Period: models.SalaryPeriodMonth, Gross: true}},
+		{"$3000 - $4500", models.Salary{From: 3000, To: 4500, Currency: "USD", Period: models.SalaryPeriodMonth}},
+		{"150k – 200k KZT", models.Salary{From: 150000, To: 200000, Currency: "KZT", Period: models.SalaryPeriodMonth}},
+		{"500 ₽ в час", models.Salary{From: 500, Currency: "RUB", Period: models.SalaryPeriodHour}},
+		{"по договорённости", models.Salary{}},
+		{"", models.Salary{}},
+	}
+
+	for _, tt := range tests {
+		if got := parseSalaryText(tt.text); got != tt.want {
+			t.Errorf("parseSalaryText(%q) = %+v, ожидалось %+v", tt.text, got, tt.want)
+		}
+	}
+}
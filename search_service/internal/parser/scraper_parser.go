@@ -0,0 +1,398 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/pkg"
+
+	"search_service/internal/search_interfaces"
+
+	"golang.org/x/net/html"
+)
+
+// скомпилированные селекторы полей вакансии
+type scrapedFields struct {
+	id          *fieldSelector
+	title       *fieldSelector
+	company     *fieldSelector
+	salary      *fieldSelector
+	city        *fieldSelector
+	url         *fieldSelector
+	description *fieldSelector
+}
+
+// создаём стркутуру парсера HTML страниц на базе общего парсера
+// страницы скачиваются через BaseParser (rate limiter, семафор, circuit breaker), поля вынимаются селекторами из конфига
+type ScraperParser struct {
+	*BaseParser
+	scraping *configs.ScrapingConfig
+	item     cssSelector    // селектор карточки вакансии на странице поиска
+	total    *fieldSelector // селектор общего количества найденного (nil - сайт его не показывает)
+	fields   scrapedFields  // селекторы полей внутри карточки
+	details  scrapedFields  // селекторы полей на странице вакансии
+}
+
+// конструктор для парсера HTML страниц
+func NewScraperParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, error) {
+	// для парсера HTML страниц нет конфига по умолчанию - селекторы должны быть описаны в yml
+	if cfg == nil {
+		return nil, errors.New("scraper parser requires config")
+	}
+	if err := validateScraping(cfg); err != nil {
+		return nil, err
+	}
+
+	// селекторы компилируем сразу, чтобы ошибки в конфиге всплывали при старте сервиса
+	item, err := parseSelector(cfg.Scraping.ItemSelector)
+	if err != nil {
+		return nil, fmt.Errorf("scraper parser %s: item_selector: %w", cfg.Name, err)
+	}
+	total, err := parseFieldSelector(cfg.Scraping.TotalSelector)
+	if err != nil {
+		return nil, fmt.Errorf("scraper parser %s: total_selector: %w", cfg.Name, err)
+	}
+	fields, err := compileScrapedFields(cfg.Scraping.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("scraper parser %s: fields: %w", cfg.Name, err)
+	}
+	details, err := compileScrapedFields(cfg.Scraping.Details)
+	if err != nil {
+		return nil, fmt.Errorf("scraper parser %s: details: %w", cfg.Name, err)
+	}
+
+	baseCfg := BaseConfig{
+		Name:                  cfg.Name,
+		BaseURL:               cfg.BaseURL,
+		HealthEndPoint:        cfg.HealthEndPoint,
+		APIKey:                cfg.APIKey,
+		Timeout:               cfg.Timeout,
+		RateLimit:             cfg.RateLimit,
+		MaxConcurrent:         cfg.MaxConcurrent,
+		CircuitBreakerCfg:     cfg.CircuitBreaker,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		Request:               cfg.Request,
+	}
+
+	baseParser, err := NewBaseParser(baseCfg)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка в конфигурации rate limiter для парсера %s\n", baseCfg.Name)
+	}
+
+	return &ScraperParser{
+		BaseParser: baseParser,
+		scraping:   cfg.Scraping,
+		item:       item,
+		total:      total,
+		fields:     fields,
+		details:    details,
+	}, nil
+}
+
+// функция проверки, что в конфиге описано всё необходимое для работы парсера HTML страниц
+func validateScraping(cfg *configs.ParserInstanceConfig) error {
+	switch {
+	case cfg.Name == "":
+		return errors.New("scraper parser: name is required")
+	case cfg.BaseURL == "":
+		return fmt.Errorf("scraper parser %s: base_url is required", cfg.Name)
+	case cfg.Scraping == nil:
+		return fmt.Errorf("scraper parser %s: scraping section is required", cfg.Name)
+	case cfg.Scraping.ItemSelector == "" || cfg.Scraping.Fields.Title == "":
+		return fmt.Errorf("scraper parser %s: item_selector and fields.title are required", cfg.Name)
+	case cfg.Scraping.Fields.ID == "" && cfg.Scraping.Fields.URL == "":
+		// без ID и ссылки вакансию нельзя будет найти через обратный индекс
+		return fmt.Errorf("scraper parser %s: fields.id or fields.url is required", cfg.Name)
+	}
+
+	switch cfg.Scraping.Pagination {
+	case "", configs.PaginationPage, configs.PaginationPage0, configs.PaginationOffset:
+		return nil
+	default:
+		return fmt.Errorf("scraper parser %s: unknown pagination style %q", cfg.Name, cfg.Scraping.Pagination)
+	}
+}
+
+// функция компиляции селекторов полей из конфига
+func compileScrapedFields(cfg configs.ScrapingFieldsConfig) (scrapedFields, error) {
+	var (
+		fields scrapedFields
+		err    error
+	)
+
+	targets := []struct {
+		name string
+		raw  string
+		dst  **fieldSelector
+	}{
+		{"id", cfg.ID, &fields.id},
+		{"title", cfg.Title, &fields.title},
+		{"company", cfg.Company, &fields.company},
+		{"salary", cfg.Salary, &fields.salary},
+		{"city", cfg.City, &fields.city},
+		{"url", cfg.URL, &fields.url},
+		{"description", cfg.Description, &fields.description},
+	}
+
+	for _, target := range targets {
+		if *target.dst, err = parseFieldSelector(target.raw); err != nil {
+			return scrapedFields{}, fmt.Errorf("%s: %w", target.name, err)
+		}
+	}
+	return fields, nil
+}
+
+// метод парсера для поиска списка вакансий
+func (p *ScraperParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
+	page, err := p.SearchVacanciesPage(ctx, params)
+	return page.Vacancies, err
+}
+
+// метод парсера для поиска одной страницы вакансий вместе с общим количеством найденного (если сайт его показывает)
+func (p *ScraperParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	return p.BaseParser.SearchVacanciesPage(
+		ctx,
+		params,
+		ParserFuncs{
+			BuildURL: p.buildURL,
+			Parse:    p.parseHTML,
+			Convert:  p.convertToUniversal,
+			Totals: func(data interface{}) (int, int) {
+				return p.totals(data, params.PerPage)
+			},
+		},
+	)
+}
+
+// метод парсера для поиска деталей по конкретной вакансии
+func (p *ScraperParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string) (models.SearchVacancyDetailesResult, error) {
+	return p.BaseParser.SearchVacancyDetailes(
+		ctx,
+		vacancyID,
+		ParserFuncs{
+			BuildDetailsURL: p.buildDetailsURL,
+			Parse:           p.parseHTML,
+			ConvertDetails: func(data interface{}) (models.SearchVacancyDetailesResult, error) {
+				return p.convertDetails(data, vacancyID)
+			},
+		},
+	)
+}
+
+// справочник локаций, которым пользуется источник (задаётся в конфиге, пусто - локация передаётся текстом)
+func (p *ScraperParser) LocationCatalog() string {
+	return p.scraping.Query.AreaCatalog
+}
+
+// buildURL строит URL страницы поиска, согласно описанию в конфиге
+func (p *ScraperParser) buildURL(params models.SearchParams) (string, error) {
+	// преобразуем строку запроса в структуру URL
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	// заводим переменную, где будут хнаниться значения
+	query := u.Query()
+
+	// статические параметры источника
+	for key, value := range p.scraping.ExtraQuery {
+		query.Set(key, value)
+	}
+
+	// добавляем основной параметр поиска
+	if params.Text != "" && p.scraping.Query.Text != "" {
+		query.Set(p.scraping.Query.Text, params.Text)
+	}
+
+	// добавляем параметр - локация (код из справочника area_catalog, если он задан, иначе - текстом)
+	if params.Country != "" && p.scraping.Query.Area != "" {
+		query.Set(p.scraping.Query.Area, p.locationCode(p.scraping.Query.AreaCatalog, params.Country))
+	}
+
+	// добавляем параетры страниц
+	perPage := params.PerPage
+	if perPage <= 0 {
+		perPage = p.scraping.DefaultPerPage
+	}
+	if p.scraping.Query.PerPage != "" && perPage > 0 {
+		query.Set(p.scraping.Query.PerPage, strconv.Itoa(perPage))
+	}
+
+	if p.scraping.Query.Page != "" {
+		query.Set(p.scraping.Query.Page, strconv.Itoa(paginationValue(p.scraping.Pagination, params.Page, perPage)))
+	}
+
+	// формируем строку эндпоинта для запроса
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// метод для построения ссылки на страницу вакансии
+func (p *ScraperParser) buildDetailsURL(vacancyID string) (string, error) {
+	if p.scraping.DetailsURL == "" {
+		return pkg.UrlBuilder(strings.TrimRight(p.baseURL, "/"), url.PathEscape(vacancyID)), nil
+	}
+	return strings.ReplaceAll(p.scraping.DetailsURL, "{id}", url.PathEscape(vacancyID)), nil
+}
+
+// метод парсера обработки тела ответа (общий для страницы поиска и страницы вакансии)
+func (p *ScraperParser) parseHTML(body []byte) (interface{}, error) {
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+	}
+	return doc, nil
+}
+
+// метод приведения карточек вакансий со страницы поиска к унифицированной структуре
+func (p *ScraperParser) convertToUniversal(data interface{}) ([]models.Vacancy, error) {
+	// Проводим type assertion
+	doc, ok := data.(*html.Node)
+	if !ok {
+
+		// Для более детальной информации можно использовать reflect
+		fmt.Printf("----------------->>>[Parser name: %s] DEBUG: Type details: %v\n", p.name, reflect.TypeOf(data))
+		return nil, fmt.Errorf("[Parser name: %s], wrong data type in the response body\n", p.name)
+	}
+
+	items := p.item.selectAll(doc)
+
+	// сразу инициализируем слайс универсальных вакансий, чтобы уменьшить количество переаалокаций
+	universalVacancies := make([]models.Vacancy, 0, len(items))
+
+	for _, item := range items {
+		link := p.absoluteURL(p.fields.url.extract(item))
+
+		id := p.fields.id.extract(item)
+		if id == "" {
+			id = idFromLink(link)
+		}
+		if id == "" {
+			// без ID вакансию нельзя будет найти через обратный индекс - пропускаем
+			continue
+		}
+
+		universalVacancies = append(universalVacancies, models.Vacancy{
+			ID:          id,
+			Job:         p.fields.title.extract(item),
+			Company:     p.fields.company.extract(item),
+			Salary:      parseSalaryText(p.fields.salary.extract(item)),
+			Location:    p.fields.city.extract(item),
+			URL:         link,
+			Source:      p.GetName(),
+			Description: p.fields.description.extract(item),
+		})
+	}
+
+	return universalVacancies, nil
+}
+
+// метод получения общего количества найденного со страницы поиска
+func (p *ScraperParser) totals(data interface{}, perPage int) (int, int) {
+	doc, ok := data.(*html.Node)
+	if !ok || p.total == nil {
+		return 0, 0
+	}
+
+	// если сайт не принимает размер страницы - страница всегда размера default_per_page
+	if perPage <= 0 || p.scraping.Query.PerPage == "" {
+		perPage = p.scraping.DefaultPerPage
+	}
+
+	found := digitsToInt(p.total.extract(doc))
+	return found, pagesCount(found, perPage)
+}
+
+// метод приведения страницы вакансии к нужному типу
+func (p *ScraperParser) convertDetails(data interface{}, vacancyID string) (models.SearchVacancyDetailesResult, error) {
+	// Проводим type assertion
+	doc, ok := data.(*html.Node)
+	if !ok {
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[Parser name: %s], wrong data type in the response body\n", p.name)
+	}
+
+	name := p.details.title.extract(doc)
+	if name == "" {
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[Parser name: %s], vacancy title not found on the page\n", p.name)
+	}
+
+	detailsURL, _ := p.buildDetailsURL(vacancyID)
+	if link := p.absoluteURL(p.details.url.extract(doc)); link != "" {
+		detailsURL = link
+	}
+
+	return models.SearchVacancyDetailesResult{
+		Employer:    models.Employer{Name: p.details.company.extract(doc)},
+		Location:    models.Area{Name: p.details.city.extract(doc)},
+		Description: p.details.description.extract(doc),
+		Name:        name,
+		ID:          vacancyID,
+		Url:         detailsURL,
+	}, nil
+}
+
+// метод для построения абсолютной ссылки на вакансию (относительные ссылки дополняются url_prefix или хостом base_url)
+func (p *ScraperParser) absoluteURL(link string) string {
+	if link == "" {
+		return ""
+	}
+
+	u, err := url.Parse(link)
+	if err != nil || u.IsAbs() {
+		return link
+	}
+
+	if p.scraping.URLPrefix != "" {
+		return strings.TrimRight(p.scraping.URLPrefix, "/") + "/" + strings.TrimLeft(link, "/")
+	}
+
+	base, err := url.Parse(p.baseURL)
+	if err != nil {
+		return link
+	}
+	return base.ResolveReference(u).String()
+}
+
+// функция получения ID вакансии из последнего сегмента ссылки ("https://site/vacancy/123?from=list" -> "123")
+func idFromLink(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Path == "" {
+		return ""
+	}
+
+	id := path.Base(strings.TrimRight(u.Path, "/"))
+	if id == "/" || id == "." {
+		return ""
+	}
+	return id
+}
+
+// функция получения числа из текста, в котором цифры разбиты пробелами и словами ("Найдено 1 234 вакансии" -> 1234)
+func digitsToInt(text string) int {
+	var digits strings.Builder
+	for _, r := range text {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	value, err := strconv.Atoi(digits.String())
+	if err != nil {
+		return 0
+	}
+	return value
+}
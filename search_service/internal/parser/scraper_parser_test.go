@@ -0,0 +1,224 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+// функция создания конфига парсера HTML страниц, который смотрит на тестовый сервер
+func newTestScraperConfig(baseURL string) *configs.ParserInstanceConfig {
+	cfg := newTestParserConfig(baseURL + "/search")
+	cfg.Name = "Example Jobs"
+	cfg.Scraping = &configs.ScrapingConfig{
+		Query:        configs.MappedQueryConfig{Text: "q", Page: "page"},
+		Pagination:   configs.PaginationPage0,
+		ItemSelector: "ul.results > li.vacancy",
+		DetailsURL:   baseURL + "/vacancy/{id}",
+		Fields: configs.ScrapingFieldsConfig{
+			ID:          "@data-id",
+			Title:       "a.vacancy-title",
+			Company:     ".company",
+			Salary:      ".salary",
+			City:        ".location",
+			URL:         "h2 > a.vacancy-title@href",
+			Description: "p.snippet",
+		},
+		Details: configs.ScrapingFieldsConfig{
+			Title:       "article h1",
+			Company:     ".vacancy-company",
+			City:        ".vacancy-location",
+			Description: "div.vacancy-description",
+		},
+	}
+	return cfg
+}
+
+// функция создания парсера HTML страниц, который ходит в тестовый сервер с фикстурами
+func newTestScraperParser(t *testing.T) (*ScraperParser, *httptest.Server) {
+	t.Helper()
+
+	search := loadFixture(t, "scraper_search.html")
+	details := loadFixture(t, "scraper_details.html")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(search)
+	})
+	mux.HandleFunc("/vacancy/5501", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(details)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	p, err := NewScraperParser(newTestScraperConfig(server.URL))
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+	return p.(*ScraperParser), server
+}
+
+func TestParseSelector(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`
+		<div id="main" class="list wide">
+			<section><a class="title" href="/1" data-kind="job">Первая</a></section>
+			<a class="title" href="/2">Вторая</a>
+		</div>
+		<a class="title" href="/3">Вне списка</a>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"a.title", []string{"/1", "/2", "/3"}},
+		{"#main a", []string{"/1", "/2"}},
+		{"div.list.wide > a", []string{"/2"}},
+		{"div > section > a[data-kind=job]", []string{"/1"}},
+		{"[data-kind]", []string{"/1"}},
+		{"div.missing a", nil},
+	}
+
+	for _, tt := range tests {
+		selector, err := parseSelector(tt.selector)
+		if err != nil {
+			t.Fatalf("%q: ошибка разбора: %v", tt.selector, err)
+		}
+
+		var got []string
+		for _, n := range selector.selectAll(doc) {
+			got = append(got, nodeAttr(n, "href"))
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%q: получено %v, ожидалось %v", tt.selector, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "> a", "div >", "a[href", "div.", "a..b"} {
+		if _, err := parseSelector(bad); err == nil {
+			t.Errorf("%q: ожидалась ошибка разбора", bad)
+		}
+	}
+}
+
+func TestNewScraperParser_Validation(t *testing.T) {
+	if _, err := NewScraperParser(nil); err == nil {
+		t.Error("ожидалась ошибка для пустого конфига")
+	}
+
+	cfg := newTestScraperConfig("https://jobs.example.com")
+	cfg.Scraping.ItemSelector = "ul >"
+	if _, err := NewScraperParser(cfg); err == nil {
+		t.Error("ожидалась ошибка для некорректного item_selector")
+	}
+
+	cfg = newTestScraperConfig("https://jobs.example.com")
+	cfg.Scraping.Fields.ID = ""
+	cfg.Scraping.Fields.URL = ""
+	if _, err := NewScraperParser(cfg); err == nil {
+		t.Error("ожидалась ошибка для конфига без id и url")
+	}
+}
+
+func TestScraperParser_BuildURL(t *testing.T) {
+	p, server := newTestScraperParser(t)
+
+	rawURL, err := p.buildURL(models.SearchParams{Text: "golang", Page: 3})
+	if err != nil {
+		t.Fatalf("ошибка построения URL: %v", err)
+	}
+
+	u, _ := url.Parse(rawURL)
+	if u.Path != "/search" || u.Query().Get("q") != "golang" || u.Query().Get("page") != "2" {
+		t.Errorf("неверный URL: %s", rawURL)
+	}
+
+	detailsURL, _ := p.buildDetailsURL("5501")
+	if detailsURL != server.URL+"/vacancy/5501" {
+		t.Errorf("неверный URL деталей: %s", detailsURL)
+	}
+}
+
+func TestScraperParser_SearchVacancies(t *testing.T) {
+	p, server := newTestScraperParser(t)
+
+	vacancies, err := p.SearchVacancies(context.Background(), models.SearchParams{Text: "golang"})
+	if err != nil {
+		t.Fatalf("ошибка поиска: %v", err)
+	}
+
+	// карточка без ID и ссылки пропускается, реклама в шапке не попадает под item_selector
+	if len(vacancies) != 2 {
+		t.Fatalf("ожидалось 2 вакансии, получено %d: %+v", len(vacancies), vacancies)
+	}
+
+	first := vacancies[0]
+	if first.ID != "5501" || first.Job != "Go-разработчик (Middle)" || first.Company != "ООО «Ромашка»" {
+		t.Errorf("неверно сконвертирована вакансия: %+v", first)
+	}
+	wantSalary := models.Salary{From: 200000, To: 280000, Currency: "RUB", Period: models.SalaryPeriodMonth}
+	if first.Salary != wantSalary {
+		t.Errorf("неверная зарплата: %+v", first.Salary)
+	}
+	if first.URL != server.URL+"/vacancy/5501?from=search" {
+		t.Errorf("относительная ссылка не дополнена хостом: %s", first.URL)
+	}
+	if first.Description != "Разработка микросервисов на Go" || first.Source != "Example Jobs" {
+		t.Errorf("неверное описание или источник: %+v", first)
+	}
+
+	second := vacancies[1]
+	if second.Salary.Specified() || second.Location != "Санкт-Петербург" {
+		t.Errorf("неверно сконвертирована вакансия без зарплаты: %+v", second)
+	}
+}
+
+func TestScraperParser_SearchVacanciesDetailes(t *testing.T) {
+	p, server := newTestScraperParser(t)
+
+	details, err := p.SearchVacanciesDetailes(context.Background(), "5501")
+	if err != nil {
+		t.Fatalf("ошибка получения деталей: %v", err)
+	}
+
+	if details.ID != "5501" || details.Name != "Go-разработчик (Middle)" || details.Employer.Name != "ООО «Ромашка»" {
+		t.Errorf("неверные детали вакансии: %+v", details)
+	}
+	if details.Location.Name != "Москва, м. Курская" || details.Url != server.URL+"/vacancy/5501" {
+		t.Errorf("неверная локация или ссылка: %+v", details)
+	}
+	if details.Description != "Мы ищем Go-разработчика в команду платформы. Go, PostgreSQL, Kafka" {
+		t.Errorf("неверное описание: %q", details.Description)
+	}
+
+	// страницы нет - ошибка от BaseParser
+	if _, err := p.SearchVacanciesDetailes(context.Background(), "404"); err == nil {
+		t.Error("ожидалась ошибка для несуществующей вакансии")
+	}
+}
+
+func TestIDFromLink(t *testing.T) {
+	tests := map[string]string{
+		"https://jobs.example.com/vacancy/123?from=list": "123",
+		"https://jobs.example.com/vacancy/abc-42/":       "abc-42",
+		"https://jobs.example.com/":                      "",
+		"":                                               "",
+	}
+	for link, want := range tests {
+		if got := idFromLink(link); got != want {
+			t.Errorf("idFromLink(%q) = %q, ожидалось %q", link, got, want)
+		}
+	}
+}
@@ -11,10 +11,19 @@ import (
 
 	"search_service/configs"
 	"search_service/internal/domain/models"
+	"search_service/internal/locations"
 	"search_service/internal/parser/model"
+	"search_service/pkg"
 
 	"search_service/internal/search_interfaces"
 	"strconv"
+	"time"
+)
+
+const (
+	sjDefaultPerPage = 20  // размер страницы SuperJob по умолчанию
+	sjMaxPerPage     = 100 // максимальный размер страницы, который принимает SuperJob
+	sjMaxResults     = 500 // SuperJob отдаёт не более 500 вакансий по одному запросу
 )
 
 // создаём стркутуру парсера для SuperJob.ru на базе общего парсера
@@ -42,6 +51,7 @@ func NewSJParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, e
 		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
 		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
 		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		Request:               cfg.Request,
 	}
 
 	baseParser, err := NewBaseParser(baseCfg)
@@ -56,15 +66,32 @@ func NewSJParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, e
 
 // метод парсера для поиска списка вакансий
 func (p *SJParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
-	return p.BaseParser.SearchVacancies(
+	page, err := p.SearchVacanciesPage(ctx, params)
+	return page.Vacancies, err
+}
+
+// метод парсера для поиска одной страницы вакансий вместе с общим количеством найденного
+func (p *SJParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	page, err := p.BaseParser.SearchVacanciesPage(
 		ctx,
 		params,
 		ParserFuncs{
 			BuildURL: p.buildURL,
 			Parse:    p.parseResponseSearchVacancies,
 			Convert:  p.convertToUniversal,
+			Totals: func(searchResponse interface{}) (int, int) {
+				return p.totals(searchResponse, sjPerPage(params.PerPage))
+			},
 		},
 	)
+	if err != nil {
+		return page, err
+	}
+
+	// фильтры, которых нет у SuperJob, применяем к уже полученной странице
+	// (found при этом остаётся в терминах SuperJob - без учёта этих фильтров)
+	page.Vacancies = sjPostFilter(page.Vacancies, params)
+	return page, nil
 }
 
 // метод парсера для поиска деталей по конкретной вакансии
@@ -79,6 +106,11 @@ func (p *SJParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string
 	)
 }
 
+// справочник локаций, которым пользуется источник
+func (p *SJParser) LocationCatalog() string {
+	return locations.CatalogSuperJob
+}
+
 // buildURL строит URL для API запроса для поиска списка вакансий
 func (p *SJParser) buildURL(params models.SearchParams) (string, error) {
 	// преобразуем строку запроса в структуру URL
@@ -97,10 +129,15 @@ func (p *SJParser) buildURL(params models.SearchParams) (string, error) {
 
 	// добавляем параметр - локация
 	if params.Country != "" {
-		query.Set("country", p.convertArea(params.Country))
+		setSJLocation(query, p.locations, params.Country)
 	}
 
+	// добавляем фильтры поиска
+	setSJFilters(query, params, time.Now())
+
 	// добавляем параетры страниц
+	query.Set("count", strconv.Itoa(sjPerPage(params.PerPage)))
+
 	if params.Page > 0 {
 		query.Set("page", strconv.Itoa(params.Page-1)) // SuperJob использует 0-based
 	}
@@ -165,33 +202,39 @@ func (p *SJParser) convertToUniversal(searchResponse interface{}) ([]models.Vaca
 	universalVacancies := make([]models.Vacancy, len(searchResp.Items))
 
 	for i, sjv := range searchResp.Items {
-		salary := sjv.GetSalaryString()
 		universalVacancies[i] = models.Vacancy{
 			ID:          strconv.Itoa(sjv.ID),
 			Job:         sjv.Profession,
 			Company:     sjv.FirmName,
-			Currency:    sjv.Currency,
-			Salary:      &salary,
+			Salary:      sjSalary(sjv),
 			Location:    sjv.Town.Title,
+			Experience:  sjExperienceCodes[sjv.Experience.ID],
+			Schedule:    sjScheduleCode(sjv.TypeOfWork.ID, sjv.PlaceOfWork.ID),
+			Employment:  sjEmploymentCodes[sjv.TypeOfWork.ID],
 			URL:         sjv.Link,
 			Source:      p.GetName(),
 			Description: sjv.VacancyRichText,
 		}
+		if sjv.DatePublished > 0 {
+			universalVacancies[i].PublishedAt = time.Unix(sjv.DatePublished, 0)
+		}
 	}
 	return universalVacancies, nil
 }
 
-// метод для конвертации локации
-func (p *SJParser) convertArea(area string) string {
-	// Конвертируем коды регионов HH.ru в названия SuperJob
-	areas := map[string]string{
-		"1": "Москва",
-		"2": "Санкт-Петербург",
+// метод получения общего количества найденных вакансий и страниц
+// SuperJob не отдаёт больше sjMaxResults вакансий по одному запросу, поэтому страниц может быть меньше, чем total/count
+func (p *SJParser) totals(searchResponse interface{}, perPage int) (int, int) {
+	searchResp, ok := searchResponse.(*model.SuperJobResponse)
+	if !ok {
+		return 0, 0
 	}
-	if name, ok := areas[area]; ok {
-		return name
+
+	available := searchResp.Total
+	if available > sjMaxResults {
+		available = sjMaxResults
 	}
-	return ""
+	return searchResp.Total, pagesCount(available, perPage)
 }
 
 // GetVacancyByID получает детальную информацию о вакансии по ID
@@ -202,7 +245,7 @@ func (p *SJParser) GetVacancyByID(vacancyID string) (*model.SJVacancy, error) {
 	}
 
 	apiURL := p.baseURL + "/" + vacancyID
-	resp, err := p.httpClient.Get(apiURL)
+	resp, err := p.executeRequest(context.Background(), apiURL)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -226,3 +269,193 @@ func (p *SJParser) GetVacancyByID(vacancyID string) (*model.SJVacancy, error) {
 
 	return &vacancy, nil
 }
+
+// функция перевода локации в параметры SuperJob: страна - c, регион - o, город - town
+// если локация не найдена в справочнике - передаём название города текстом, SuperJob понимает его сам
+func setSJLocation(query url.Values, resolver search_interfaces.LocationResolver, text string) {
+	location, ok := resolver.Resolve(locations.CatalogSuperJob, text)
+	if !ok {
+		query.Set("town", text)
+		return
+	}
+
+	switch location.Kind {
+	case models.LocationCountry:
+		query.Set("c", location.Code)
+	case models.LocationRegion:
+		query.Set("o", location.Code)
+	default:
+		query.Set("town", location.Code)
+	}
+}
+
+// функция вычисления размера страницы для SuperJob (параметр count)
+func sjPerPage(perPage int) int {
+	if perPage <= 0 {
+		return sjDefaultPerPage
+	}
+	if perPage > sjMaxPerPage {
+		return sjMaxPerPage
+	}
+	return perPage
+}
+
+// справочники SuperJob: https://api.superjob.ru/#catalogues
+const (
+	sjTypeOfWorkFullDay     = 6  // полный рабочий день
+	sjTypeOfWorkTemporary   = 7  // временная работа
+	sjTypeOfWorkFlyInFlyOut = 9  // вахтовый метод
+	sjTypeOfWorkPartDay     = 10 // неполный рабочий день
+	sjTypeOfWorkShift       = 12 // сменный график
+	sjTypeOfWorkPartTime    = 13 // частичная занятость
+	sjPlaceOfWorkRemote     = 2  // работа на дому
+)
+
+// опыт работы: универсальный код -> параметр experience SuperJob
+var sjExperience = map[string]int{
+	models.ExperienceNone:      1,
+	models.Experience1To3:      2,
+	models.Experience3To6:      3,
+	models.ExperienceMoreThan6: 4,
+}
+
+// опыт работы: id справочника SuperJob -> универсальный код
+var sjExperienceCodes = map[int]string{
+	1: models.ExperienceNone,
+	2: models.Experience1To3,
+	3: models.Experience3To6,
+	4: models.ExperienceMoreThan6,
+}
+
+// график работы: универсальный код -> параметр type_of_work SuperJob (удалёнка задаётся через place_of_work)
+var sjScheduleTypeOfWork = map[string]int{
+	models.ScheduleFullDay:     sjTypeOfWorkFullDay,
+	models.ScheduleShift:       sjTypeOfWorkShift,
+	models.ScheduleFlyInFlyOut: sjTypeOfWorkFlyInFlyOut,
+}
+
+// тип занятости: универсальный код -> параметр type_of_work SuperJob
+var sjEmploymentTypeOfWork = map[string]int{
+	models.EmploymentFull:    sjTypeOfWorkFullDay,
+	models.EmploymentPart:    sjTypeOfWorkPartTime,
+	models.EmploymentProject: sjTypeOfWorkTemporary,
+}
+
+// тип занятости: id type_of_work SuperJob -> универсальный код
+var sjEmploymentCodes = map[int]string{
+	sjTypeOfWorkFullDay:   models.EmploymentFull,
+	sjTypeOfWorkPartDay:   models.EmploymentPart,
+	sjTypeOfWorkPartTime:  models.EmploymentPart,
+	sjTypeOfWorkTemporary: models.EmploymentProject,
+}
+
+// функция получения универсального кода графика работы по справочникам SuperJob
+func sjScheduleCode(typeOfWork, placeOfWork int) string {
+	if placeOfWork == sjPlaceOfWorkRemote {
+		return models.ScheduleRemote
+	}
+
+	switch typeOfWork {
+	case sjTypeOfWorkFullDay:
+		return models.ScheduleFullDay
+	case sjTypeOfWorkShift:
+		return models.ScheduleShift
+	case sjTypeOfWorkFlyInFlyOut:
+		return models.ScheduleFlyInFlyOut
+	default:
+		return ""
+	}
+}
+
+// функция определения, какие из фильтров графика и занятости SuperJob умеет применить сам
+// у SuperJob один параметр type_of_work на оба фильтра, поэтому при конфликте занятость фильтруется после получения
+func sjNativeFilters(params models.SearchParams) (typeOfWork int, scheduleNative, employmentNative bool) {
+	switch {
+	case params.Schedule == models.ScheduleRemote:
+		scheduleNative = true
+	case sjScheduleTypeOfWork[params.Schedule] != 0:
+		typeOfWork = sjScheduleTypeOfWork[params.Schedule]
+		scheduleNative = true
+	}
+
+	if code, ok := sjEmploymentTypeOfWork[params.Employment]; ok && (typeOfWork == 0 || typeOfWork == code) {
+		typeOfWork = code
+		employmentNative = true
+	}
+
+	return typeOfWork, scheduleNative, employmentNative
+}
+
+// функция перевода фильтров поиска в параметры SuperJob API
+func setSJFilters(query url.Values, params models.SearchParams, now time.Time) {
+	if params.SalaryFrom > 0 {
+		query.Set("payment_from", strconv.Itoa(params.SalaryFrom))
+	}
+	if params.OnlyWithSalary {
+		query.Set("no_agreement", "1") // не показывать вакансии "по договорённости"
+	}
+	if experience, ok := sjExperience[params.Experience]; ok {
+		query.Set("experience", strconv.Itoa(experience))
+	}
+
+	typeOfWork, _, _ := sjNativeFilters(params)
+	if typeOfWork != 0 {
+		query.Set("type_of_work", strconv.Itoa(typeOfWork))
+	}
+	if params.Schedule == models.ScheduleRemote {
+		query.Set("place_of_work", strconv.Itoa(sjPlaceOfWorkRemote))
+	}
+
+	if params.PublishedWithinDays > 0 {
+		query.Set("date_published_from", strconv.FormatInt(now.AddDate(0, 0, -params.PublishedWithinDays).Unix(), 10))
+	}
+
+	switch params.OrderBy {
+	case models.OrderDate:
+		query.Set("order_field", "date")
+		query.Set("order_direction", "desc")
+	case models.OrderSalaryDesc:
+		query.Set("order_field", "payment")
+		query.Set("order_direction", "desc")
+	case models.OrderSalaryAsc:
+		query.Set("order_field", "payment")
+		query.Set("order_direction", "asc")
+	}
+}
+
+// функция фильтрации вакансий по тем фильтрам, которые SuperJob не умеет применять сам
+func sjPostFilter(vacancies []models.Vacancy, params models.SearchParams) []models.Vacancy {
+	_, scheduleNative, employmentNative := sjNativeFilters(params)
+	checkSchedule := params.Schedule != "" && !scheduleNative
+	checkEmployment := params.Employment != "" && !employmentNative
+
+	if !checkSchedule && !checkEmployment {
+		return vacancies
+	}
+
+	filtered := make([]models.Vacancy, 0, len(vacancies))
+	for _, vacancy := range vacancies {
+		if checkSchedule && vacancy.Schedule != params.Schedule {
+			continue
+		}
+		if checkEmployment && vacancy.Employment != params.Employment {
+			continue
+		}
+		filtered = append(filtered, vacancy)
+	}
+	return filtered
+}
+
+// функция приведения зарплаты SuperJob к общей структуре (SuperJob указывает зарплату за месяц)
+func sjSalary(v model.SJVacancy) models.Salary {
+	if v.PaymentFrom == 0 && v.PaymentTo == 0 {
+		return models.Salary{}
+	}
+
+	return models.Salary{
+		From:     v.PaymentFrom,
+		To:       v.PaymentTo,
+		Currency: pkg.NormalizeCurrency(v.Currency),
+		Period:   models.SalaryPeriodMonth,
+	}
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"net/url"
+	"search_service/internal/domain/models"
+	"search_service/internal/locations"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSetSJFilters(t *testing.T) {
+	now := time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	query := url.Values{}
+	setSJFilters(query, models.SearchParams{
+		SalaryFrom:          100000,
+		OnlyWithSalary:      true,
+		Experience:          models.Experience3To6,
+		Schedule:            models.ScheduleShift,
+		PublishedWithinDays: 3,
+		OrderBy:             models.OrderSalaryAsc,
+	}, now)
+
+	want := map[string]string{
+		"payment_from":        "100000",
+		"no_agreement":        "1",
+		"experience":          "3",
+		"type_of_work":        "12",
+		"date_published_from": strconv.FormatInt(now.AddDate(0, 0, -3).Unix(), 10),
+		"order_field":         "payment",
+		"order_direction":     "asc",
+	}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("ожидался %s=%s, получено %q", key, value, got)
+		}
+	}
+
+	// удалёнка задаётся через place_of_work, занятость - через type_of_work
+	query = url.Values{}
+	setSJFilters(query, models.SearchParams{Schedule: models.ScheduleRemote, Employment: models.EmploymentPart}, now)
+	if query.Get("place_of_work") != "2" || query.Get("type_of_work") != "13" {
+		t.Errorf("неверные параметры графика и занятости: %v", query)
+	}
+}
+
+func TestSJPostFilter(t *testing.T) {
+	vacancies := []models.Vacancy{
+		{ID: "1", Schedule: models.ScheduleShift, Employment: models.EmploymentFull},
+		{ID: "2", Schedule: models.ScheduleShift, Employment: models.EmploymentPart},
+		{ID: "3", Schedule: models.ScheduleFullDay, Employment: models.EmploymentFull},
+	}
+
+	tests := []struct {
+		name   string
+		params models.SearchParams
+		want   []string
+	}{
+		{"без фильтров", models.SearchParams{}, []string{"1", "2", "3"}},
+		// оба фильтра SuperJob применяет сам - ничего не отсекаем
+		{"нативные фильтры", models.SearchParams{Schedule: models.ScheduleShift}, []string{"1", "2", "3"}},
+		// график и занятость конфликтуют в type_of_work - занятость фильтруем сами
+		{"конфликт type_of_work", models.SearchParams{Schedule: models.ScheduleShift, Employment: models.EmploymentPart}, []string{"2"}},
+		// гибкого графика у SuperJob нет
+		{"нет такого графика", models.SearchParams{Schedule: models.ScheduleFlexible}, nil},
+		// стажировки у SuperJob нет
+		{"нет такой занятости", models.SearchParams{Employment: models.EmploymentProbation}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, vacancy := range sjPostFilter(vacancies, tt.params) {
+				got = append(got, vacancy.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("получено %v, ожидалось %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("получено %v, ожидалось %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSJScheduleCode(t *testing.T) {
+	if code := sjScheduleCode(sjTypeOfWorkFullDay, sjPlaceOfWorkRemote); code != models.ScheduleRemote {
+		t.Errorf("работа на дому должна считаться удалёнкой, получено %q", code)
+	}
+	if code := sjScheduleCode(sjTypeOfWorkFlyInFlyOut, 1); code != models.ScheduleFlyInFlyOut {
+		t.Errorf("ожидался вахтовый метод, получено %q", code)
+	}
+	if code := sjScheduleCode(sjTypeOfWorkPartTime, 1); code != "" {
+		t.Errorf("для частичной занятости график не определён, получено %q", code)
+	}
+}
+
+// проверяем перевод локации в параметры SuperJob
+func TestSetSJLocation(t *testing.T) {
+	resolver := locations.Snapshot()
+
+	tests := []struct {
+		text  string
+		key   string
+		value string
+	}{
+		{"Москва", "town", "4"},
+		{"Россия", "c", "1"},
+		{"Тверь", "town", "Тверь"}, // нет в справочнике - передаём название
+	}
+
+	for _, tt := range tests {
+		query := url.Values{}
+		setSJLocation(query, resolver, tt.text)
+		if got := query.Get(tt.key); got != tt.value {
+			t.Errorf("%s: ожидался %s=%s, получено %v", tt.text, tt.key, tt.value, query)
+		}
+	}
+}
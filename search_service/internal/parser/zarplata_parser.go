@@ -0,0 +1,232 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/internal/locations"
+	"search_service/internal/parser/model"
+
+	"search_service/internal/search_interfaces"
+)
+
+// формат даты публикации в API HH-совместимых источников ("2024-05-14T12:31:08+0300")
+const hhDateLayout = "2006-01-02T15:04:05-0700"
+
+// создаём стркутуру парсера для Зарплата.ру на базе общего парсера
+// Зарплата.ру работает на платформе HH, поэтому формат ответов API совпадает с HH.ru
+type ZarplataParser struct {
+	*BaseParser
+}
+
+// конструктор для парсера Зарплата.ру
+func NewZarplataParser(cfg *configs.ParserInstanceConfig) (search_interfaces.Parser, error) {
+	if cfg == nil {
+		cfg = configs.DefaultParsersConfig().Zarplata
+	}
+
+	baseCfg := BaseConfig{
+		Name:                  "Zarplata.ru",
+		BaseURL:               cfg.BaseURL,
+		HealthEndPoint:        cfg.HealthEndPoint,
+		APIKey:                cfg.APIKey,
+		Timeout:               cfg.Timeout,
+		RateLimit:             cfg.RateLimit,
+		MaxConcurrent:         cfg.MaxConcurrent,
+		CircuitBreakerCfg:     cfg.CircuitBreaker,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		Request:               cfg.Request,
+	}
+
+	baseParser, err := NewBaseParser(baseCfg)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка в конфигурации rate limiter для парсера %s\n", baseCfg.Name)
+	}
+
+	return &ZarplataParser{
+		BaseParser: baseParser,
+	}, nil
+}
+
+// метод парсера для поиска списка вакансий
+func (p *ZarplataParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
+	page, err := p.SearchVacanciesPage(ctx, params)
+	return page.Vacancies, err
+}
+
+// метод парсера для поиска одной страницы вакансий вместе с общим количеством найденного
+func (p *ZarplataParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	return p.BaseParser.SearchVacanciesPage(
+		ctx,
+		params,
+		ParserFuncs{
+			BuildURL: p.buildURL,
+			Parse:    p.parseResponseSearchVacancies,
+			Convert:  p.convertToUniversal,
+			Totals:   hhTotals,
+		},
+	)
+}
+
+// метод парсера для поиска деталей по конкретной вакансии
+func (p *ZarplataParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string) (models.SearchVacancyDetailesResult, error) {
+	return p.BaseParser.SearchVacancyDetailes(
+		ctx,
+		vacancyID,
+		ParserFuncs{
+			Parse:          p.parseResponseSearchDetails,
+			ConvertDetails: p.convertDetails,
+		},
+	)
+}
+
+// справочник локаций, которым пользуется источник (классификатор регионов общий с HH.ru)
+func (p *ZarplataParser) LocationCatalog() string {
+	return locations.CatalogHH
+}
+
+// buildURL строит URL для API запроса для поиска списка вакансий
+func (p *ZarplataParser) buildURL(params models.SearchParams) (string, error) {
+	// преобразуем строку запроса в структуру URL
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	// заводим переменную, где будут хнаниться значения
+	query := u.Query()
+
+	// добавляем основной параметр поиска
+	if params.Text != "" {
+		query.Set("text", params.Text)
+	}
+
+	// добавляем параметр - локация (классификатор регионов общий с HH.ru)
+	if params.Country != "" {
+		query.Set("area", hhAreaCode(p.locations, params.Country))
+	}
+
+	// добавляем параетры страниц
+	perPage := params.PerPage
+	if perPage <= 0 || perPage > 100 {
+		perPage = 20 // Значение по умолчанию
+	}
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	if params.Page > 1 {
+		query.Set("page", strconv.Itoa(params.Page-1)) // Zarplata.ru использует 0-based
+	}
+
+	// добавляем фильтры поиска (параметры такие же, как у HH.ru)
+	setHHFilters(query, params, time.Now())
+
+	// формируем строку эндпоинта для запроса
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// метод парсера обработки тела запроса при поиске списка вакансий
+func (p *ZarplataParser) parseResponseSearchVacancies(body []byte) (interface{}, error) {
+	var searchResponse model.SearchResponse
+	if err := json.Unmarshal(body, &searchResponse); err != nil {
+		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+	}
+	return &searchResponse, nil
+}
+
+// метод парсера обработки тела запроса при поиске деталей вакансии
+func (p *ZarplataParser) parseResponseSearchDetails(body []byte) (interface{}, error) {
+	var searchResponse model.SearchDetails
+	if err := json.Unmarshal(body, &searchResponse); err != nil {
+		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
+	}
+	return &searchResponse, nil
+}
+
+// метод приведения результатов поиска у унифицированной структуре + проверка данных их интерфейса
+func (p *ZarplataParser) convertToUniversal(searchResponse interface{}) ([]models.Vacancy, error) {
+	// проверка интерфейса на nil
+	if searchResponse == nil {
+		return []models.Vacancy{}, fmt.Errorf("[%s] searchResponse is nil", p.name)
+	}
+
+	// Проводим type assertion
+	searchResp, ok := searchResponse.(*model.SearchResponse)
+	if !ok {
+
+		// Для более детальной информации можно использовать reflect
+		fmt.Printf("----------------->>>[Parser name: %s] DEBUG: Type details: %v\n", p.name, reflect.TypeOf(searchResponse))
+		return []models.Vacancy{}, fmt.Errorf("[Parser name: %s], wrong data type in the response body\n", p.name)
+	}
+
+	// сразу инициализируем слайс универсальных вакансий, чтобы уменьшить количество переаалокаций, если выйдем за размер базового массива слайса
+	universalVacancies := make([]models.Vacancy, len(searchResp.Items))
+
+	for i, zv := range searchResp.Items {
+		// для пользователя важна ссылка на сайт, а не на API
+		link := zv.AlternateURL
+		if link == "" {
+			link = zv.URL
+		}
+
+		// дата публикации может прийти пустой или в неожиданном формате - тогда просто оставляем нулевое значение
+		publishedAt, _ := time.Parse(hhDateLayout, zv.PublishedAt)
+
+		universalVacancies[i] = models.Vacancy{
+			ID:          zv.ID,
+			Job:         zv.Name,
+			Company:     zv.Employer.Name,
+			Salary:      hhSalary(zv.Salary, zv.SalaryRange.Mode.ID),
+			Location:    zv.Area.Name,
+			Experience:  zv.Experience.ID,
+			Schedule:    zv.Schedule.ID,
+			Employment:  zv.Employment.ID,
+			URL:         link,
+			Source:      p.GetName(),
+			Description: zv.Description,
+			PublishedAt: publishedAt,
+		}
+	}
+
+	return universalVacancies, nil
+}
+
+// метод приведения результатов поиска по конкретной ваансии к нужному типу + проверка данных интерфейса
+func (p *ZarplataParser) convertDetails(detailsResponse interface{}) (models.SearchVacancyDetailesResult, error) {
+	// проверка интерфейса на nil
+	if detailsResponse == nil {
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[%s] searchResponse is nil", p.name)
+	}
+
+	// Проводим type assertion
+	searchResp, ok := detailsResponse.(*model.SearchDetails)
+	if !ok {
+
+		// Для более детальной информации можно использовать reflect
+		fmt.Printf("----------------->>>[Parser name: %s] DEBUG: Type details: %v\n", p.name, reflect.TypeOf(detailsResponse))
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[Parser name: %s], wrong data type in the response body\n", p.name)
+	}
+
+	vacDetails := models.SearchVacancyDetailesResult{
+		Employer:    models.Employer(searchResp.Employer),
+		Location:    models.Area(searchResp.Area),
+		Salary:      hhSalary(searchResp.Salary, ""),
+		Description: searchResp.Description,
+		Name:        searchResp.Name,
+		ID:          searchResp.ID,
+		Url:         searchResp.Url,
+	}
+
+	return vacDetails, nil
+}
@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"net/url"
+	"search_service/internal/domain/models"
+	"testing"
+)
+
+// проверяем формирование URL поиска (классификатор регионов общий с HH.ru)
+func TestZarplataParser_BuildURL(t *testing.T) {
+	p, err := NewZarplataParser(newTestParserConfig("https://api.zarplata.ru/vacancies"))
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+	zp := p.(*ZarplataParser)
+
+	rawURL, err := zp.buildURL(models.SearchParams{Text: "продавец", Country: "Казахстан", Page: 1})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	u, _ := url.Parse(rawURL)
+	query := u.Query()
+	if query.Get("text") != "продавец" {
+		t.Errorf("ожидался text=продавец, получено %q", query.Get("text"))
+	}
+	if query.Get("area") != "40" {
+		t.Errorf("ожидался area=40, получено %q", query.Get("area"))
+	}
+	if query.Get("per_page") != "20" {
+		t.Errorf("ожидался per_page=20 по умолчанию, получено %q", query.Get("per_page"))
+	}
+	// первая страница нашего API - это страница 0 у HH-совместимого API, параметр не передаём
+	if query.Has("page") {
+		t.Errorf("для первой страницы параметр page не ожидался, получено %q", query.Get("page"))
+	}
+
+	rawURL, _ = zp.buildURL(models.SearchParams{Text: "продавец", Page: 3})
+	u, _ = url.Parse(rawURL)
+	if u.Query().Get("page") != "2" {
+		t.Errorf("ожидался page=2 для третьей страницы, получено %q", u.Query().Get("page"))
+	}
+
+	// неизвестная страна - используем Россию
+	rawURL, _ = zp.buildURL(models.SearchParams{Text: "продавец", Country: "Атлантида"})
+	u, _ = url.Parse(rawURL)
+	if u.Query().Get("area") != "113" {
+		t.Errorf("ожидался area=113 для неизвестной страны, получено %q", u.Query().Get("area"))
+	}
+}
+
+// проверяем разбор и конвертацию записанного ответа поиска
+func TestZarplataParser_ParseAndConvert(t *testing.T) {
+	p, err := NewZarplataParser(newTestParserConfig("https://api.zarplata.ru/vacancies"))
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+	zp := p.(*ZarplataParser)
+
+	parsed, err := zp.parseResponseSearchVacancies(loadFixture(t, "zarplata_search.json"))
+	if err != nil {
+		t.Fatalf("ошибка разбора: %v", err)
+	}
+
+	vacancies, err := zp.convertToUniversal(parsed)
+	if err != nil {
+		t.Fatalf("ошибка конвертации: %v", err)
+	}
+
+	if len(vacancies) != 2 {
+		t.Fatalf("ожидалось 2 вакансии, получено %d", len(vacancies))
+	}
+
+	first := vacancies[0]
+	if first.Job != "Продавец-консультант" || first.Company != "Магнит" || first.Location != "Новосибирск" {
+		t.Errorf("неверные данные вакансии: %+v", first)
+	}
+	if first.URL != "https://novosibirsk.zarplata.ru/vacancy/card/98765432" {
+		t.Errorf("ожидалась ссылка на сайт, получено %s", first.URL)
+	}
+	wantSalary := models.Salary{From: 45000, To: 60000, Currency: "RUB", Period: models.SalaryPeriodMonth}
+	if first.Salary != wantSalary {
+		t.Errorf("неверная зарплата: %+v", first.Salary)
+	}
+	if first.Source != "Zarplata.ru" {
+		t.Errorf("неверный источник: %s", first.Source)
+	}
+	if first.PublishedAt.IsZero() {
+		t.Error("дата публикации должна быть заполнена")
+	}
+
+	// если ссылки на сайт нет - используем ссылку API
+	second := vacancies[1]
+	if second.URL != "https://api.zarplata.ru/vacancies/98765433" {
+		t.Errorf("неверная ссылка: %s", second.URL)
+	}
+	if second.Salary.Specified() {
+		t.Errorf("для вакансии без зарплаты ожидалась пустая зарплата, получено %+v", second.Salary)
+	}
+}
+
+func TestHHTotals(t *testing.T) {
+	p, err := NewZarplataParser(newTestParserConfig("https://api.zarplata.ru/vacancies"))
+	if err != nil {
+		t.Fatalf("не удалось создать парсер: %v", err)
+	}
+
+	data, err := p.(*ZarplataParser).parseResponseSearchVacancies(loadFixture(t, "zarplata_search.json"))
+	if err != nil {
+		t.Fatalf("ошибка разбора: %v", err)
+	}
+
+	if found, pages := hhTotals(data); found != 2 || pages != 1 {
+		t.Errorf("неверные итоги: found=%d pages=%d", found, pages)
+	}
+	if found, pages := hhTotals("не тот тип"); found != 0 || pages != 0 {
+		t.Errorf("для неизвестного типа ожидались нули, получено found=%d pages=%d", found, pages)
+	}
+}
+
+func TestPagesCount(t *testing.T) {
+	tests := []struct{ found, perPage, want int }{
+		{0, 20, 0},
+		{1, 20, 1},
+		{20, 20, 1},
+		{21, 20, 2},
+		{100, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := pagesCount(tt.found, tt.perPage); got != tt.want {
+			t.Errorf("pagesCount(%d, %d) = %d, ожидалось %d", tt.found, tt.perPage, got, tt.want)
+		}
+	}
+}
@@ -3,38 +3,69 @@ package parsers_manager
 import (
 	"fmt"
 	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+	"time"
 
 	"strings"
 )
 
+// функция чтения из кэша вместе с устаревшими значениями, если кэш их хранит (stale-while-revalidate)
+// для кэша без такой поддержки найденное значение всегда свежее
+func getWithStale[V any](cache search_interfaces.CacheInterface[V], key string) (value V, fresh bool, found bool) {
+	if staleCache, isStale := cache.(search_interfaces.StaleCacheInterface[V]); isStale {
+		return staleCache.GetStale(key)
+	}
+	value, found = cache.Get(key)
+	return value, found, found
+}
+
+// функция записи в кэш: после ttl значение ещё stale хранится как устаревшее, если кэш это умеет
+func setWithStale[V any](cache search_interfaces.CacheInterface[V], key string, value V, ttl, stale time.Duration) {
+	if staleCache, isStale := cache.(search_interfaces.StaleCacheInterface[V]); isStale && stale > 0 {
+		staleCache.SetWithStale(key, value, ttl, stale)
+		return
+	}
+	cache.Set(key, value, ttl)
+}
+
+// GetVacancyIndex - метод получения записи обратного индекса по составному индексу источника и ID ("hh_123")
+// запись живёт столько же, сколько результаты поиска, на которые она ведёт (вместе с устаревшими)
+func (pm *ParsersManager) GetVacancyIndex(compositeID string) (models.VacancyIndex, bool) {
+	index, _, found := getWithStale(pm.VacancyIndex, compositeID)
+	return index, found
+}
+
+// GetCachedSearchResults - метод получения результатов поиска из кэша по хэшу поиска (вместе с устаревшими)
+func (pm *ParsersManager) GetCachedSearchResults(searchHash string) ([]models.SearchVacanciesResult, bool) {
+	results, _, found := getWithStale(pm.SearchCache, searchHash)
+	return results, found
+}
+
 // метод получения данных из поикового кэша по заданному хэшу поиска
-func (pm *ParsersManager) tryGetFromCache(params models.SearchParams) ([]models.SearchVacanciesResult, bool) {
+// если кэш хранит устаревшие результаты (stale-while-revalidate), они тоже возвращаются - с fresh == false
+func (pm *ParsersManager) tryGetFromCache(params models.SearchParams) (results []models.SearchVacanciesResult, fresh bool, found bool) {
 	fmt.Println("\n" + strings.Repeat("=", 50))
 	fmt.Println("⏳ Ищем вакансии в кэше...")
 
 	searchHash, err := pm.generateSearchHash(params)
 	if err != nil {
 		fmt.Printf("⚠️  Ошибка генерации поискового хэша: %v\n", err)
-		return nil, false
+		return nil, false, false
 	}
 
-	// пытаемся найти в кэше данные по заданному хэш ключу
-	cached, ok := pm.SearchCache.GetItem(searchHash)
-	if !ok {
+	// пытаемся найти в кэше данные по заданному хэш ключу (вместе с устаревшими, если кэш их хранит)
+	results, fresh, found = getWithStale(pm.SearchCache, searchHash)
+	if !found {
 		fmt.Println("⏳ Не удалось найти данные в кэше!")
-		return nil, false
+		return nil, false, false
 	}
 
-	// если можно получить данные из кэша №1, то получаем интерфейс.
-	// проводим type assertion, проверяем нужный тип
-	results, ok := cached.([]models.SearchVacanciesResult)
-	if !ok {
-		fmt.Println("⚠️  Type assertion для кэшированных данных не удался")
-		return nil, false
+	if fresh {
+		fmt.Println("✅ Найдены кэшированные данные")
+	} else {
+		fmt.Println("✅ Найдены устаревшие кэшированные данные")
 	}
-
-	fmt.Println("✅ Найдены кэшированные данные")
-	return results, true
+	return results, fresh, true
 }
 
 // метод для кэширования результатов поиска списка вакансий в 2 кэша (в поисковый кэш и в индексный)
@@ -45,8 +76,9 @@ func (pm *ParsersManager) cacheSearchResults(params models.SearchParams, results
 		return
 	}
 
-	//записываем данные в поисковый кэш №1
-	pm.SearchCache.AddItemWithTTL(searchHash, results, pm.config.Cache.SearchCacheConfig.SearchCacheTTL)
+	//записываем данные в поисковый кэш №1 (после TTL они ещё хранятся как устаревшие, если кэш это умеет)
+	searchCacheConfig := pm.config.Cache.SearchCacheConfig
+	setWithStale(pm.SearchCache, searchHash, results, searchCacheConfig.SearchCacheTTL, searchCacheConfig.SearchCacheStale)
 
 	// Строим обратный индекс и сразу кэшируем его в кэше №2
 	pm.buildReverseIndex(searchHash, results)
@@ -54,12 +86,12 @@ func (pm *ParsersManager) cacheSearchResults(params models.SearchParams, results
 	fmt.Printf("✅ Результаты поиска закэшированы в поисковом кэше (ключ: %s)\n", searchHash)
 }
 
-// метод для кэширования результатов поиска деталей конкретной вакансии по заднанному ID и парсеру (источнику)
-func (pm *ParsersManager) cacheDetailsResult(vacancyID string, results models.SearchVacancyDetailesResult) {
+// метод для кэширования результатов поиска деталей конкретной вакансии по составному индексу источника и ID ("hh_123")
+func (pm *ParsersManager) cacheDetailsResult(detailsKey string, results models.SearchVacancyDetailesResult) {
 	//записываем данные в поисковый кэш №3 (для деталей вакансии)
-	pm.SearchCache.AddItemWithTTL(vacancyID, results, pm.config.Cache.VacancyCacheConfig.VacancyCacheTTL)
+	pm.vacancyDetails.Set(detailsKey, results, pm.config.Cache.VacancyCacheConfig.VacancyCacheTTL)
 
-	fmt.Printf("✅ Результаты поиска закэшированы в поисковом кэше (ключ: %s)\n", vacancyID)
+	fmt.Printf("✅ Результаты поиска закэшированы в поисковом кэше (ключ: %s)\n", detailsKey)
 }
 
 // метод обёртка для генерации поискового хэша
@@ -0,0 +1,169 @@
+package parsers_manager
+
+import (
+	"fmt"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+	"strings"
+)
+
+// имена кэшей менеджера парсеров (в статистике и результатах очистки)
+const (
+	CacheNameSearch         = "search_cache"
+	CacheNameVacancyIndex   = "vacancy_index"
+	CacheNameVacancyDetails = "vacancy_details"
+)
+
+// кэш менеджера парсеров с именем (кэши хранят значения разных типов, поэтому здесь они без типа значений)
+type namedCache struct {
+	name  string
+	cache interface{}
+}
+
+// метод получения кэшей менеджера парсеров в постоянном порядке
+func (pm *ParsersManager) namedCaches() []namedCache {
+	return []namedCache{
+		{CacheNameSearch, pm.SearchCache},
+		{CacheNameVacancyIndex, pm.VacancyIndex},
+		{CacheNameVacancyDetails, pm.vacancyDetails},
+	}
+}
+
+// CacheStats - метод получения статистики кэшей поиска, обратного индекса и деталей вакансий
+func (pm *ParsersManager) CacheStats() []models.CacheStats {
+	caches := pm.namedCaches()
+	result := make([]models.CacheStats, 0, len(caches))
+
+	for _, c := range caches {
+		var stats models.CacheStats
+		if statsCache, ok := c.cache.(search_interfaces.StatsCacheInterface); ok {
+			stats = statsCache.Stats()
+		}
+		stats.Name = c.name
+
+		result = append(result, stats)
+	}
+
+	return result
+}
+
+// PurgeSearchHash - метод удаления результатов одного поиска из кэша поиска вместе с их записями в обратном индексе
+// следующий такой же поиск пойдёт в источники
+func (pm *ParsersManager) PurgeSearchHash(searchHash string) models.CachePurgeResult {
+	result := newCachePurgeResult()
+
+	// устаревшие результаты тоже хранятся в кэше - их удаление тоже учитывается
+	if _, _, found := getWithStale(pm.SearchCache, searchHash); found {
+		result.Removed[CacheNameSearch]++
+	}
+	pm.SearchCache.Delete(searchHash)
+
+	purgeCacheItems(&result, CacheNameVacancyIndex, pm.VacancyIndex, func(key string, index models.VacancyIndex) bool {
+		return index.SearchHash == searchHash
+	})
+
+	fmt.Printf("🧹 Из кэша удалены результаты поиска %s\n", searchHash)
+	return result
+}
+
+// PurgeVacancy - метод удаления вакансии из кэшей (ключи индекса и деталей - составной индекс источника и ID)
+// из кэша поиска удаляются поиски, в которых есть вакансия (целиком), из индекса - записи, которые ведут на них
+func (pm *ParsersManager) PurgeVacancy(source, vacancyID string) models.CachePurgeResult {
+	compositeID := fmt.Sprintf("%s_%s", source, vacancyID)
+	purgedHashes := make(map[string]bool)
+
+	// поиск, на который ведёт индекс вакансии, известен и без обхода кэша поиска (кэш в Redis обход не умеет)
+	index, indexed := pm.GetVacancyIndex(compositeID)
+	if indexed {
+		purgedHashes[index.SearchHash] = true
+	}
+
+	result := newCachePurgeResult()
+	purgeCacheItems(&result, CacheNameSearch, pm.SearchCache, func(key string, results []models.SearchVacanciesResult) bool {
+		for _, res := range results {
+			for _, vacancy := range res.Vacancies {
+				if vacancy.HasSourceID(source, vacancyID) {
+					purgedHashes[key] = true
+					return true
+				}
+			}
+		}
+		return false
+	})
+	if indexed {
+		pm.SearchCache.Delete(index.SearchHash)
+	}
+	purgeCacheItems(&result, CacheNameVacancyIndex, pm.VacancyIndex, func(key string, index models.VacancyIndex) bool {
+		return key == compositeID || purgedHashes[index.SearchHash]
+	})
+
+	pm.VacancyIndex.Delete(compositeID)
+	pm.vacancyDetails.Delete(compositeID)
+
+	fmt.Printf("🧹 Из кэшей удалена вакансия %s: %v\n", compositeID, result.Removed)
+	return result
+}
+
+// PurgeSource - метод удаления из кэшей всех данных источника
+// из кэша поиска удаляются поиски, в которых есть результаты источника (целиком), из индекса - записи, которые ведут на них
+func (pm *ParsersManager) PurgeSource(source string) models.CachePurgeResult {
+	prefix := source + "_"
+	purgedHashes := make(map[string]bool)
+
+	result := newCachePurgeResult()
+	purgeCacheItems(&result, CacheNameSearch, pm.SearchCache, func(key string, results []models.SearchVacanciesResult) bool {
+		for _, res := range results {
+			if res.ParserName == source {
+				purgedHashes[key] = true
+				return true
+			}
+		}
+		return false
+	})
+	purgeCacheItems(&result, CacheNameVacancyIndex, pm.VacancyIndex, func(key string, index models.VacancyIndex) bool {
+		return strings.HasPrefix(key, prefix) || index.ParserName == source || purgedHashes[index.SearchHash]
+	})
+	purgeCacheItems(&result, CacheNameVacancyDetails, pm.vacancyDetails, func(key string, _ models.SearchVacancyDetailesResult) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+
+	fmt.Printf("🧹 Из кэшей удалены данные источника %s: %v\n", source, result.Removed)
+	return result
+}
+
+// FlushCaches - метод полной очистки кэшей поиска, обратного индекса и деталей вакансий
+func (pm *ParsersManager) FlushCaches() models.CachePurgeResult {
+	result := newCachePurgeResult()
+
+	flushCache(&result, CacheNameSearch, pm.SearchCache)
+	flushCache(&result, CacheNameVacancyIndex, pm.VacancyIndex)
+	flushCache(&result, CacheNameVacancyDetails, pm.vacancyDetails)
+
+	fmt.Printf("🧹 Кэши очищены: %v\n", result.Removed)
+	return result
+}
+
+// функция создания пустого результата очистки кэшей
+func newCachePurgeResult() models.CachePurgeResult {
+	return models.CachePurgeResult{Removed: make(map[string]int)}
+}
+
+// функция удаления элементов кэша по условию с учётом в результате очистки
+func purgeCacheItems[V any](result *models.CachePurgeResult, name string, cache search_interfaces.CacheInterface[V], fn func(key string, value V) bool) {
+	purgeCache, ok := cache.(search_interfaces.PurgeCacheInterface[V])
+	if !ok {
+		result.Unsupported = append(result.Unsupported, name)
+		return
+	}
+	result.Removed[name] = purgeCache.DeleteFunc(fn)
+}
+
+// функция полной очистки кэша с учётом в результате очистки
+func flushCache[V any](result *models.CachePurgeResult, name string, cache search_interfaces.CacheInterface[V]) {
+	purgeCache, ok := cache.(search_interfaces.PurgeCacheInterface[V])
+	if !ok {
+		result.Unsupported = append(result.Unsupported, name)
+		return
+	}
+	result.Removed[name] = purgeCache.Clear()
+}
@@ -0,0 +1,125 @@
+package parsers_manager
+
+import (
+	"search_service/internal/domain/models"
+	"testing"
+)
+
+// функция заполнения кэшей менеджера результатами поиска двух источников и деталями вакансий
+func fillTestCaches(t *testing.T, pm *ParsersManager) (hhHash, sjHash string) {
+	t.Helper()
+
+	hhParams := models.SearchParams{Text: "golang", PerPage: 10}
+	sjParams := models.SearchParams{Text: "python", PerPage: 10}
+
+	pm.cacheSearchResults(hhParams, []models.SearchVacanciesResult{
+		{ParserName: "hh", Vacancies: []models.Vacancy{{ID: "1", Source: "hh"}, {ID: "2", Source: "hh"}}},
+	})
+	pm.cacheSearchResults(sjParams, []models.SearchVacanciesResult{
+		{ParserName: "sj", Vacancies: []models.Vacancy{{ID: "1", Source: "sj"}}},
+	})
+	pm.cacheDetailsResult("hh_1", models.SearchVacancyDetailesResult{ID: "1"})
+	pm.cacheDetailsResult("sj_1", models.SearchVacancyDetailesResult{ID: "1"})
+
+	hhHash, _ = pm.generateSearchHash(hhParams)
+	sjHash, _ = pm.generateSearchHash(sjParams)
+	return hhHash, sjHash
+}
+
+func TestPurgeSource(t *testing.T) {
+	pm := newTestQueueManager(t, &fakePagedParser{})
+	hhHash, sjHash := fillTestCaches(t, pm)
+
+	result := pm.PurgeSource("hh")
+
+	want := map[string]int{CacheNameSearch: 1, CacheNameVacancyIndex: 2, CacheNameVacancyDetails: 1}
+	for name, removed := range want {
+		if result.Removed[name] != removed {
+			t.Errorf("Из кэша %s удалено %d элементов, ожидалось %d", name, result.Removed[name], removed)
+		}
+	}
+	if len(result.Unsupported) != 0 {
+		t.Errorf("Инмэмори кэши должны поддерживать очистку: %v", result.Unsupported)
+	}
+
+	if _, ok := pm.SearchCache.Get(hhHash); ok {
+		t.Error("Результаты поиска hh должны быть удалены")
+	}
+	if _, ok := pm.SearchCache.Get(sjHash); !ok {
+		t.Error("Результаты поиска sj не должны удаляться")
+	}
+	if _, ok := pm.VacancyIndex.Get("sj_1"); !ok {
+		t.Error("Индекс вакансий sj не должен удаляться")
+	}
+	if _, ok := pm.vacancyDetails.Get("sj_1"); !ok {
+		t.Error("Детали вакансий sj не должны удаляться")
+	}
+}
+
+func TestPurgeSearchHashAndVacancy(t *testing.T) {
+	pm := newTestQueueManager(t, &fakePagedParser{})
+	hhHash, sjHash := fillTestCaches(t, pm)
+
+	result := pm.PurgeSearchHash(hhHash)
+	if result.Removed[CacheNameSearch] != 1 {
+		t.Errorf("Из кэша поиска должны удаляться результаты поиска, удалено: %d", result.Removed[CacheNameSearch])
+	}
+	if result.Removed[CacheNameVacancyIndex] != 2 {
+		t.Errorf("Из индекса должны удаляться записи поиска, удалено: %d", result.Removed[CacheNameVacancyIndex])
+	}
+	if _, ok := pm.SearchCache.Get(hhHash); ok {
+		t.Error("Результаты поиска должны быть удалены")
+	}
+	if _, ok := pm.SearchCache.Get(sjHash); !ok {
+		t.Error("Результаты другого поиска не должны удаляться")
+	}
+	if result = pm.PurgeSearchHash(hhHash); result.Removed[CacheNameSearch] != 0 {
+		t.Errorf("Повторное удаление поиска не должно ничего удалять, удалено: %d", result.Removed[CacheNameSearch])
+	}
+
+	result = pm.PurgeVacancy("sj", "1")
+	if result.Removed[CacheNameSearch] != 1 {
+		t.Errorf("Из кэша поиска должны удаляться поиски с вакансией, удалено: %d", result.Removed[CacheNameSearch])
+	}
+	if _, ok := pm.SearchCache.Get(sjHash); ok {
+		t.Error("Поиск, в котором есть вакансия, должен быть удалён")
+	}
+	if _, ok := pm.VacancyIndex.Get("sj_1"); ok {
+		t.Error("Вакансия должна быть удалена из индекса")
+	}
+	if _, ok := pm.vacancyDetails.Get("sj_1"); ok {
+		t.Error("Вакансия должна быть удалена из кэша деталей")
+	}
+	if _, ok := pm.vacancyDetails.Get("hh_1"); !ok {
+		t.Error("Вакансия с тем же ID из другого источника не должна удаляться")
+	}
+}
+
+func TestFlushCachesAndStats(t *testing.T) {
+	pm := newTestQueueManager(t, &fakePagedParser{})
+	hhHash, _ := fillTestCaches(t, pm)
+
+	pm.SearchCache.Get(hhHash)    // попадание
+	pm.SearchCache.Get("missing") // промах
+
+	stats := pm.CacheStats()
+	if len(stats) != 3 || stats[0].Name != CacheNameSearch {
+		t.Fatalf("Неверный список кэшей в статистике: %+v", stats)
+	}
+	if !stats[0].Available || stats[0].Entries != 2 || stats[0].Hits != 1 || stats[0].Misses != 1 || stats[0].HitRatio != 0.5 {
+		t.Errorf("Неверная статистика кэша поиска: %+v", stats[0])
+	}
+
+	result := pm.FlushCaches()
+	want := map[string]int{CacheNameSearch: 2, CacheNameVacancyIndex: 3, CacheNameVacancyDetails: 2}
+	for name, removed := range want {
+		if result.Removed[name] != removed {
+			t.Errorf("Из кэша %s удалено %d элементов, ожидалось %d", name, result.Removed[name], removed)
+		}
+	}
+	for _, s := range pm.CacheStats() {
+		if s.Entries != 0 {
+			t.Errorf("Кэш %s не очищен: %d элементов", s.Name, s.Entries)
+		}
+	}
+}
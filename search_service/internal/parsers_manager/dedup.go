@@ -0,0 +1,178 @@
+package parsers_manager
+
+import (
+	"sort"
+	"strings"
+
+	"search_service/internal/currency"
+	"search_service/internal/domain/models"
+	"search_service/pkg"
+)
+
+// допустимое расхождение зарплат (по середине вилки), при котором вакансии ещё считаются одной и той же
+const dedupSalaryTolerance = 0.15
+
+// организационно-правовые формы, которые не учитываются при сравнении названий компаний
+var companyLegalForms = map[string]struct{}{
+	"ооо": {}, "оао": {}, "зао": {}, "пао": {}, "ао": {}, "ип": {}, "нко": {}, "гк": {},
+	"llc": {}, "ltd": {}, "inc": {}, "gmbh": {}, "corp": {}, "co": {},
+}
+
+// кластер одинаковых вакансий: где лежит основная вакансия и из каких источников в нём уже есть вакансии
+type dedupCluster struct {
+	result  int // индекс результата парсера
+	vacancy int // индекс основной вакансии в результате парсера
+	salary  int // середина вилки зарплаты основной вакансии за месяц в базовой валюте (0 - не указана)
+	sources map[string]struct{}
+}
+
+// метод объединения одинаковых вакансий из разных источников
+// основной считается вакансия источника, который раньше зарегистрирован в парсер-менеджере,
+// чтобы результат не зависел от того, какой парсер ответил первым
+func (pm *ParsersManager) deduplicateResults(results []models.SearchVacanciesResult) []models.SearchVacanciesResult {
+	order := make(map[string]int, len(pm.parsers))
+	for i, name := range pm.getAllParsersNames() {
+		order[name] = i
+	}
+
+	sorted := make([]models.SearchVacanciesResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return parserOrder(order, sorted[i].ParserName) < parserOrder(order, sorted[j].ParserName)
+	})
+
+	return mergeDuplicateVacancies(sorted, pm.currency)
+}
+
+// функция получения порядкового номера парсера (неизвестные парсеры - в конец)
+func parserOrder(order map[string]int, name string) int {
+	if i, ok := order[name]; ok {
+		return i
+	}
+	return len(order)
+}
+
+// функция объединения дублей: вакансия, уже найденная в более приоритетном источнике, убирается из выдачи
+// своего источника, а ссылка на неё добавляется в список источников основной вакансии
+// внутри одного источника вакансии не объединяются - там одинаковые названия обычно означают разные вакансии
+// зарплаты сравниваются за месяц в базовой валюте конвертера
+func mergeDuplicateVacancies(results []models.SearchVacanciesResult, converter *currency.Converter) []models.SearchVacanciesResult {
+	merged := make([]models.SearchVacanciesResult, len(results))
+	clusters := make(map[string][]*dedupCluster)
+
+	for i, result := range results {
+		merged[i] = result
+		if result.Error != nil || len(result.Vacancies) == 0 {
+			continue
+		}
+
+		vacancies := make([]models.Vacancy, 0, len(result.Vacancies))
+		mergedCount := 0
+
+		for _, vacancy := range result.Vacancies {
+			key := dedupKey(vacancy)
+			if key == "" {
+				// без компании или названия сравнивать не с чем
+				vacancies = append(vacancies, vacancy)
+				continue
+			}
+
+			salary := converter.MonthlyInBase(vacancy.Salary)
+			if cluster := findDedupCluster(clusters[key], vacancy.Source, salary); cluster != nil {
+				primary := &merged[cluster.result].Vacancies[cluster.vacancy]
+				addVacancySource(primary, vacancy)
+				cluster.sources[vacancy.Source] = struct{}{}
+				mergedCount++
+				continue
+			}
+
+			clusters[key] = append(clusters[key], &dedupCluster{
+				result:  i,
+				vacancy: len(vacancies),
+				salary:  salary,
+				sources: map[string]struct{}{vacancy.Source: {}},
+			})
+			vacancies = append(vacancies, vacancy)
+		}
+
+		merged[i].Vacancies = vacancies
+		merged[i].Merged = mergedCount
+	}
+
+	return merged
+}
+
+// функция поиска кластера, в который можно добавить вакансию из заданного источника
+func findDedupCluster(clusters []*dedupCluster, source string, salary int) *dedupCluster {
+	for _, cluster := range clusters {
+		if _, ok := cluster.sources[source]; ok {
+			continue
+		}
+		if salaryClose(cluster.salary, salary) {
+			return cluster
+		}
+	}
+	return nil
+}
+
+// функция добавления ссылки на дубль в список источников основной вакансии
+func addVacancySource(primary *models.Vacancy, duplicate models.Vacancy) {
+	if len(primary.Sources) == 0 {
+		primary.Sources = []models.VacancySource{{Source: primary.Source, ID: primary.ID, URL: primary.URL}}
+	}
+	primary.Sources = append(primary.Sources, models.VacancySource{
+		Source: duplicate.Source,
+		ID:     duplicate.ID,
+		URL:    duplicate.URL,
+	})
+}
+
+// функция получения ключа для поиска дублей: нормализованные компания + название + город
+func dedupKey(vacancy models.Vacancy) string {
+	company := normalizeCompany(vacancy.Company)
+	title := normalizeDedupText(vacancy.Job)
+	if company == "" || title == "" {
+		return ""
+	}
+	return company + "|" + title + "|" + normalizeCity(vacancy.Location)
+}
+
+// функция нормализации текста: нижний регистр, ё -> е, без знаков препинания и лишних пробелов
+func normalizeDedupText(s string) string {
+	return strings.Join(pkg.NormalizedWords(s), " ")
+}
+
+// функция нормализации названия компании (без кавычек и организационно-правовой формы)
+func normalizeCompany(s string) string {
+	words := pkg.NormalizedWords(s)
+	filtered := words[:0]
+	for _, word := range words {
+		if _, ok := companyLegalForms[word]; ok {
+			continue
+		}
+		filtered = append(filtered, word)
+	}
+	return strings.Join(filtered, " ")
+}
+
+// функция нормализации города ("г. Москва" и "Москва" - один город)
+func normalizeCity(s string) string {
+	words := pkg.NormalizedWords(s)
+	if len(words) > 1 && (words[0] == "г" || words[0] == "город") {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// функция проверки близости зарплат; если у одной из вакансий зарплата не указана - сравнивать не с чем
+func salaryClose(a, b int) bool {
+	if a == 0 || b == 0 {
+		return true
+	}
+
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return float64(diff) <= dedupSalaryTolerance*float64(max(a, b))
+}
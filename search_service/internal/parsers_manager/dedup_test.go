@@ -0,0 +1,95 @@
+package parsers_manager
+
+import (
+	"errors"
+	"search_service/internal/domain/models"
+	"testing"
+)
+
+func TestDedupKey(t *testing.T) {
+	a := models.Vacancy{Company: `ООО "Рога и Копыта"`, Job: "Go-разработчик (Senior)", Location: "г. Москва"}
+	b := models.Vacancy{Company: "Рога и копыта", Job: "Go разработчик senior", Location: "Москва"}
+
+	if dedupKey(a) != dedupKey(b) {
+		t.Errorf("ключи должны совпадать: %q != %q", dedupKey(a), dedupKey(b))
+	}
+
+	// без компании вакансии не сравниваются
+	if key := dedupKey(models.Vacancy{Job: "Go разработчик"}); key != "" {
+		t.Errorf("ожидался пустой ключ, получено %q", key)
+	}
+
+	c := models.Vacancy{Company: "Рога и копыта", Job: "Go разработчик senior", Location: "Казань"}
+	if dedupKey(a) == dedupKey(c) {
+		t.Error("вакансии в разных городах не должны совпадать")
+	}
+}
+
+func TestSalaryClose(t *testing.T) {
+	tests := []struct {
+		a, b int
+		want bool
+	}{
+		{0, 200000, true},
+		{200000, 0, true},
+		{200000, 210000, true},
+		{200000, 300000, false},
+		{models.Salary{From: 150000, To: 250000}.Middle(), 200000, true},
+	}
+
+	for _, tt := range tests {
+		if got := salaryClose(tt.a, tt.b); got != tt.want {
+			t.Errorf("salaryClose(%d, %d) = %v, ожидалось %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMergeDuplicateVacancies(t *testing.T) {
+	results := []models.SearchVacanciesResult{
+		{
+			ParserName: "HH.ru",
+			Vacancies: []models.Vacancy{
+				{ID: "1", Source: "HH.ru", URL: "https://hh.ru/vacancy/1", Company: "Яндекс", Job: "Go разработчик", Location: "Москва", Salary: models.Salary{From: 200000, Currency: "RUB"}},
+				{ID: "2", Source: "HH.ru", Company: "Яндекс", Job: "Go разработчик", Location: "Москва", Salary: models.Salary{From: 400000, Currency: "RUB"}},
+			},
+		},
+		{
+			ParserName: "SuperJob",
+			Vacancies: []models.Vacancy{
+				{ID: "sj1", Source: "SuperJob", URL: "https://superjob.ru/sj1", Company: "ООО Яндекс", Job: "Go-разработчик", Location: "г. Москва", Salary: models.Salary{From: 210000, Currency: "RUB"}},
+				{ID: "sj2", Source: "SuperJob", Company: "Яндекс", Job: "Go разработчик", Location: "Москва", Salary: models.Salary{From: 400000, Currency: "RUB"}},
+				{ID: "sj3", Source: "SuperJob", Company: "Яндекс", Job: "Go разработчик", Location: "Москва", Salary: models.Salary{From: 210000, Currency: "RUB"}},
+				{ID: "sj4", Source: "SuperJob", Company: "Ozon", Job: "Python разработчик", Location: "Москва"},
+			},
+		},
+		{ParserName: "Habr Career", Error: errors.New("timeout")},
+	}
+
+	merged := mergeDuplicateVacancies(results, nil)
+
+	if len(merged) != 3 {
+		t.Fatalf("ожидалось 3 результата, получено %d", len(merged))
+	}
+
+	hh := merged[0]
+	if len(hh.Vacancies) != 2 || hh.Merged != 0 {
+		t.Fatalf("HH.ru: ожидалось 2 вакансии без объединений, получено %d (merged %d)", len(hh.Vacancies), hh.Merged)
+	}
+	if !hh.Vacancies[0].HasSourceID("SuperJob", "sj1") || !hh.Vacancies[1].HasSourceID("SuperJob", "sj2") {
+		t.Errorf("дубли SuperJob должны быть привязаны к вакансиям HH.ru: %+v", hh.Vacancies)
+	}
+	if len(hh.Vacancies[0].Sources) != 2 || hh.Vacancies[0].Sources[0].ID != "1" {
+		t.Errorf("первым источником должна идти сама вакансия: %+v", hh.Vacancies[0].Sources)
+	}
+
+	// sj3 - второй дубль той же вакансии из того же источника, его не с чем объединить
+	sj := merged[1]
+	if sj.Merged != 2 || len(sj.Vacancies) != 2 || sj.Vacancies[0].ID != "sj3" || sj.Vacancies[1].ID != "sj4" {
+		t.Errorf("SuperJob: ожидались вакансии sj3 и sj4 и 2 объединения, получено %+v (merged %d)", sj.Vacancies, sj.Merged)
+	}
+
+	// исходные результаты не должны меняться
+	if len(results[1].Vacancies) != 4 || results[0].Vacancies[0].Sources != nil {
+		t.Error("исходные результаты изменены")
+	}
+}
@@ -21,7 +21,13 @@ func (pm *ParsersManager) printMultiSearchResults(results []models.SearchVacanci
 			continue
 		}
 
-		fmt.Printf("   ✅ Найдено: %d вакансий\n", len(result.Vacancies))
+		fmt.Printf("   ✅ Получено: %d вакансий (страниц: %d)\n", len(result.Vacancies), result.PagesFetched)
+		if result.Merged > 0 {
+			fmt.Printf("   🔗 Объединено с другими источниками: %d вакансий\n", result.Merged)
+		}
+		if result.Found > 0 {
+			fmt.Printf("   🔎 Всего у источника: %d вакансий на %d стр.\n", result.Found, result.Pages)
+		}
 		totalVacancies += len(result.Vacancies)
 
 		// Показываем столько результатов, сколько ввели, или если их меньше --- столько, сколько нашли
@@ -29,7 +35,7 @@ func (pm *ParsersManager) printMultiSearchResults(results []models.SearchVacanci
 			if i >= resultsPerPage {
 				break
 			}
-			fmt.Printf("      %d. %s - %s, company:%s, URL:[ %s ], ID:%s\n", i+1, vacancy.Job, *vacancy.Salary, vacancy.Company, vacancy.URL, vacancy.ID)
+			fmt.Printf("      %d. %s - %s, company:%s, URL:[ %s ], ID:%s\n", i+1, vacancy.Job, salaryForConsole(vacancy.Salary), vacancy.Company, vacancy.URL, vacancy.ID)
 		}
 
 		if len(result.Vacancies) > resultsPerPage {
@@ -40,8 +46,20 @@ func (pm *ParsersManager) printMultiSearchResults(results []models.SearchVacanci
 	fmt.Printf("\n🎯 Всего найдено: %d вакансий\n", totalVacancies)
 }
 
+// функция краткого вывода зарплаты в консоль (форматирование для клиентов - в конвертерах DTO)
+func salaryForConsole(salary models.Salary) string {
+	if !salary.Specified() {
+		return "не указана"
+	}
+	return fmt.Sprintf("%d-%d %s/%s", salary.From, salary.To, salary.Currency, salary.Period)
+}
+
 // метод для построения обратного индекса и хранения его в кэше №2 для индексов и ID вакансий
+// индекс должен жить не меньше результатов поиска (вместе с устаревшими), иначе вакансии из устаревшего поиска не найти
 func (pm *ParsersManager) buildReverseIndex(searchHash string, results []models.SearchVacanciesResult) {
+	searchCacheConfig := pm.config.Cache.SearchCacheConfig
+	indexTTL := max(pm.config.Cache.VacancyCacheConfig.VacancyCacheTTL, searchCacheConfig.SearchCacheTTL)
+
 	for _, parserResult := range results {
 		for i, vacancy := range parserResult.Vacancies {
 			compositeID := fmt.Sprintf("%s_%s", vacancy.Source, vacancy.ID)
@@ -52,8 +70,17 @@ func (pm *ParsersManager) buildReverseIndex(searchHash string, results []models.
 				Index:      i,
 			}
 
-			// Сохраняем в индексный кэш (ТОТ ЖЕ ТИП!), TTL такой же как для кэша поиска
-			pm.VacancyIndex.AddItemWithTTL(compositeID, indexEntry, pm.config.Cache.VacancyCacheConfig.VacancyCacheTTL)
+			// Сохраняем в индексный кэш (ТОТ ЖЕ ТИП!), устаревшие записи хранятся так же долго, как в кэше поиска
+			setWithStale(pm.VacancyIndex, compositeID, indexEntry, indexTTL, searchCacheConfig.SearchCacheStale)
+
+			// объединённые дубли из других источников ведут на ту же основную вакансию
+			for _, source := range vacancy.Sources {
+				if source.Source == vacancy.Source && source.ID == vacancy.ID {
+					continue
+				}
+				aliasID := fmt.Sprintf("%s_%s", source.Source, source.ID)
+				setWithStale(pm.VacancyIndex, aliasID, indexEntry, indexTTL, searchCacheConfig.SearchCacheStale)
+			}
 		}
 	}
 }
@@ -62,16 +89,38 @@ func (pm *ParsersManager) buildReverseIndex(searchHash string, results []models.
 func genHashFromSearchParam(params models.SearchParams) (string, error) {
 	// Учитываем ВСЕ параметры, которые влияют на результат
 	keyData := struct {
-		Text    string `json:"text"`
-		Area    string `json:"area"`
-		PerPage int    `json:"per_page"`
-		Page    int    `json:"page"`
+		Text     string `json:"text"`
+		Area     string `json:"area"`
+		PerPage  int    `json:"per_page"`
+		Page     int    `json:"page"`
+		MaxPages int    `json:"max_pages"`
+
+		SalaryFrom          int    `json:"salary_from"`
+		OnlyWithSalary      bool   `json:"only_with_salary"`
+		Experience          string `json:"experience"`
+		Schedule            string `json:"schedule"`
+		Employment          string `json:"employment"`
+		PublishedWithinDays int    `json:"published_within_days"`
+		OrderBy             string `json:"order_by"`
+
+		DisableDedup bool `json:"disable_dedup"`
 		// Добавьте другие поля из SearchParams
 	}{
-		Text:    params.Text,
-		Area:    params.Country,
-		PerPage: params.PerPage,
-		Page:    params.Page,
+		Text:     params.Text,
+		Area:     params.Country,
+		PerPage:  params.PerPage,
+		Page:     params.Page,
+		MaxPages: params.MaxPages,
+
+		SalaryFrom:          params.SalaryFrom,
+		OnlyWithSalary:      params.OnlyWithSalary,
+		Experience:          params.Experience,
+		Schedule:            params.Schedule,
+		Employment:          params.Employment,
+		PublishedWithinDays: params.PublishedWithinDays,
+		OrderBy:             params.OrderBy,
+
+		DisableDedup: params.DisableDedup,
 	}
 
 	data, err := json.Marshal(keyData)
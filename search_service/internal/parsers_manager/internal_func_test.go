@@ -0,0 +1,42 @@
+package parsers_manager
+
+import (
+	"search_service/internal/domain/models"
+	"testing"
+)
+
+func TestGenHashFromSearchParam(t *testing.T) {
+	base := models.SearchParams{Text: "golang", PerPage: 20, Page: 1}
+
+	baseHash, err := genHashFromSearchParam(base)
+	if err != nil {
+		t.Fatalf("ошибка генерации хэша: %v", err)
+	}
+
+	sameHash, _ := genHashFromSearchParam(base)
+	if baseHash != sameHash {
+		t.Error("одинаковые параметры должны давать одинаковый хэш")
+	}
+
+	// каждый фильтр влияет на выдачу, поэтому должен менять ключ кэша
+	variants := map[string]models.SearchParams{
+		"max_pages":             {Text: "golang", PerPage: 20, Page: 1, MaxPages: 2},
+		"salary_from":           {Text: "golang", PerPage: 20, Page: 1, SalaryFrom: 100000},
+		"only_with_salary":      {Text: "golang", PerPage: 20, Page: 1, OnlyWithSalary: true},
+		"experience":            {Text: "golang", PerPage: 20, Page: 1, Experience: models.ExperienceNone},
+		"schedule":              {Text: "golang", PerPage: 20, Page: 1, Schedule: models.ScheduleRemote},
+		"employment":            {Text: "golang", PerPage: 20, Page: 1, Employment: models.EmploymentPart},
+		"published_within_days": {Text: "golang", PerPage: 20, Page: 1, PublishedWithinDays: 3},
+		"order_by":              {Text: "golang", PerPage: 20, Page: 1, OrderBy: models.OrderDate},
+	}
+
+	for name, params := range variants {
+		hash, err := genHashFromSearchParam(params)
+		if err != nil {
+			t.Fatalf("%s: ошибка генерации хэша: %v", name, err)
+		}
+		if hash == baseHash {
+			t.Errorf("%s: фильтр не учитывается в ключе кэша", name)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package parsers_manager
+
+import (
+	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+)
+
+// LoadStats - метод получения текущей загрузки менеджера парсеров: очередь, воркеры, семафоры и состояния circuit breaker
+// парсеры, которые не сообщают свою загрузку, пропускаются
+func (pm *ParsersManager) LoadStats() models.LoadStats {
+	stats := models.LoadStats{
+		QueueDepth:     pm.jobSearchQueue.Size(),
+		QueueCapacity:  pm.queueCapacity,
+		Workers:        pm.workers,
+		BusyWorkers:    int(pm.busyWorkers.Load()),
+		SemaphoreInUse: len(pm.semaphore),
+		SemaphoreSize:  cap(pm.semaphore),
+		CircuitBreaker: pm.circuitBreaker.State().String(),
+	}
+
+	for _, p := range pm.parsers {
+		if loadParser, ok := p.(search_interfaces.LoadStatsParser); ok {
+			stats.Parsers = append(stats.Parsers, loadParser.LoadStats())
+		}
+	}
+
+	return stats
+}
@@ -11,7 +11,8 @@ import (
 )
 
 // concurrentSearchWithTimeout выполняет поиск во всех парсерах одновременно с таймаутом
-func (pm *ParsersManager) concurrentSearchWithTimeout(ctx context.Context, params models.SearchParams, parsers []string) ([]models.SearchVacanciesResult, error) {
+// onResult (необязательный) вызывается для результата каждого парсера сразу, как только он готов (для потокового поиска)
+func (pm *ParsersManager) concurrentSearchWithTimeout(ctx context.Context, params models.SearchParams, parsers []string, onResult resultCallback) ([]models.SearchVacanciesResult, error) {
 
 	var wg sync.WaitGroup
 	// создаём переменную для результатов
@@ -37,7 +38,7 @@ func (pm *ParsersManager) concurrentSearchWithTimeout(ctx context.Context, param
 
 			go func() {
 				start := time.Now()
-				vacancies, err := p.SearchVacancies(ctx, params)
+				page, pagesFetched, err := pm.searchParserPages(ctx, p, params)
 				duration := time.Since(start)
 
 				// обновляем статус парсера, в зависимости от результата поиска
@@ -48,11 +49,15 @@ func (pm *ParsersManager) concurrentSearchWithTimeout(ctx context.Context, param
 				}
 
 				resultChan <- models.SearchVacanciesResult{
-					ParserName: p.GetName(),
-					Vacancies:  vacancies,
-					SearchHash: searchHash,
-					Error:      err,
-					Duration:   duration,
+					ParserName:   p.GetName(),
+					Vacancies:    page.Vacancies,
+					SearchHash:   searchHash,
+					Error:        err,
+					Duration:     duration,
+					Found:        page.Found,
+					Pages:        page.Pages,
+					Page:         firstPage(params.Page),
+					PagesFetched: pagesFetched,
 				}
 			}()
 
@@ -79,6 +84,9 @@ func (pm *ParsersManager) concurrentSearchWithTimeout(ctx context.Context, param
 	var searchResults []models.SearchVacanciesResult
 
 	for result := range results {
+		if onResult != nil {
+			onResult(result)
+		}
 		searchResults = append(searchResults, result)
 	}
 
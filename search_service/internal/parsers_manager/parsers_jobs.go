@@ -4,63 +4,81 @@ package parsers_manager
 import (
 	"context"
 	"fmt"
+	"search_service/configs"
 	"search_service/internal/domain/models"
 	"search_service/internal/jobs"
 	"search_service/internal/search_interfaces"
 	"search_service/pkg"
+	"shared/queue"
 	"time"
 )
 
-// newSearchJob - создает джобу для поиска вакансий
-func (pm *ParsersManager) newSearchJob(params models.SearchParams) *jobs.SearchJob {
+// newSearchJob - создает джобу для поиска вакансий (пользователь и его приоритет в очереди - из контекста запроса)
+func (pm *ParsersManager) newSearchJob(ctx context.Context, params models.SearchParams) *jobs.SearchJob {
 	return &jobs.SearchJob{
-		BaseJob: jobs.BaseJob{
-			ID:         pkg.QuickUUID(),
-			ResultChan: make(chan *jobs.JobOutput, 1), // обязательно - буферизированный канал
-			CreatedAt:  time.Now(),
-		},
-		Params: params,
+		BaseJob: pm.newBaseJob(ctx),
+		Params:  params,
 	}
 }
 
-// NewFetchVacancyJob - создает джобу для получения деталей вакансии
-func (pm *ParsersManager) NewFetchVacancyJob(source, vacancyID string) *jobs.FetchDetailsJob {
+// NewFetchVacancyJob - создает джобу для получения деталей вакансии (пользователь и его приоритет в очереди - из контекста запроса)
+func (pm *ParsersManager) NewFetchVacancyJob(ctx context.Context, source, vacancyID string) *jobs.FetchDetailsJob {
 	return &jobs.FetchDetailsJob{
-		BaseJob: jobs.BaseJob{
-			ID:         pkg.QuickUUID(),
-			ResultChan: make(chan *jobs.JobOutput, 1), // обязательно - буферизированный канал
-			CreatedAt:  time.Now(),
-		},
+		BaseJob:   pm.newBaseJob(ctx),
 		Source:    source,
 		VacancyID: vacancyID,
 	}
 }
 
-// метод для добавления джобы в очередь, с возможностью повторных попыток в течение таймаута
-func (pm *ParsersManager) tryEnqueueJob(ctx context.Context, job search_interfaces.Job, timeout time.Duration) bool {
+// метод создания общей части джобы
+func (pm *ParsersManager) newBaseJob(ctx context.Context) jobs.BaseJob {
+	requester := models.RequesterFromContext(ctx)
 
-	start := time.Now()
+	return jobs.BaseJob{
+		ID:         pkg.QuickUUID(),
+		ResultChan: make(chan *jobs.JobOutput, 1), // обязательно - буферизированный канал
+		CreatedAt:  time.Now(),
+		UserID:     requester.UserID,
+		Priority:   pm.requesterPriority(requester),
+	}
+}
 
-	for {
-		// Пробуем добавить в очередь
-		if pm.jobSearchQueue.Enqueue(job) {
-			return true
+// метод определения приоритета джоб пользователя по его ролям (наибольший из приоритетов ролей, без ролей - 0)
+func (pm *ParsersManager) requesterPriority(requester models.Requester) int {
+	priority := 0
+	for role, rolePriority := range pm.config.Manager.Queue.RolePriorities {
+		if rolePriority > priority && requester.HasRole(role) {
+			priority = rolePriority
 		}
+	}
+	return priority
+}
 
-		// Проверяем таймаут
-		if time.Since(start) > timeout {
-			return false
-		}
+// функция создания очереди джоб по конфигу: обычная FIFO или честная (приоритеты и обслуживание пользователей по кругу)
+func newJobQueue(cfg configs.QueueConfig, capacity int) search_interfaces.FIFOQueueInterface[search_interfaces.Job] {
+	if cfg.Scheduler == configs.QueueSchedulerFIFO {
+		return queue.NewFIFOQueue[search_interfaces.Job](capacity)
+	}
 
-		// Проверяем отмену контекста
-		select {
-		case <-ctx.Done():
-			return false
-		default:
-			// Небольшая пауза перед следующей попыткой
-			time.Sleep(50 * time.Millisecond)
-		}
+	return queue.NewFairQueue(queue.FairQueueConfig{
+		Capacity:        capacity,
+		MaxPerOwner:     cfg.MaxPerUser,
+		StarvationLimit: cfg.StarvationLimit,
+	}, func(job search_interfaces.Job) queue.ItemClass {
+		return queue.ItemClass{Owner: job.GetUserID(), Priority: job.GetPriority()}
+	})
+}
+
+// метод для добавления джобы в очередь: ждём свободного места не дольше таймаута (или до отмены контекста)
+func (pm *ParsersManager) tryEnqueueJob(ctx context.Context, job search_interfaces.Job, timeout time.Duration) bool {
+	enqueueCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := pm.jobSearchQueue.EnqueueContext(enqueueCtx, job); err != nil {
+		fmt.Printf("⚠️  Джоба %s не добавлена в очередь: %v\n", job.GetID(), err)
+		return false
 	}
+	return true
 }
 
 // метод - обёртка, чтобы внутри вызвать функцию-джерерик для нужного типа (тип: список вакансий)
@@ -0,0 +1,61 @@
+package parsers_manager
+
+import (
+	"context"
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+	"testing"
+)
+
+func TestNewSearchJob_RequesterPriority(t *testing.T) {
+	pm := &ParsersManager{config: &configs.SearchServiceConfig{Manager: configs.DefaultParsersManagerConfig()}}
+
+	tests := []struct {
+		name      string
+		requester models.Requester
+		want      int
+	}{
+		{name: "без ролей", requester: models.Requester{UserID: "1"}, want: 0},
+		{name: "обычный пользователь", requester: models.Requester{UserID: "2", Roles: []string{"user"}}, want: 0},
+		{name: "премиум", requester: models.Requester{UserID: "3", Roles: models.ParseRoles("user, Premium")}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := pm.newSearchJob(models.WithRequester(context.Background(), tt.requester), models.SearchParams{})
+			if job.UserID != tt.requester.UserID {
+				t.Errorf("ожидался пользователь %q, получен %q", tt.requester.UserID, job.UserID)
+			}
+			if job.Priority != tt.want {
+				t.Errorf("ожидался приоритет %d, получен %d", tt.want, job.Priority)
+			}
+		})
+	}
+}
+
+func TestNewJobQueue_Fair(t *testing.T) {
+	pm := &ParsersManager{config: &configs.SearchServiceConfig{Manager: configs.DefaultParsersManagerConfig()}}
+	q := newJobQueue(pm.config.Manager.Queue, 10)
+
+	newJob := func(userID string, roles ...string) search_interfaces.Job {
+		ctx := models.WithRequester(context.Background(), models.Requester{UserID: userID, Roles: roles})
+		return pm.newSearchJob(ctx, models.SearchParams{})
+	}
+
+	heavy1, heavy2 := newJob("heavy"), newJob("heavy")
+	light := newJob("light")
+	premium := newJob("vip", "premium")
+	for _, job := range []search_interfaces.Job{heavy1, heavy2, light, premium} {
+		if !q.Enqueue(job) {
+			t.Fatalf("джоба %s не добавлена в очередь", job.GetID())
+		}
+	}
+
+	for i, want := range []search_interfaces.Job{premium, heavy1, light, heavy2} {
+		got, ok := q.Dequeue()
+		if !ok || got.GetID() != want.GetID() {
+			t.Fatalf("позиция %d: ожидалась джоба пользователя %s, получена %v", i, want.GetUserID(), got)
+		}
+	}
+}
@@ -2,35 +2,53 @@
 package parsers_manager
 
 import (
+	"context"
 	"errors"
 	"math"
 	"search_service/configs"
+	"search_service/internal/currency"
+	"search_service/internal/domain/models"
+	"search_service/internal/metrics"
 	"search_service/internal/search_interfaces"
 	"shared/circuitbreaker"
-	"shared/queue"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // структура менеджера парсеров
 type ParsersManager struct {
-	parsers              []search_interfaces.Parser             // парсеры, которыми оперирует мэнеджер
-	config               *configs.SearchServiceConfig           // общий конфиг
-	SearchCache          search_interfaces.CacheInterface       // поисковый кэш
-	VacancyIndex         search_interfaces.CacheInterface       // кэш для обратного индекса
-	vacancyDetails       search_interfaces.CacheInterface       // кэш для деталей вакансии
-	parsersStatusManager search_interfaces.ParsersStatusManager // менеджер сотсояний парверов внутри менеджера
-	circuitBreaker       search_interfaces.CBInterface          // глобальный circut breaker (используем интерфейс)
+	parsers              []search_interfaces.Parser                                           // парсеры, которыми оперирует мэнеджер
+	config               *configs.SearchServiceConfig                                         // общий конфиг
+	SearchCache          search_interfaces.CacheInterface[[]models.SearchVacanciesResult]     // поисковый кэш
+	VacancyIndex         search_interfaces.CacheInterface[models.VacancyIndex]                // кэш для обратного индекса
+	vacancyDetails       search_interfaces.CacheInterface[models.SearchVacancyDetailesResult] // кэш для деталей вакансии
+	searchJobs           search_interfaces.CacheInterface[models.SearchJobInfo]               // кэш завершённых асинхронных поисков (состояние и результаты)
+	parsersStatusManager search_interfaces.ParsersStatusManager                               // менеджер сотсояний парверов внутри менеджера
+	circuitBreaker       search_interfaces.CBInterface                                        // глобальный circut breaker (используем интерфейс)
+	currency             *currency.Converter                                                  // конвертер валют для сравнения зарплат разных источников
 
 	// Поля для управления нагрузкой --------------------------------------------------------------------------
 	semaphore          chan struct{}                                               // Семафор для ограничения одновременных запросов
 	jobSearchQueue     search_interfaces.FIFOQueueInterface[search_interfaces.Job] // Очередь заданий (в качестве типа используем интерфейс с дженеником)
+	queueCapacity      int                                                         // ёмкость очереди заданий
 	workers            int                                                         // Количество воркеров
-	stopWorkers        chan struct{}                                               // Сигнал остановки воркеров (когда захотим завершить все воркеры - зкрываем канал)
+	busyWorkers        atomic.Int32                                                // количество воркеров, которые сейчас обрабатывают джобу
+	workersCtx         context.Context                                             // контекст воркеров: воркеры ждут джобы из очереди, пока он не отменён
+	stopWorkers        context.CancelFunc                                          // Сигнал остановки воркеров (когда захотим завершить все воркеры - отменяем контекст)
 	semaSlotGetTimeout time.Duration                                               // таймаут ожидания свободного слота глобального семафора менеджера парсеров
 	wg                 sync.WaitGroup                                              // Для graceful shutdown
 	mu                 sync.RWMutex                                                // Для потокобезопасности
 	// --------------------------------------------------------------------------------------------------------
+
+	activeSearchJobs map[string]*asyncSearch // асинхронные поиски, которые ещё не завершены (по ID джобы)
+	searchJobsMu     sync.Mutex              // защищает activeSearchJobs и состояние поисков
+
+	searchFlights   map[string]*searchFlight // поиски по источникам, которые выполняются сейчас (по хэшу поиска)
+	searchFlightsMu sync.Mutex               // защищает searchFlights и состояние поисков по источникам
+
+	cacheRefreshes   map[string]struct{} // устаревшие результаты, обновление которых уже запланировано (по хэшу поиска)
+	cacheRefreshesMu sync.Mutex          // защищает cacheRefreshes
 }
 
 // структура параметров для системы управления нагрузкой менеджера парсеров
@@ -58,9 +76,10 @@ func NewPMLoad(numCPUCores int) *PMLoad {
 // Конструктор для мэнеджера парсинга из разных источников
 func NewParserManager(config *configs.SearchServiceConfig,
 	numCPUCores int,
-	searchCache search_interfaces.CacheInterface,
-	vacancyIndex search_interfaces.CacheInterface,
-	vacancyDetails search_interfaces.CacheInterface,
+	searchCache search_interfaces.CacheInterface[[]models.SearchVacanciesResult],
+	vacancyIndex search_interfaces.CacheInterface[models.VacancyIndex],
+	vacancyDetails search_interfaces.CacheInterface[models.SearchVacancyDetailesResult],
+	searchJobs search_interfaces.CacheInterface[models.SearchJobInfo],
 	pStatManager search_interfaces.ParsersStatusManager,
 	parsers ...search_interfaces.Parser) (*ParsersManager, error) {
 
@@ -74,23 +93,43 @@ func NewParserManager(config *configs.SearchServiceConfig,
 	if len(parsers) == 0 {
 		return nil, errors.New("нужен хотя бы один парсер")
 	}
-	if searchCache == nil || vacancyIndex == nil || vacancyDetails == nil {
+	if searchCache == nil || vacancyIndex == nil || vacancyDetails == nil || searchJobs == nil {
 		return nil, errors.New("кэши обязательны")
 	}
 
+	// конвертер валют по курсам из конфига
+	currencyConverter, err := currency.NewConverter(config.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// контекст воркеров, его отмена останавливает всех воркеров
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
+	// глобальный circuit breaker, смены его состояний учитываем в метриках
+	circuitBreaker := circuitbreaker.NewCircutBreaker(config.Manager.CircuitBreakerCfg)
+	circuitBreaker.OnStateChange(metrics.CircuitBreakerTransitions(metrics.BreakerManager))
+
 	pm := &ParsersManager{
 		parsers:              parsers,
 		config:               config,
 		SearchCache:          searchCache,    // кэш для поиска
 		VacancyIndex:         vacancyIndex,   // кэш для обратного индекса
 		vacancyDetails:       vacancyDetails, // кэш для деталей отдельной вакансии
+		searchJobs:           searchJobs,     // кэш завершённых асинхронных поисков
 		parsersStatusManager: pStatManager,
-		circuitBreaker:       circuitbreaker.NewCircutBreaker(config.Manager.CircuitBreakerCfg),
+		circuitBreaker:       circuitBreaker,
+		currency:             currencyConverter,
 		workers:              pmLoad.numOfWorkers,
 		semaphore:            make(chan struct{}, pmLoad.semaphoreSize),
-		jobSearchQueue:       queue.NewFIFOQueue[search_interfaces.Job](pmLoad.queueSize), // создаём очередь через конструктор
-		stopWorkers:          make(chan struct{}),
+		jobSearchQueue:       newJobQueue(config.Manager.Queue, pmLoad.queueSize), // создаём очередь (FIFO или честную) по конфигу
+		queueCapacity:        pmLoad.queueSize,
+		workersCtx:           workersCtx,
+		stopWorkers:          stopWorkers,
 		semaSlotGetTimeout:   pmLoad.semSlotTimeout,
+		activeSearchJobs:     make(map[string]*asyncSearch),
+		searchFlights:        make(map[string]*searchFlight),
+		cacheRefreshes:       make(map[string]struct{}),
 		// wg и mu автоматически инициализируются нулевыми значениями
 	}
 
@@ -100,6 +139,23 @@ func NewParserManager(config *configs.SearchServiceConfig,
 	return pm, nil
 }
 
+// CurrencyConverter возвращает конвертер валют, по которому сравниваются зарплаты
+func (pm *ParsersManager) CurrencyConverter() *currency.Converter {
+	return pm.currency
+}
+
+// GetSources возвращает описание доступных источников в порядке регистрации парсеров
+func (pm *ParsersManager) GetSources() []models.SourceInfo {
+	sources := make([]models.SourceInfo, len(pm.parsers))
+	for i, parser := range pm.parsers {
+		sources[i] = models.SourceInfo{Name: parser.GetName()}
+		if aware, ok := parser.(search_interfaces.LocationAwareParser); ok {
+			sources[i].LocationCatalog = aware.LocationCatalog()
+		}
+	}
+	return sources
+}
+
 // GetAllParsers возвращает список доступных парсеров
 func (pm *ParsersManager) GetParserNames() []string {
 	names := make([]string, len(pm.parsers))
@@ -0,0 +1,87 @@
+// этот раздел отвечает за поиск нескольких страниц подряд у одного источника (параметр MaxPages)
+package parsers_manager
+
+import (
+	"context"
+	"log"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+)
+
+// метод поиска у одного парсера с учётом params.MaxPages: страницы запрашиваются последовательно, начиная с params.Page
+// каждый запрос проходит через rate limiter и circuit breaker самого парсера, поэтому лимиты источника соблюдаются
+// возвращает объединённую страницу и количество реально полученных страниц
+func (pm *ParsersManager) searchParserPages(ctx context.Context, p search_interfaces.Parser, params models.SearchParams) (models.SearchPage, int, error) {
+	maxPages := pm.maxPagesPerSource(params.MaxPages)
+	startPage := firstPage(params.Page)
+
+	var result models.SearchPage
+	pagesFetched := 0
+	seen := make(map[string]struct{}) // выдача источника может сдвинуться между запросами - убираем повторы
+
+	for i := 0; i < maxPages; i++ {
+		pageParams := params
+		pageParams.Page = startPage + i
+
+		page, err := searchParserPage(ctx, p, pageParams)
+		if err != nil {
+			if pagesFetched == 0 {
+				return models.SearchPage{}, 0, err
+			}
+			// часть страниц уже получена - отдаём то, что есть
+			log.Printf("⚠️  %s: страница %d не получена, возвращаем %d стр.: %v\n", p.GetName(), pageParams.Page, pagesFetched, err)
+			break
+		}
+
+		pagesFetched++
+		result.Found, result.Pages = page.Found, page.Pages
+		for _, vacancy := range page.Vacancies {
+			if _, ok := seen[vacancy.ID]; ok {
+				continue
+			}
+			seen[vacancy.ID] = struct{}{}
+			result.Vacancies = append(result.Vacancies, vacancy)
+		}
+
+		// дальше страниц у источника нет
+		if len(page.Vacancies) == 0 || (page.Pages > 0 && pageParams.Page >= page.Pages) {
+			break
+		}
+	}
+
+	return result, pagesFetched, nil
+}
+
+// функция поиска одной страницы: если парсер умеет отдавать общее количество найденного - используем это
+func searchParserPage(ctx context.Context, p search_interfaces.Parser, params models.SearchParams) (models.SearchPage, error) {
+	if paged, ok := p.(search_interfaces.PagedParser); ok {
+		return paged.SearchVacanciesPage(ctx, params)
+	}
+
+	vacancies, err := p.SearchVacancies(ctx, params)
+	return models.SearchPage{Vacancies: vacancies}, err
+}
+
+// метод вычисления количества страниц для запроса у одного источника с учётом ограничения из конфига
+func (pm *ParsersManager) maxPagesPerSource(requested int) int {
+	if requested < 1 {
+		requested = 1
+	}
+
+	if pm.config == nil || pm.config.Manager == nil {
+		return requested
+	}
+
+	if limit := pm.config.Manager.MaxPagesPerSource; limit > 0 && requested > limit {
+		return limit
+	}
+	return requested
+}
+
+// функция нормализации номера первой страницы (нумерация страниц с 1)
+func firstPage(page int) int {
+	if page < 1 {
+		return 1
+	}
+	return page
+}
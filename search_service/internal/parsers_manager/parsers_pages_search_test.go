@@ -0,0 +1,141 @@
+package parsers_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"search_service/configs"
+	"search_service/internal/domain/models"
+	"testing"
+)
+
+// тестовый парсер, который отдаёт заранее заданное количество страниц по perPage вакансий
+type fakePagedParser struct {
+	found     int
+	perPage   int
+	failPage  int   // номер страницы, на которой парсер вернёт ошибку (0 - без ошибок)
+	requested []int // какие страницы запрашивались
+}
+
+func (f *fakePagedParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	f.requested = append(f.requested, params.Page)
+	if params.Page == f.failPage {
+		return models.SearchPage{}, errors.New("source unavailable")
+	}
+
+	pages := (f.found + f.perPage - 1) / f.perPage
+	page := models.SearchPage{Found: f.found, Pages: pages}
+
+	for i := (params.Page - 1) * f.perPage; i < params.Page*f.perPage && i < f.found; i++ {
+		page.Vacancies = append(page.Vacancies, models.Vacancy{ID: fmt.Sprint(i)})
+	}
+	return page, nil
+}
+
+func (f *fakePagedParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
+	page, err := f.SearchVacanciesPage(ctx, params)
+	return page.Vacancies, err
+}
+
+func (f *fakePagedParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string) (models.SearchVacancyDetailesResult, error) {
+	return models.SearchVacancyDetailesResult{}, nil
+}
+
+func (f *fakePagedParser) GetName() string           { return "fake" }
+func (f *fakePagedParser) GetHealthEndPoint() string { return "" }
+
+// функция создания менеджера только с конфигом (для методов, которым не нужны воркеры и кэши)
+func newTestManager(maxPagesPerSource int) *ParsersManager {
+	return &ParsersManager{
+		config: &configs.SearchServiceConfig{
+			Manager: &configs.ParserManagerConfig{MaxPagesPerSource: maxPagesPerSource},
+		},
+	}
+}
+
+func TestSearchParserPages(t *testing.T) {
+	tests := []struct {
+		name         string
+		parser       *fakePagedParser
+		params       models.SearchParams
+		limit        int
+		wantIDs      int
+		wantFetched  int
+		wantRequests []int
+		wantErr      bool
+	}{
+		{
+			name:         "одна страница по умолчанию",
+			parser:       &fakePagedParser{found: 25, perPage: 10},
+			params:       models.SearchParams{Page: 1},
+			limit:        5,
+			wantIDs:      10,
+			wantFetched:  1,
+			wantRequests: []int{1},
+		},
+		{
+			name:         "несколько страниц подряд",
+			parser:       &fakePagedParser{found: 50, perPage: 10},
+			params:       models.SearchParams{Page: 2, MaxPages: 3},
+			limit:        5,
+			wantIDs:      30,
+			wantFetched:  3,
+			wantRequests: []int{2, 3, 4},
+		},
+		{
+			name:         "останавливаемся на последней странице источника",
+			parser:       &fakePagedParser{found: 25, perPage: 10},
+			params:       models.SearchParams{Page: 1, MaxPages: 5},
+			limit:        5,
+			wantIDs:      25,
+			wantFetched:  3,
+			wantRequests: []int{1, 2, 3},
+		},
+		{
+			name:         "ограничение из конфига",
+			parser:       &fakePagedParser{found: 100, perPage: 10},
+			params:       models.SearchParams{Page: 1, MaxPages: 10},
+			limit:        2,
+			wantIDs:      20,
+			wantFetched:  2,
+			wantRequests: []int{1, 2},
+		},
+		{
+			name:         "ошибка на второй странице - отдаём первую",
+			parser:       &fakePagedParser{found: 50, perPage: 10, failPage: 2},
+			params:       models.SearchParams{Page: 1, MaxPages: 3},
+			limit:        5,
+			wantIDs:      10,
+			wantFetched:  1,
+			wantRequests: []int{1, 2},
+		},
+		{
+			name:         "ошибка на первой странице",
+			parser:       &fakePagedParser{found: 50, perPage: 10, failPage: 1},
+			params:       models.SearchParams{Page: 1, MaxPages: 3},
+			limit:        5,
+			wantRequests: []int{1},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newTestManager(tt.limit)
+
+			page, fetched, err := pm.searchParserPages(context.Background(), tt.parser, tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка: %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+			if len(page.Vacancies) != tt.wantIDs || fetched != tt.wantFetched {
+				t.Errorf("получено %d вакансий на %d стр., ожидалось %d на %d стр.", len(page.Vacancies), fetched, tt.wantIDs, tt.wantFetched)
+			}
+			if fmt.Sprint(tt.parser.requested) != fmt.Sprint(tt.wantRequests) {
+				t.Errorf("запрошены страницы %v, ожидалось %v", tt.parser.requested, tt.wantRequests)
+			}
+			if !tt.wantErr && page.Found != tt.parser.found {
+				t.Errorf("found=%d, ожидалось %d", page.Found, tt.parser.found)
+			}
+		})
+	}
+}
@@ -23,7 +23,7 @@ func (pm *ParsersManager) selectParsersForSearch() []string {
 }
 
 // метод, который позволит асинхронно провести поиск по заданным параметрам среди списка переданных парсеров, учитывается контектс с таймаутом
-func (pm *ParsersManager) searchWithParsers(ctx context.Context, params models.SearchParams, parserNames []string) ([]models.SearchVacanciesResult, error) {
+func (pm *ParsersManager) searchWithParsers(ctx context.Context, params models.SearchParams, parserNames []string, onResult resultCallback) ([]models.SearchVacanciesResult, error) {
 	searchCtx, cancel := context.WithTimeout(ctx, pm.config.API.ConcSearchTimeout)
 	defer cancel()
 
@@ -31,7 +31,7 @@ func (pm *ParsersManager) searchWithParsers(ctx context.Context, params models.S
 	case <-ctx.Done():
 		return nil, fmt.Errorf("❌ Таймайут конкурентного поиска: %v\n", ctx.Err())
 	default:
-		return pm.concurrentSearchWithTimeout(searchCtx, params, parserNames)
+		return pm.concurrentSearchWithTimeout(searchCtx, params, parserNames, onResult)
 	}
 
 }
@@ -2,9 +2,11 @@ package parsers_manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"search_service/internal/domain/models"
 	"search_service/internal/jobs"
+	"search_service/internal/search_interfaces"
 	"time"
 )
 
@@ -16,30 +18,35 @@ func (pm *ParsersManager) startSearchWorkers() {
 	}
 }
 
-// метод, описывающий работу отдельного воркера. Воркер пытется забрать работу из очереди и обработать её
+// метод, описывающий работу отдельного воркера. Воркер ждёт работу из очереди (без холостого цикла) и обрабатывает её
+// воркер завершается при остановке воркеров или когда очередь закрыта и в ней ничего не осталось
 func (pm *ParsersManager) searchWorker(id int) {
 	defer pm.wg.Done()
 
 	for {
-		select {
-		case <-pm.stopWorkers: // канал для остановки всех воркеров
+		job, err := pm.jobSearchQueue.DequeueContext(pm.workersCtx)
+		if err != nil {
 			// Получен сигнал остановки
-			//fmt.Printf("Worker #%d: received stop signal\n", id)
+			//fmt.Printf("Worker #%d: stopped: %v\n", id, err)
 			return
-		default:
-			job, ok := pm.jobSearchQueue.Dequeue()
-			if ok {
-				fmt.Printf("woker #%d - взял задачу из очереди и начал обработку\n", id)
-
-				// проверяем тип джобы и вызываем соответствующий обработчик
-				switch j := job.(type) {
-				case *jobs.SearchJob:
-					pm.proccessSearchJob(j) // конкурентно ищем вакансии по всем доступным парсерам
-				case *jobs.FetchDetailsJob:
-					pm.proccessDetailsJob(j) // делаем запрос в конкретный сервис по конкретному ID
-				}
-			}
 		}
+
+		fmt.Printf("woker #%d - взял задачу из очереди и начал обработку\n", id)
+		pm.processJob(job)
+	}
+}
+
+// метод обработки одной джобы воркером (воркер считается занятым, пока джоба обрабатывается)
+func (pm *ParsersManager) processJob(job search_interfaces.Job) {
+	pm.busyWorkers.Add(1)
+	defer pm.busyWorkers.Add(-1)
+
+	// проверяем тип джобы и вызываем соответствующий обработчик
+	switch j := job.(type) {
+	case *jobs.SearchJob:
+		pm.proccessSearchJob(j) // конкурентно ищем вакансии по всем доступным парсерам
+	case *jobs.FetchDetailsJob:
+		pm.proccessDetailsJob(j) // делаем запрос в конкретный сервис по конкретному ID
 	}
 }
 
@@ -48,6 +55,14 @@ func (pm *ParsersManager) proccessSearchJob(job *jobs.SearchJob) {
 	var results []models.SearchVacanciesResult
 	var err error
 
+	// асинхронный поиск могли отменить, пока джоба ждала в очереди
+	ctx := job.Context()
+	if ctx.Err() != nil {
+		job.Complete(results, ctx.Err())
+		return
+	}
+	pm.markSearchJobRunning(job.ID)
+
 	select {
 	case pm.semaphore <- struct{}{}:
 		// Получили слот в семафоре менеджера парсеров
@@ -57,10 +72,23 @@ func (pm *ParsersManager) proccessSearchJob(job *jobs.SearchJob) {
 		// Используем глобальный Circuit Breaker
 		err = pm.circuitBreaker.Execute(func() error {
 			var err error
-			results, err = pm.executeSearch(context.Background(), job.Params)
+			if job.Refresh {
+				results, err = pm.coalescedSearch(ctx, job.Params, nil)
+			} else {
+				results, err = pm.executeSearch(ctx, job.Params, progressCallback(job.Progress))
+			}
+			// отмена поиска клиентом - не сбой источников, глобальный CB её не учитывает
+			if ctx.Err() != nil {
+				return nil
+			}
 			return err
 		})
 
+		if ctx.Err() != nil {
+			job.Complete(nil, ctx.Err())
+			return
+		}
+
 		results, err = pm.handleSearchResult(results, err, job.Params)
 
 	case <-time.After(pm.semaSlotGetTimeout):
@@ -100,13 +128,30 @@ func (pm *ParsersManager) proccessDetailsJob(job *jobs.FetchDetailsJob) {
 	job.Complete(result, err)
 }
 
+// ошибка джоб, которые не успели выполниться до остановки менеджера парсеров
+var ErrManagerStopped = errors.New("parsers manager is shutting down")
+
 // метод для остановки всех воркеров
+// очередь закрывается (новые джобы не принимаются), воркеры доделывают текущие джобы,
+// а джобы, которые остались в очереди, сразу завершаются с ошибкой ErrManagerStopped
 func (pm *ParsersManager) Shutdown() {
 	fmt.Println("============================================================================")
 	fmt.Println("Initiating shutdown...")
 
-	// Закрываем канал - все воркеры получат сигнал
-	close(pm.stopWorkers)
+	// Закрываем очередь - добавление, которое ждёт места, сразу получит ошибку
+	pm.jobSearchQueue.Close()
+
+	// Отменяем контекст воркеров - все воркеры получат сигнал и перестанут брать джобы
+	pm.stopWorkers()
+
+	// Завершаем оставшиеся в очереди джобы, чтобы их не ждали до таймаута
+	pending := pm.jobSearchQueue.Drain()
+	for _, job := range pending {
+		job.Complete(nil, ErrManagerStopped)
+	}
+	if len(pending) > 0 {
+		fmt.Printf("Pending jobs canceled: %d\n", len(pending))
+	}
 
 	// Ожидаем завершения всех воркеров
 	done := make(chan struct{})
@@ -125,5 +170,25 @@ func (pm *ParsersManager) Shutdown() {
 	case <-time.After(10 * time.Second):
 		fmt.Println("Warning: shutdown timeout, some workers may still be running")
 	}
-	// Закрываем очередь ---- нужно доработать
+
+	// останавливаем кэши (после воркеров - чтобы в снимки на диске попали последние результаты)
+	pm.shutdownCaches()
+}
+
+// метод остановки кэшей менеджера, которые это умеют (самоочистка, сохранение снимков на диск)
+func (pm *ParsersManager) shutdownCaches() {
+	caches := map[string]interface{}{
+		"search cache":    pm.SearchCache,
+		"vacancy index":   pm.VacancyIndex,
+		"vacancy details": pm.vacancyDetails,
+		"search jobs":     pm.searchJobs,
+	}
+
+	for name, cache := range caches {
+		if closer, ok := cache.(search_interfaces.ShutdownCacheInterface); ok {
+			if err := closer.Shutdown(); err != nil {
+				fmt.Printf("Warning: %s shutdown failed: %v\n", name, err)
+			}
+		}
+	}
 }
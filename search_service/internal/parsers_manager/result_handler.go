@@ -13,6 +13,13 @@ import (
 func (pm *ParsersManager) handleSearchResult(results []models.SearchVacanciesResult, err error, params models.SearchParams) ([]models.SearchVacanciesResult, error) {
 	// Случай 1: Всё идеально
 	if err == nil {
+		// все источники ответили ошибкой - лучше отдать устаревшие данные из кэша, если они есть
+		if allSourcesFailed(results) {
+			if cachedResults, _, found := pm.tryGetFromCache(params); found {
+				fmt.Println("⚠️  Все источники недоступны, отдаём данные из кэша")
+				return cachedResults, nil
+			}
+		}
 		return results, nil
 	}
 
@@ -36,8 +43,9 @@ func (pm *ParsersManager) handleSearchResult(results []models.SearchVacanciesRes
 }
 
 // метод - попытка получить данные из кэша (тут понимаем, что это ошибка НЕ от circuit breaker)
+// подходят и устаревшие данные: при сбое они лучше, чем ничего
 func (pm *ParsersManager) tryFallbackStrategies(params models.SearchParams, originalErr error) ([]models.SearchVacanciesResult, error) {
-	if results, found := pm.tryGetFromCache(params); found {
+	if results, _, found := pm.tryGetFromCache(params); found {
 		msg := "данные из кэша"
 		return results, fmt.Errorf("%s: %w", msg, originalErr)
 	}
@@ -51,3 +59,13 @@ func (pm *ParsersManager) isCircuitBreakerError(err error) bool {
 		errors.Is(err, circuitbreaker.ErrTooManyRequests) ||
 		strings.Contains(err.Error(), "circuit breaker")
 }
+
+// функция проверки, что все источники ответили ошибкой (пустой результат - не сбой)
+func allSourcesFailed(results []models.SearchVacanciesResult) bool {
+	for _, result := range results {
+		if result.Error == nil {
+			return false
+		}
+	}
+	return len(results) > 0
+}
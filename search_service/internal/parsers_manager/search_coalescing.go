@@ -0,0 +1,122 @@
+// этот раздел отвечает за объединение одинаковых поисков, которые выполняются одновременно:
+// пока поиск по источникам идёт, такие же поиски (с тем же хэшем параметров) не обращаются к источникам,
+// а ждут его и получают тот же результат (это бережёт квоты API источников, например HH.ru)
+package parsers_manager
+
+import (
+	"context"
+	"fmt"
+	"search_service/internal/domain/models"
+)
+
+// поиск по источникам, который выполняется сейчас, и все, кто его ждёт
+type searchFlight struct {
+	done        chan struct{}                  // закрывается, когда поиск завершён (results и err заполнены)
+	results     []models.SearchVacanciesResult // итог поиска (общий для всех ожидающих, не изменять)
+	err         error
+	received    []models.SearchVacanciesResult // результаты источников, полученные к этому моменту (для тех, кто присоединился позже)
+	subscribers map[int]resultCallback         // обратные вызовы потоковых поисков по номеру ожидающего
+	nextWaiter  int                            // номер следующего ожидающего
+	waiters     int                            // сколько поисков ждут результат
+	cancel      context.CancelFunc             // прерывает поиск, когда ждать его больше некому
+}
+
+// метод поиска по источникам с объединением одинаковых поисков
+// первый поиск с таким хэшем запускает обращение к источникам, остальные присоединяются к нему
+// поиск по источникам не привязан к контексту конкретного запроса: если один клиент ушёл, остальные получат результат,
+// а прерывается поиск, только когда ушли все ожидающие
+func (pm *ParsersManager) coalescedSearch(ctx context.Context, params models.SearchParams, onResult resultCallback) ([]models.SearchVacanciesResult, error) {
+	searchHash, err := pm.generateSearchHash(params)
+	if err != nil {
+		// без хэша объединять не по чему - ищем отдельно
+		fmt.Printf("⚠️  Ошибка генерации поискового хэша: %v\n", err)
+		return pm.searchSources(ctx, params, onResult)
+	}
+
+	pm.searchFlightsMu.Lock()
+	flight, joined := pm.searchFlights[searchHash]
+	if !joined {
+		// значения контекста (пользователь, request_id) сохраняем, отмену - нет
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		flight = &searchFlight{
+			done:        make(chan struct{}),
+			subscribers: make(map[int]resultCallback),
+			cancel:      cancel,
+		}
+		pm.searchFlights[searchHash] = flight
+		go pm.runSearchFlight(flightCtx, searchHash, params, flight)
+	}
+	waiter := pm.joinSearchFlightLocked(flight, onResult)
+	pm.searchFlightsMu.Unlock()
+
+	if joined {
+		fmt.Printf("🔗 Такой же поиск уже выполняется, ждём его результат (ключ: %s)\n", searchHash)
+	}
+
+	select {
+	case <-flight.done:
+		return flight.results, flight.err
+	case <-ctx.Done():
+		pm.leaveSearchFlight(searchHash, flight, waiter)
+		return nil, ctx.Err()
+	}
+}
+
+// метод добавления ожидающего к поиску (вызывается под блокировкой searchFlightsMu)
+// потоковый поиск сразу получает результаты источников, которые уже ответили
+func (pm *ParsersManager) joinSearchFlightLocked(flight *searchFlight, onResult resultCallback) int {
+	waiter := flight.nextWaiter
+	flight.nextWaiter++
+	flight.waiters++
+
+	if onResult != nil {
+		for _, result := range flight.received {
+			onResult(result)
+		}
+		flight.subscribers[waiter] = onResult
+	}
+	return waiter
+}
+
+// метод выхода ожидающего из поиска (его запрос отменён или истёк)
+// если ждать больше некому - поиск по источникам прерывается, а следующий такой же поиск начнётся заново
+func (pm *ParsersManager) leaveSearchFlight(searchHash string, flight *searchFlight, waiter int) {
+	pm.searchFlightsMu.Lock()
+	defer pm.searchFlightsMu.Unlock()
+
+	delete(flight.subscribers, waiter)
+	flight.waiters--
+
+	if flight.waiters == 0 {
+		flight.cancel()
+		if pm.searchFlights[searchHash] == flight {
+			delete(pm.searchFlights, searchHash)
+		}
+	}
+}
+
+// метод выполнения поиска по источникам для всех ожидающих
+// результаты кэшируются до того, как поиск перестаёт принимать ожидающих, поэтому следующий такой же поиск найдёт их в кэше
+func (pm *ParsersManager) runSearchFlight(ctx context.Context, searchHash string, params models.SearchParams, flight *searchFlight) {
+	defer flight.cancel()
+
+	results, err := pm.searchSources(ctx, params, func(result models.SearchVacanciesResult) {
+		pm.searchFlightsMu.Lock()
+		defer pm.searchFlightsMu.Unlock()
+
+		flight.received = append(flight.received, result)
+		for _, onResult := range flight.subscribers {
+			onResult(result)
+		}
+	})
+
+	pm.searchFlightsMu.Lock()
+	if pm.searchFlights[searchHash] == flight {
+		delete(pm.searchFlights, searchHash)
+	}
+	flight.results, flight.err = results, err
+	flight.subscribers = nil
+	pm.searchFlightsMu.Unlock()
+
+	close(flight.done)
+}
@@ -0,0 +1,173 @@
+package parsers_manager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"search_service/internal/domain/models"
+)
+
+// тестовый парсер, который считает обращения и отвечает только после release (или отмены контекста)
+type fakeCountingParser struct {
+	fakePagedParser
+	calls    atomic.Int32
+	canceled atomic.Int32
+	release  chan struct{}
+}
+
+func (f *fakeCountingParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	f.calls.Add(1)
+	select {
+	case <-f.release:
+		return models.SearchPage{Found: 1, Pages: 1, Vacancies: []models.Vacancy{{ID: "1", Job: "Go разработчик"}}}, nil
+	case <-ctx.Done():
+		f.canceled.Add(1)
+		return models.SearchPage{}, ctx.Err()
+	}
+}
+
+// функция ожидания нужного количества ожидающих поиска по источникам
+func waitSearchFlightWaiters(t *testing.T, pm *ParsersManager, params models.SearchParams, waiters int) {
+	t.Helper()
+
+	searchHash, _ := genHashFromSearchParam(params)
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		pm.searchFlightsMu.Lock()
+		flight, ok := pm.searchFlights[searchHash]
+		got := 0
+		if ok {
+			got = flight.waiters
+		}
+		pm.searchFlightsMu.Unlock()
+
+		if got == waiters {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("поиск по источникам не дождался %d ожидающих", waiters)
+}
+
+func TestCoalescedSearch_SharesUpstream(t *testing.T) {
+	parser := &fakeCountingParser{release: make(chan struct{})}
+	pm := newTestQueueManager(t, parser)
+	params := models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1}
+
+	const searches = 3
+	results := make([][]models.SearchVacanciesResult, searches)
+	streamed := make(chan models.SearchVacanciesResult, searches)
+
+	var wg sync.WaitGroup
+	for i := 0; i < searches; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			results[i], err = pm.executeSearch(context.Background(), params, progressCallback(streamed))
+			if err != nil {
+				t.Errorf("поиск %d завершился ошибкой: %v", i, err)
+			}
+		}(i)
+	}
+
+	waitSearchFlightWaiters(t, pm, params, searches)
+	close(parser.release)
+	wg.Wait()
+
+	if calls := parser.calls.Load(); calls != 1 {
+		t.Errorf("одинаковые поиски должны обращаться к источнику один раз, обращений: %d", calls)
+	}
+	for i, result := range results {
+		if len(result) != 1 || len(result[0].Vacancies) != 1 {
+			t.Errorf("поиск %d: ожидался один источник с одной вакансией, получено %+v", i, result)
+		}
+	}
+	if len(streamed) != searches {
+		t.Errorf("результат источника должен прийти каждому потоковому поиску, получено: %d", len(streamed))
+	}
+
+	// поиск завершён и закэширован - следующий такой же поиск к источникам не идёт
+	if _, err := pm.executeSearch(context.Background(), params, nil); err != nil {
+		t.Fatalf("повторный поиск завершился ошибкой: %v", err)
+	}
+	if calls := parser.calls.Load(); calls != 1 {
+		t.Errorf("повторный поиск должен взять результат из кэша, обращений: %d", calls)
+	}
+}
+
+func TestCoalescedSearch_WaiterLeaves(t *testing.T) {
+	parser := &fakeCountingParser{release: make(chan struct{})}
+	pm := newTestQueueManager(t, parser)
+	params := models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1}
+
+	// первый клиент уходит, не дождавшись результата
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := pm.executeSearch(leaderCtx, params, nil)
+		leaderErr <- err
+	}()
+	waitSearchFlightWaiters(t, pm, params, 1)
+
+	followerResult := make(chan []models.SearchVacanciesResult, 1)
+	go func() {
+		results, _ := pm.executeSearch(context.Background(), params, nil)
+		followerResult <- results
+	}()
+	waitSearchFlightWaiters(t, pm, params, 2)
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("ушедший клиент должен получить ошибку отмены, получено: %v", err)
+	}
+
+	// оставшийся клиент получает результат общего поиска
+	close(parser.release)
+	if results := <-followerResult; len(results) != 1 || results[0].Error != nil {
+		t.Errorf("оставшийся клиент должен получить результат, получено %+v", results)
+	}
+	if parser.canceled.Load() != 0 {
+		t.Error("поиск не должен прерываться, пока его кто-то ждёт")
+	}
+}
+
+func TestCoalescedSearch_AllWaitersLeave(t *testing.T) {
+	parser := &fakeCountingParser{release: make(chan struct{})}
+	pm := newTestQueueManager(t, parser)
+	params := models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		pm.executeSearch(ctx, params, nil)
+		close(done)
+	}()
+	waitSearchFlightWaiters(t, pm, params, 1)
+
+	cancel()
+	<-done
+
+	// ждать некому - обращение к источнику прерывается
+	deadline := time.Now().Add(3 * time.Second)
+	for parser.canceled.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if parser.canceled.Load() != 1 {
+		t.Error("поиск по источникам должен прерываться, когда ушли все ожидающие")
+	}
+
+	// следующий такой же поиск начинается заново
+	close(parser.release)
+	results, err := pm.executeSearch(context.Background(), params, nil)
+	if err != nil || len(results) != 1 || results[0].Error != nil {
+		t.Errorf("новый поиск должен выполниться заново, получено %+v, ошибка: %v", results, err)
+	}
+	if calls := parser.calls.Load(); calls != 2 {
+		t.Errorf("ожидалось два обращения к источнику, получено: %d", calls)
+	}
+}
@@ -0,0 +1,147 @@
+// этот раздел отвечает за асинхронный поиск вакансий: джоба ставится в очередь и клиент сразу получает её ID,
+// состояние и результаты поиска клиент забирает потом (результаты хранятся ограниченное время)
+package parsers_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"search_service/internal/domain/models"
+	"search_service/internal/jobs"
+	"time"
+)
+
+// ошибки асинхронного поиска
+var (
+	ErrSearchQueueFull = errors.New("search queue is full") // очередь заполнена, джоба не принята
+	ErrSearchCanceled  = errors.New("search canceled")      // клиент отменил поиск
+)
+
+// асинхронный поиск, который ещё не завершён
+type asyncSearch struct {
+	job    *jobs.SearchJob
+	info   models.SearchJobInfo
+	cancel context.CancelFunc
+}
+
+// StartSearchJob - метод постановки асинхронного поиска в очередь, не ждёт результатов поиска
+// из ctx берётся только пользователь (для очереди): поиск продолжается и после завершения запроса клиента
+func (pm *ParsersManager) StartSearchJob(ctx context.Context, params models.SearchParams) (models.SearchJobInfo, error) {
+	job := pm.newSearchJob(ctx, params)
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job.Ctx = jobCtx
+
+	search := &asyncSearch{
+		job:    job,
+		cancel: cancel,
+		info: models.SearchJobInfo{
+			ID:        job.ID,
+			UserID:    job.UserID,
+			Status:    models.SearchJobQueued,
+			Params:    params,
+			CreatedAt: job.CreatedAt,
+		},
+	}
+
+	// регистрируем до постановки в очередь, чтобы воркер сразу мог отметить начало поиска
+	pm.searchJobsMu.Lock()
+	pm.activeSearchJobs[job.ID] = search
+	pm.searchJobsMu.Unlock()
+
+	if !pm.jobSearchQueue.Enqueue(job) {
+		pm.searchJobsMu.Lock()
+		delete(pm.activeSearchJobs, job.ID)
+		pm.searchJobsMu.Unlock()
+		cancel()
+		return models.SearchJobInfo{}, ErrSearchQueueFull
+	}
+
+	go pm.awaitSearchJob(search)
+
+	return search.info, nil
+}
+
+// GetSearchJob - метод получения состояния асинхронного поиска по ID
+// false - поиска нет (неизвестный ID или результаты уже удалены по истечении срока хранения)
+func (pm *ParsersManager) GetSearchJob(id string) (models.SearchJobInfo, bool) {
+	pm.searchJobsMu.Lock()
+	search, ok := pm.activeSearchJobs[id]
+	if ok {
+		info := search.info
+		pm.searchJobsMu.Unlock()
+		return info, true
+	}
+	pm.searchJobsMu.Unlock()
+
+	return pm.searchJobs.Get(id)
+}
+
+// CancelSearchJob - метод отмены асинхронного поиска
+// незавершённый поиск прерывается (или снимается с очереди) и остаётся доступен со статусом canceled,
+// у завершённого поиска удаляются сохранённые результаты
+func (pm *ParsersManager) CancelSearchJob(id string) (models.SearchJobInfo, bool) {
+	pm.searchJobsMu.Lock()
+	if search, ok := pm.activeSearchJobs[id]; ok {
+		if !search.info.Finished() {
+			search.info.Status = models.SearchJobCanceled
+			search.info.Error = ErrSearchCanceled
+			search.info.FinishedAt = time.Now()
+		}
+		info := search.info
+		pm.searchJobsMu.Unlock()
+
+		search.cancel()
+		return info, true
+	}
+	pm.searchJobsMu.Unlock()
+
+	info, ok := pm.GetSearchJob(id)
+	if ok {
+		pm.searchJobs.Delete(id)
+	}
+	return info, ok
+}
+
+// метод отметки о начале асинхронного поиска (вызывает воркер, когда берёт джобу из очереди)
+func (pm *ParsersManager) markSearchJobRunning(id string) {
+	pm.searchJobsMu.Lock()
+	defer pm.searchJobsMu.Unlock()
+
+	if search, ok := pm.activeSearchJobs[id]; ok && search.info.Status == models.SearchJobQueued {
+		search.info.Status = models.SearchJobRunning
+		search.info.StartedAt = time.Now()
+	}
+}
+
+// метод ожидания результата асинхронного поиска: результат сохраняется на время хранения и снимается с активных поисков
+func (pm *ParsersManager) awaitSearchJob(search *asyncSearch) {
+	defer search.cancel()
+
+	// результат приходит всегда: от воркера или, при остановке менеджера, с ошибкой ErrManagerStopped
+	output := <-search.job.ResultChan
+
+	pm.searchJobsMu.Lock()
+	info := search.info
+	if !info.Finished() {
+		info.FinishedAt = time.Now()
+		results, _ := output.Data.([]models.SearchVacanciesResult)
+
+		switch {
+		case output.Error != nil:
+			info.Status = models.SearchJobFailed
+			info.Error = output.Error
+		case len(results) == 0:
+			info.Status = models.SearchJobFailed
+			info.Error = fmt.Errorf("Failed to find vacancies")
+		default:
+			info.Status = models.SearchJobDone
+			info.Results = results
+		}
+	}
+
+	// сначала сохраняем результат, потом снимаем с активных - чтобы поиск не "пропадал" между ними
+	pm.searchJobs.Set(info.ID, info, pm.config.Cache.SearchJobsCacheConfig.SearchJobsTTL)
+	delete(pm.activeSearchJobs, info.ID)
+	pm.searchJobsMu.Unlock()
+}
@@ -0,0 +1,208 @@
+package parsers_manager
+
+import (
+	"context"
+	"search_service/configs"
+	"search_service/internal/caches"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+	"shared/inmemory_cache"
+	"testing"
+	"time"
+)
+
+// тестовый менеджер состояний: все парсеры считаются здоровыми
+type fakeStatusManager struct {
+	names []string
+}
+
+func (f *fakeStatusManager) UpdateStatus(name string, success bool, err error) {}
+func (f *fakeStatusManager) GetHealthyParsers() []string                       { return f.names }
+func (f *fakeStatusManager) GetParserStatus(name string) (*search_interfaces.ParserStatus, bool) {
+	return nil, false
+}
+func (f *fakeStatusManager) Stop() {}
+
+// тестовый парсер, который отвечает только после release (или отмены контекста)
+type fakeBlockingParser struct {
+	fakePagedParser
+	release chan struct{}
+}
+
+func (f *fakeBlockingParser) SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error) {
+	select {
+	case <-f.release:
+		return models.SearchPage{Found: 1, Pages: 1, Vacancies: []models.Vacancy{{ID: "1", Job: "Go разработчик"}}}, nil
+	case <-ctx.Done():
+		return models.SearchPage{}, ctx.Err()
+	}
+}
+
+// функция создания инмемори кэша значений типа V для менеджера
+func newTestCache[V any](t *testing.T, cfg *configs.SearchServiceConfig) search_interfaces.CacheInterface[V] {
+	t.Helper()
+
+	cache, err := caches.NewInmemory(inmemory_cache.ShardedCacheConfig[string, V]{
+		NumShards:       cfg.Cache.NumOfShards,
+		CleanUpInterval: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("ошибка создания кэша: %v", err)
+	}
+	return cache
+}
+
+// функция создания менеджера с воркерами и инмемори кэшами
+func newTestQueueManager(t *testing.T, parsers ...search_interfaces.Parser) *ParsersManager {
+	t.Helper()
+
+	cfg := &configs.SearchServiceConfig{
+		API:     configs.APIConfig{ConcSearchTimeout: 5 * time.Second},
+		Cache:   configs.DefaultCacheConfig(),
+		Manager: configs.DefaultParsersManagerConfig(),
+	}
+
+	names := make([]string, len(parsers))
+	for i, p := range parsers {
+		names[i] = p.GetName()
+	}
+
+	pm, err := NewParserManager(cfg, 1,
+		newTestCache[[]models.SearchVacanciesResult](t, cfg), newTestCache[models.VacancyIndex](t, cfg),
+		newTestCache[models.SearchVacancyDetailesResult](t, cfg), newTestCache[models.SearchJobInfo](t, cfg),
+		&fakeStatusManager{names: names}, parsers...)
+	if err != nil {
+		t.Fatalf("ошибка создания менеджера парсеров: %v", err)
+	}
+	t.Cleanup(pm.Shutdown)
+	return pm
+}
+
+// функция ожидания нужного статуса асинхронного поиска
+func waitSearchJobStatus(t *testing.T, pm *ParsersManager, id, status string) models.SearchJobInfo {
+	t.Helper()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		info, ok := pm.GetSearchJob(id)
+		if ok && info.Status == status {
+			return info
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	info, _ := pm.GetSearchJob(id)
+	t.Fatalf("поиск %s не перешёл в статус %s, текущий статус: %s", id, status, info.Status)
+	return info
+}
+
+func TestSearchJob_Done(t *testing.T) {
+	parser := &fakeBlockingParser{release: make(chan struct{})}
+	pm := newTestQueueManager(t, parser)
+
+	ctx := models.WithRequester(context.Background(), models.Requester{UserID: "user-1"})
+	info, err := pm.StartSearchJob(ctx, models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1})
+	if err != nil {
+		t.Fatalf("поиск не поставлен в очередь: %v", err)
+	}
+	if info.Status != models.SearchJobQueued {
+		t.Errorf("новый поиск должен быть в статусе queued, получен %s", info.Status)
+	}
+	if info.UserID != "user-1" {
+		t.Errorf("у поиска должен сохраняться поставивший его пользователь, получен %q", info.UserID)
+	}
+
+	running := waitSearchJobStatus(t, pm, info.ID, models.SearchJobRunning)
+	if running.StartedAt.IsZero() {
+		t.Error("у запущенного поиска должно быть время начала")
+	}
+
+	close(parser.release)
+	done := waitSearchJobStatus(t, pm, info.ID, models.SearchJobDone)
+	if len(done.Results) != 1 || len(done.Results[0].Vacancies) != 1 {
+		t.Fatalf("ожидался один источник с одной вакансией, получено %+v", done.Results)
+	}
+	if stored, _ := pm.GetSearchJob(info.ID); stored.UserID != "user-1" {
+		t.Errorf("у завершённого поиска должен сохраняться пользователь, получен %q", stored.UserID)
+	}
+
+	// удаление завершённого поиска удаляет и его результаты
+	if _, ok := pm.CancelSearchJob(info.ID); !ok {
+		t.Fatal("завершённый поиск должен удаляться")
+	}
+	if _, ok := pm.GetSearchJob(info.ID); ok {
+		t.Error("результаты удалённого поиска не должны быть доступны")
+	}
+}
+
+func TestSearchJob_Cancel(t *testing.T) {
+	parser := &fakeBlockingParser{release: make(chan struct{})}
+	pm := newTestQueueManager(t, parser)
+
+	info, err := pm.StartSearchJob(context.Background(), models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1})
+	if err != nil {
+		t.Fatalf("поиск не поставлен в очередь: %v", err)
+	}
+	waitSearchJobStatus(t, pm, info.ID, models.SearchJobRunning)
+
+	canceled, ok := pm.CancelSearchJob(info.ID)
+	if !ok || canceled.Status != models.SearchJobCanceled {
+		t.Fatalf("ожидался статус canceled, получено %+v", canceled)
+	}
+
+	// после остановки поиска в воркере статус не меняется, поиск остаётся доступен
+	time.Sleep(100 * time.Millisecond)
+	if got := waitSearchJobStatus(t, pm, info.ID, models.SearchJobCanceled); got.Results != nil {
+		t.Errorf("у отменённого поиска не должно быть результатов, получено %+v", got.Results)
+	}
+}
+
+func TestSearchJob_Unknown(t *testing.T) {
+	pm := newTestQueueManager(t, &fakeBlockingParser{release: make(chan struct{})})
+
+	if _, ok := pm.GetSearchJob("unknown"); ok {
+		t.Error("неизвестный поиск не должен находиться")
+	}
+	if _, ok := pm.CancelSearchJob("unknown"); ok {
+		t.Error("неизвестный поиск не должен отменяться")
+	}
+}
+
+func TestShutdown_CompletesPendingJobs(t *testing.T) {
+	parser := &fakeBlockingParser{release: make(chan struct{})}
+	pm := newTestQueueManager(t, parser)
+
+	// все воркеры заняты, следующий поиск ждёт в очереди
+	var running []models.SearchJobInfo
+	for i := 0; i < pm.workers; i++ {
+		info, err := pm.StartSearchJob(context.Background(), models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1})
+		if err != nil {
+			t.Fatalf("поиск не поставлен в очередь: %v", err)
+		}
+		waitSearchJobStatus(t, pm, info.ID, models.SearchJobRunning)
+		running = append(running, info)
+	}
+
+	pending, err := pm.StartSearchJob(context.Background(), models.SearchParams{Text: "python", PerPage: 10, Page: 1, MaxPages: 1})
+	if err != nil {
+		t.Fatalf("поиск не поставлен в очередь: %v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(parser.release)
+	}()
+	pm.Shutdown()
+
+	// ожидающий поиск завершён с ошибкой, начатый - доделан
+	if info := waitSearchJobStatus(t, pm, pending.ID, models.SearchJobFailed); info.Error == nil {
+		t.Error("у снятого с очереди поиска должна быть ошибка")
+	}
+	for _, info := range running {
+		waitSearchJobStatus(t, pm, info.ID, models.SearchJobDone)
+	}
+
+	if _, err := pm.StartSearchJob(context.Background(), models.SearchParams{Text: "java"}); err == nil {
+		t.Error("остановленный менеджер не должен принимать поиски")
+	}
+}
@@ -0,0 +1,54 @@
+// этот раздел отвечает за фоновое обновление устаревших результатов поиска в кэше (stale-while-revalidate):
+// клиент сразу получает устаревшие результаты, а в очередь ставится джоба, которая заново ищет в источниках и обновляет кэш
+package parsers_manager
+
+import (
+	"context"
+	"fmt"
+	"search_service/internal/domain/models"
+)
+
+// метод планирования фонового обновления устаревших результатов поиска
+// на один поиск в очереди стоит не больше одной джобы обновления, если очередь заполнена - обновление пропускается
+// (устаревшие результаты обновятся при следующем обращении)
+func (pm *ParsersManager) scheduleCacheRefresh(params models.SearchParams) {
+	searchHash, err := pm.generateSearchHash(params)
+	if err != nil {
+		fmt.Printf("⚠️  Обновление кэша не запланировано: %v\n", err)
+		return
+	}
+
+	pm.cacheRefreshesMu.Lock()
+	if _, scheduled := pm.cacheRefreshes[searchHash]; scheduled {
+		pm.cacheRefreshesMu.Unlock()
+		return
+	}
+	pm.cacheRefreshes[searchHash] = struct{}{}
+	pm.cacheRefreshesMu.Unlock()
+
+	// джоба обновления не от имени пользователя: она не расходует его долю в очереди
+	job := pm.newSearchJob(context.Background(), params)
+	job.Refresh = true
+
+	if !pm.jobSearchQueue.Enqueue(job) {
+		fmt.Printf("⚠️  Обновление кэша пропущено: очередь заполнена (ключ: %s)\n", searchHash)
+		pm.finishCacheRefresh(searchHash)
+		return
+	}
+	fmt.Printf("🔄 Запланировано обновление устаревших результатов в кэше (ключ: %s)\n", searchHash)
+
+	// результат записывается в кэш при поиске, здесь только дожидаемся завершения джобы
+	go func() {
+		if output := <-job.ResultChan; output != nil && output.Error != nil {
+			fmt.Printf("⚠️  Обновление кэша не удалось (ключ: %s): %v\n", searchHash, output.Error)
+		}
+		pm.finishCacheRefresh(searchHash)
+	}()
+}
+
+// метод снятия отметки о запланированном обновлении
+func (pm *ParsersManager) finishCacheRefresh(searchHash string) {
+	pm.cacheRefreshesMu.Lock()
+	delete(pm.cacheRefreshes, searchHash)
+	pm.cacheRefreshesMu.Unlock()
+}
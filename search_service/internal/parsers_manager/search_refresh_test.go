@@ -0,0 +1,138 @@
+package parsers_manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"search_service/internal/domain/models"
+	"search_service/internal/search_interfaces"
+)
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	parser := &fakeCountingParser{release: make(chan struct{})}
+	close(parser.release)
+	pm := newTestQueueManager(t, parser)
+	pm.config.Cache.SearchCacheConfig.SearchCacheTTL = 20 * time.Millisecond
+	pm.config.Cache.SearchCacheConfig.SearchCacheStale = time.Hour
+	params := models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1}
+
+	if _, err := pm.SearchVacancies(context.Background(), params); err != nil {
+		t.Fatalf("первый поиск завершился ошибкой: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	// результаты устарели - клиент получает их сразу, а обновление идёт в фоне
+	results, err := pm.SearchVacancies(context.Background(), params)
+	if err != nil || len(results) != 1 || len(results[0].Vacancies) != 1 {
+		t.Fatalf("ожидались устаревшие результаты из кэша, получено %+v, ошибка: %v", results, err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for parser.calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if calls := parser.calls.Load(); calls != 2 {
+		t.Fatalf("устаревшие результаты должны обновиться одним обращением к источнику, обращений: %d", calls)
+	}
+
+	// после обновления в кэше снова свежие результаты
+	searchHash, _ := genHashFromSearchParam(params)
+	staleCache := pm.SearchCache.(search_interfaces.StaleCacheInterface[[]models.SearchVacanciesResult])
+	for time.Now().Before(deadline) {
+		if _, fresh, _ := staleCache.GetStale(searchHash); fresh {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("после фонового обновления результаты в кэше должны быть свежими")
+}
+
+func TestScheduleCacheRefresh_Once(t *testing.T) {
+	parser := &fakeCountingParser{release: make(chan struct{})}
+	pm := newTestQueueManager(t, parser)
+	params := models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1}
+
+	// пока обновление не завершилось, повторные обращения к устаревшим данным новых джоб не ставят
+	for i := 0; i < 3; i++ {
+		pm.scheduleCacheRefresh(params)
+	}
+
+	pm.cacheRefreshesMu.Lock()
+	scheduled := len(pm.cacheRefreshes)
+	pm.cacheRefreshesMu.Unlock()
+	if scheduled != 1 {
+		t.Errorf("ожидалось одно запланированное обновление, получено: %d", scheduled)
+	}
+
+	close(parser.release)
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		pm.cacheRefreshesMu.Lock()
+		scheduled = len(pm.cacheRefreshes)
+		pm.cacheRefreshesMu.Unlock()
+		if scheduled == 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if scheduled != 0 {
+		t.Fatal("после завершения обновления отметка о нём должна сниматься")
+	}
+	if calls := parser.calls.Load(); calls != 1 {
+		t.Errorf("ожидалось одно обращение к источнику, получено: %d", calls)
+	}
+}
+
+func TestHandleSearchResult_StaleFallback(t *testing.T) {
+	pm := newTestQueueManager(t, &fakeCountingParser{release: make(chan struct{})})
+	pm.config.Cache.SearchCacheConfig.SearchCacheTTL = time.Millisecond
+	pm.config.Cache.SearchCacheConfig.SearchCacheStale = time.Hour
+	params := models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1}
+
+	cached := []models.SearchVacanciesResult{{ParserName: "fake", Vacancies: []models.Vacancy{{ID: "1"}}}}
+	pm.cacheSearchResults(params, cached)
+	time.Sleep(5 * time.Millisecond)
+
+	// все источники ответили ошибкой - отдаём устаревшие данные
+	failed := []models.SearchVacanciesResult{{ParserName: "fake", Error: errors.New("source unavailable")}}
+	results, err := pm.handleSearchResult(failed, nil, params)
+	if err != nil || len(results) != 1 || results[0].Error != nil {
+		t.Errorf("ожидались устаревшие данные из кэша, получено %+v, ошибка: %v", results, err)
+	}
+
+	// полный провал - fallback тоже видит устаревшие данные
+	results, err = pm.handleSearchResult(nil, errors.New("search failed"), params)
+	if len(results) != 1 || err == nil {
+		t.Errorf("ожидались устаревшие данные из кэша с исходной ошибкой, получено %+v, ошибка: %v", results, err)
+	}
+
+	// пустой ответ источников без ошибок - не сбой, кэш не нужен
+	empty := []models.SearchVacanciesResult{{ParserName: "fake"}}
+	if results, _ := pm.handleSearchResult(empty, nil, params); len(results[0].Vacancies) != 0 {
+		t.Error("пустой ответ источников не должен заменяться данными из кэша")
+	}
+}
+
+func TestVacancyIndex_OutlivesFreshTTL(t *testing.T) {
+	pm := newTestQueueManager(t, &fakeCountingParser{release: make(chan struct{})})
+	pm.config.Cache.SearchCacheConfig.SearchCacheTTL = time.Millisecond
+	pm.config.Cache.SearchCacheConfig.SearchCacheStale = time.Hour
+	pm.config.Cache.VacancyCacheConfig.VacancyCacheTTL = time.Millisecond
+	params := models.SearchParams{Text: "golang", PerPage: 10, Page: 1, MaxPages: 1}
+
+	cached := []models.SearchVacanciesResult{{ParserName: "hh", Vacancies: []models.Vacancy{{ID: "1", Source: "hh"}}}}
+	pm.cacheSearchResults(params, cached)
+	time.Sleep(5 * time.Millisecond)
+
+	// результаты поиска устарели, но ещё хранятся - вакансию из них должно быть можно найти через индекс
+	index, ok := pm.GetVacancyIndex("hh_1")
+	if !ok {
+		t.Fatal("запись индекса должна жить, пока в кэше есть устаревшие результаты поиска")
+	}
+	results, ok := pm.GetCachedSearchResults(index.SearchHash)
+	if !ok || len(results) != 1 || results[0].Vacancies[0].ID != "1" {
+		t.Errorf("ожидались устаревшие результаты поиска по хэшу из индекса, получено %+v", results)
+	}
+}
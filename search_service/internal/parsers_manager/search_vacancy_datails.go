@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"search_service/internal/domain/models"
 	"search_service/internal/search_interfaces"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -33,32 +32,18 @@ func (pm *ParsersManager) GetVacancyDetails(scanner *bufio.Scanner) error {
 
 	// -------------------------------------------------------------------
 	// пытаемся найти в кэше №2 данные по заданному ключу (составному индексу)
-	searchResIndex, ok := pm.VacancyIndex.GetItem(compositeID)
+	searchResIndex, ok := pm.VacancyIndex.Get(compositeID)
 	if !ok {
 		return fmt.Errorf("No Vacancy with ID:%s was found in cache\n", vacancyID)
 	}
 
-	// проводим type assertion, проверяем нужный тип (так как нам функция GetItem возвращает интерфейс)
-	searchResIndexChecked, ok := searchResIndex.(models.VacancyIndex)
-	if !ok {
-		fmt.Println("Type assertion after GetVacancyDetails ---> failed!")
-		return fmt.Errorf("Type assertion after GetVacancyDetails ---> failed!\n")
-	}
-
 	// теперь из полученного из кэша индексов индекса мы можем найти нужный хэш запроса,
 	// чтобы потом по этому хэшу из кэша поиска найти нужную вакансию по ID
 
 	// пытаемся найти в кэше данные по заданному хэш ключу
-	searchRes, ok := pm.SearchCache.GetItem(searchResIndexChecked.SearchHash)
+	searchRes, ok := pm.SearchCache.Get(searchResIndex.SearchHash)
 	if ok {
-		// если можно получить данные из кэша, то получаем интерфейс.
-		// проводим type assertion, проверяем нужный тип
-		searchResChecked, ok := searchRes.([]models.SearchVacanciesResult)
-		if !ok {
-			return fmt.Errorf("Type assertion after multi-search ---> failed!\n")
-		}
-
-		for _, neededElementRes := range searchResChecked {
+		for _, neededElementRes := range searchRes {
 			if neededElementRes.ParserName == source {
 				for _, vacancyRes := range neededElementRes.Vacancies {
 					if vacancyRes.ID == vacancyID {
@@ -73,7 +58,7 @@ func (pm *ParsersManager) GetVacancyDetails(scanner *bufio.Scanner) error {
 			}
 		}
 	} else {
-		pm.VacancyIndex.DeleteItem(compositeID)
+		pm.VacancyIndex.Delete(compositeID)
 		return fmt.Errorf("Данные устарели, сделайте повторный запрос (пункт меню 1)\n")
 	}
 
@@ -103,12 +88,10 @@ func (pm *ParsersManager) GetFullVacancyDetails(scanner *bufio.Scanner) error {
 	// создаём переменную для искомой вакансии
 	var targetVacancy models.Vacancy
 
-	salary := strconv.Itoa(result.Salary.From) // переводим зарплату из int в string
-
 	targetVacancy.Company = result.Employer.Name
 	targetVacancy.Job = result.Name
 	targetVacancy.Description = result.Description
-	targetVacancy.Salary = &salary
+	targetVacancy.Salary = result.Salary
 	targetVacancy.Location = result.Location.Name
 	targetVacancy.ID = result.ID
 	targetVacancy.URL = result.Url
@@ -121,7 +104,7 @@ func (pm *ParsersManager) GetFullVacancyDetails(scanner *bufio.Scanner) error {
 // возвращает результат поиска или ошибку
 func (pm *ParsersManager) ExecuteSearchVacancyDetailes(ctx context.Context, vacancyID, source string) (models.SearchVacancyDetailesResult, error) {
 	// создаём новую джобу необходимого типа (в данном случае джоба поиска расширенной инфы по конкретной вакансии)
-	job := pm.NewFetchVacancyJob(source, vacancyID)
+	job := pm.NewFetchVacancyJob(ctx, source, vacancyID)
 
 	// Пытаемся добавить в очередь с таймаутом и повторными попытками
 	success := pm.tryEnqueueJob(ctx, job, 5*time.Second)
@@ -141,15 +124,10 @@ func (pm *ParsersManager) ExecuteSearchVacancyDetailes(ctx context.Context, vaca
 // Основная логика поиска деталей конкретной вакансии
 func (pm *ParsersManager) searchVacancyDetailes(ctx context.Context, vacancyID, source string) (models.SearchVacancyDetailesResult, error) {
 	// Проверяем кэш деталей вакансии
-	// пытаемся найти в кэше данные по заданному хэш ключу
-	cached, found := pm.vacancyDetails.GetItem(vacancyID)
-	if found {
-		// необходим type assertion
-		checkedCached, ok := cached.(models.SearchVacancyDetailesResult)
-		if !ok {
-			return models.SearchVacancyDetailesResult{}, fmt.Errorf("⚠️  Type assertion для кэшированных данных деталей вакансии -  не удался\n")
-		}
-		return checkedCached, nil
+	// ключ - составной индекс источника и ID (у разных источников ID вакансий могут совпадать)
+	detailsKey := fmt.Sprintf("%s_%s", source, vacancyID)
+	if cached, found := pm.vacancyDetails.Get(detailsKey); found {
+		return cached, nil
 	}
 
 	// делаем проверку того, что источник(парсер) находтся в "живом состоянии"
@@ -174,7 +152,7 @@ func (pm *ParsersManager) searchVacancyDetailes(ctx context.Context, vacancyID,
 	}
 
 	// кэшируем результат в кэш для результатов поиска деталей вакансии по конкретному ID
-	pm.cacheDetailsResult(vacancyID, vacancyDetails)
+	pm.cacheDetailsResult(detailsKey, vacancyDetails)
 
 	return vacancyDetails, nil
 }
@@ -217,12 +195,7 @@ func printVacancyDetails(vacancy models.Vacancy) {
 	fmt.Println(strings.Repeat("=", 50))
 	fmt.Printf("💼 Работодатель: %s\n", vacancy.Company)
 
-	// проверяем поле salary на nil, чтобы не словить панику
-	if vacancy.Salary == nil {
-		fmt.Printf("💰 Зарплата: %s\n", "Salary is nil")
-	} else {
-		fmt.Printf("💰 Зарплата: %s\n", *vacancy.Salary)
-	}
+	fmt.Printf("💰 Зарплата: %s\n", salaryForConsole(vacancy.Salary))
 
 	fmt.Printf("📍 Местоположение: %s\n", vacancy.Location)
 	//fmt.Printf("🕐 Опубликовано: %s\n", formatDate(vacancy.PublishedAt))
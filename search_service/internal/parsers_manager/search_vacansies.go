@@ -14,7 +14,7 @@ import (
 // возвращает результат поиска или ошибку
 func (pm *ParsersManager) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.SearchVacanciesResult, error) {
 	// создаём новую джобу необходимого типа (в данном случае джоба поиска списка вакансий)
-	job := pm.newSearchJob(params)
+	job := pm.newSearchJob(ctx, params)
 
 	// Пытаемся добавить в очередь с таймаутом и повторными попытками
 	success := pm.tryEnqueueJob(ctx, job, 5*time.Second)
@@ -31,16 +31,64 @@ func (pm *ParsersManager) SearchVacancies(ctx context.Context, params models.Sea
 	return result, err
 }
 
+// SearchVacanciesStream - потоковый вариант SearchVacancies: результат каждого источника отправляется в progress,
+// как только источник ответил (из кэша - сразу все), итоговый результат (после объединения дублей) возвращается как обычно
+// progress должен быть буферизирован на число источников (GetParserNames), менеджер не ждёт читателя канала
+func (pm *ParsersManager) SearchVacanciesStream(ctx context.Context, params models.SearchParams, progress chan<- models.SearchVacanciesResult) ([]models.SearchVacanciesResult, error) {
+	job := pm.newSearchJob(ctx, params)
+	job.Progress = progress
+
+	if !pm.tryEnqueueJob(ctx, job, 5*time.Second) {
+		return []models.SearchVacanciesResult{}, fmt.Errorf("❌ Джоба не была добавлена в очередь")
+	}
+
+	return pm.waitForJobSearchVacansiesResult(ctx, job.ResultChan, 30*time.Second)
+}
+
+// функция обратного вызова для результата одного источника
+type resultCallback func(result models.SearchVacanciesResult)
+
+// функция получения обратного вызова, который отправляет результаты источников в канал потокового поиска
+// отправка не блокирует поиск: если читатель не успевает и буфер заполнен - результат пропускается
+func progressCallback(progress chan<- models.SearchVacanciesResult) resultCallback {
+	if progress == nil {
+		return nil
+	}
+	return func(result models.SearchVacanciesResult) {
+		select {
+		case progress <- result:
+		default:
+			fmt.Printf("⚠️  Результат источника %s не отправлен в поток: буфер заполнен\n", result.ParserName)
+		}
+	}
+}
+
 // Основная логика поиска списка вакансий по всем доступным парсерам
-func (pm *ParsersManager) executeSearch(ctx context.Context, params models.SearchParams) ([]models.SearchVacanciesResult, error) {
+// onResult (необязательный) получает результат каждого источника сразу, как только он готов (до объединения дублей)
+func (pm *ParsersManager) executeSearch(ctx context.Context, params models.SearchParams, onResult resultCallback) ([]models.SearchVacanciesResult, error) {
 
 	// Проверяем кэш
-	if cachedResults, found := pm.tryGetFromCache(params); found {
+	if cachedResults, fresh, found := pm.tryGetFromCache(params); found {
 		// Только возвращаем кэшированные данные
 		// Статус парсеров не трогаем — они не участвовали
+		// устаревшие данные отдаём сразу, а обновляем их в фоне отдельной джобой
+		if !fresh {
+			pm.scheduleCacheRefresh(params)
+		}
+		if onResult != nil {
+			for _, result := range cachedResults {
+				onResult(result)
+			}
+		}
 		return cachedResults, nil
 	}
 
+	// идём в источники (одинаковые поиски, которые выполняются одновременно, обращаются к источникам один раз)
+	return pm.coalescedSearch(ctx, params, onResult)
+}
+
+// метод поиска списка вакансий по всем доступным парсерам: конкурентный поиск, объединение дублей и кэширование
+func (pm *ParsersManager) searchSources(ctx context.Context, params models.SearchParams, onResult resultCallback) ([]models.SearchVacanciesResult, error) {
 	// Получаем список парсеров для использования
 	parsersToUse := pm.selectParsersForSearch()
 	if len(parsersToUse) == 0 {
@@ -48,12 +96,17 @@ func (pm *ParsersManager) executeSearch(ctx context.Context, params models.Searc
 	}
 
 	// Выполняем поиск через парсеры
-	searchResults, err := pm.searchWithParsers(ctx, params, parsersToUse)
+	searchResults, err := pm.searchWithParsers(ctx, params, parsersToUse, onResult)
 
 	if err != nil {
 		return nil, fmt.Errorf("❌ Конкурентный поиск по парсерам - не удался!")
 	}
 
+	// Объединяем одинаковые вакансии, найденные в разных источниках (если клиент не отключил)
+	if !params.DisableDedup {
+		searchResults = pm.deduplicateResults(searchResults)
+	}
+
 	// Фильтруем результаты: берем только успешные, т.е. те, у которых в models.SearchResult.Error == nil
 	successfulResults := pm.filterSuccessfulResults(searchResults)
 
@@ -69,10 +122,11 @@ func (pm *ParsersManager) executeSearch(ctx context.Context, params models.Searc
 }
 
 // Формируем слайс стркутур, где поиск прошёл без ошибок
+// источник, все вакансии которого объединены с другими источниками, тоже считается успешным
 func (pm *ParsersManager) filterSuccessfulResults(results []models.SearchVacanciesResult) []models.SearchVacanciesResult {
 	var successful []models.SearchVacanciesResult
 	for _, result := range results {
-		if result.Error == nil && len(result.Vacancies) > 0 {
+		if result.Error == nil && (len(result.Vacancies) > 0 || result.Merged > 0) {
 			successful = append(successful, result)
 		}
 	}
@@ -0,0 +1,28 @@
+package parsers_manager
+
+import (
+	"search_service/internal/domain/models"
+	"testing"
+)
+
+func TestProgressCallback(t *testing.T) {
+	if progressCallback(nil) != nil {
+		t.Fatal("без канала потокового поиска обратный вызов не нужен")
+	}
+
+	progress := make(chan models.SearchVacanciesResult, 1)
+	onResult := progressCallback(progress)
+
+	onResult(models.SearchVacanciesResult{ParserName: "HH.ru"})
+	// буфер заполнен - второй результат пропускается, поиск не блокируется
+	onResult(models.SearchVacanciesResult{ParserName: "SuperJob"})
+
+	if got := <-progress; got.ParserName != "HH.ru" {
+		t.Errorf("ожидался результат HH.ru, получен %s", got.ParserName)
+	}
+	select {
+	case got := <-progress:
+		t.Errorf("результат %s не должен был попасть в заполненный канал", got.ParserName)
+	default:
+	}
+}
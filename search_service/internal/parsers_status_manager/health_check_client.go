@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"search_service/configs"
+	"search_service/internal/search_interfaces"
 	"time"
 )
 
@@ -40,7 +41,8 @@ func NewHttpHealthCheckClient(conf *configs.HealthCheckConfig) *HttpHealthCheckC
 }
 
 // метод для выполнения тестовго запроса для проверки healthCheck
-func (h *HttpHealthCheckClient) CheckHealth(ctx context.Context, endpoint string) (time.Duration, bool, error) {
+// decorator (если не nil) добавляет к запросу заголовки и ключи источника
+func (h *HttpHealthCheckClient) CheckHealth(ctx context.Context, endpoint string, decorator search_interfaces.RequestDecorator) (time.Duration, bool, error) {
 	// создаём кнтекст с таймаутом для контроля времени запроса
 	reqCtx, cancel := context.WithTimeout(ctx, h.timeout)
 	defer cancel()
@@ -56,6 +58,11 @@ func (h *HttpHealthCheckClient) CheckHealth(ctx context.Context, endpoint string
 	// устанавливаем заголовок
 	req.Header.Set("User-Agent", "ParserHealthCheck/1.0")
 
+	// заголовки и ключи источника (могут заменить User-Agent по умолчанию)
+	if decorator != nil {
+		decorator.DecorateRequest(req)
+	}
+
 	// делаем запрос с помощью клиента
 	resp, err := h.client.Do(req)
 
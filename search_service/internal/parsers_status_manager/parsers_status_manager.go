@@ -14,10 +14,11 @@ import (
 // ParserStatusManager управляет статусами всех парсеров
 // ключ - это имя экземпляра парсера
 type ParserStatusManager struct {
-	parsersStats map[string]*search_interfaces.ParserStatus // мапа статусов парсеров
-	config       *configs.SearchServiceConfig               // конфиг
-	client       search_interfaces.HealthClient             // клиент для проверки
-	initComplete chan struct{}                              // Сигнал завершения инициализации
+	parsersStats map[string]*search_interfaces.ParserStatus    // мапа статусов парсеров
+	config       *configs.SearchServiceConfig                  // конфиг
+	client       search_interfaces.HealthClient                // клиент для проверки
+	decorators   map[string]search_interfaces.RequestDecorator // оформление запросов health check по имени парсера
+	initComplete chan struct{}                                 // Сигнал завершения инициализации
 	stopChan     chan struct{}
 	mu           sync.RWMutex
 	wg           sync.WaitGroup
@@ -29,6 +30,7 @@ func NewParserStatusManager(conf *configs.SearchServiceConfig, parsers ...search
 		parsersStats: make(map[string]*search_interfaces.ParserStatus),
 		config:       conf, // конфиг для коиента health check
 		client:       NewHttpHealthCheckClient(conf.HealthCheck),
+		decorators:   make(map[string]search_interfaces.RequestDecorator),
 		initComplete: make(chan struct{}),
 		stopChan:     make(chan struct{}),
 	}
@@ -43,6 +45,11 @@ func NewParserStatusManager(conf *configs.SearchServiceConfig, parsers ...search
 			HealthEndpoint: parser.GetHealthEndPoint(),
 			IsHealthy:      false, // Начальное состояние - не здоров
 		}
+
+		// если парсер добавляет к запросам заголовки и ключи - health check тоже должен их отправлять
+		if decorator, ok := parser.(search_interfaces.RequestDecorator); ok {
+			psm.decorators[parser.GetName()] = decorator
+		}
 	}
 
 	// Запускаем фоновую горутину для опроса
@@ -99,10 +106,10 @@ func (psm *ParserStatusManager) performHealthCheck() {
 	psm.mu.RLock()
 	for name, status := range psm.parsersStats {
 		wg.Add(1)
-		go func(n string, endpoint string) {
+		go func(n string, endpoint string, decorator search_interfaces.RequestDecorator) {
 			defer wg.Done()
 
-			_, healthy, err := psm.client.CheckHealth(context.Background(), endpoint)
+			_, healthy, err := psm.client.CheckHealth(context.Background(), endpoint, decorator)
 
 			// Определяем статус инициализации
 			initDone := true // После первой проверки считаем инициализированным
@@ -115,7 +122,7 @@ func (psm *ParserStatusManager) performHealthCheck() {
 				err:      err,
 			}
 
-		}(name, status.HealthEndpoint)
+		}(name, status.HealthEndpoint, psm.decorators[name])
 	}
 	psm.mu.RUnlock()
 
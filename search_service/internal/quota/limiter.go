@@ -0,0 +1,144 @@
+// квоты пользователей: сколько запросов поиска пользователь может сделать за минуту и за сутки
+// счётчики считаются в фиксированных окнах (текущая минута и текущие сутки по UTC)
+// и хранятся в памяти реплики или в Redis (общие для всех реплик)
+package quota
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+)
+
+// окна квот
+const (
+	minuteWindow = time.Minute
+	dayWindow    = 24 * time.Hour
+)
+
+// Counter - хранилище счётчиков квот
+// global_cache.Cache (Redis) подходит без адаптера
+type Counter interface {
+	// Incr атомарно увеличивает счётчик на 1 и возвращает новое значение, новому счётчику ставит время жизни
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+}
+
+// Decision - результат проверки квоты пользователя
+type Decision struct {
+	Allowed         bool
+	Limits          configs.QuotaLimits // лимиты пользователя (0 - без ограничения)
+	RemainingMinute int                 // сколько запросов осталось в текущей минуте (-1 - без лимита или не проверялось)
+	RemainingDay    int                 // сколько запросов осталось в текущих сутках (-1 - без лимита или не проверялось)
+	RetryAfter      time.Duration       // через сколько квота восстановится (только если запрос отклонён)
+}
+
+// Limiter - проверка квот пользователей
+type Limiter struct {
+	cfg     *configs.QuotaConfig
+	counter Counter
+	now     func() time.Time // текущее время (подменяется в тестах)
+}
+
+// конструктор для проверки квот
+func NewLimiter(cfg *configs.QuotaConfig, counter Counter) *Limiter {
+	if cfg == nil {
+		cfg = configs.DefaultQuotaConfig()
+	}
+	return &Limiter{
+		cfg:     cfg,
+		counter: counter,
+		now:     time.Now,
+	}
+}
+
+// Limits - метод получения лимитов пользователя по его ролям
+// если у пользователя есть роли из конфига - действует самый щедрый из их лимитов, иначе - лимиты по умолчанию
+func (l *Limiter) Limits(requester models.Requester) configs.QuotaLimits {
+	var limits configs.QuotaLimits
+	matched := false
+
+	for role, roleLimits := range l.cfg.Roles {
+		if !requester.HasRole(role) {
+			continue
+		}
+		if !matched {
+			limits = roleLimits
+			matched = true
+			continue
+		}
+		limits.PerMinute = generousLimit(limits.PerMinute, roleLimits.PerMinute)
+		limits.PerDay = generousLimit(limits.PerDay, roleLimits.PerDay)
+	}
+
+	if !matched {
+		return l.cfg.Default
+	}
+	return limits
+}
+
+// функция выбора более щедрого лимита (0 - без ограничения)
+func generousLimit(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return max(a, b)
+}
+
+// Allow - метод проверки и учёта запроса пользователя
+// запрос, отклонённый по минутной квоте, не расходует суточную
+// если хранилище счётчиков недоступно - запрос пропускается (квоты не должны ронять поиск)
+func (l *Limiter) Allow(ctx context.Context, requester models.Requester) Decision {
+	limits := l.Limits(requester)
+	decision := Decision{Allowed: true, Limits: limits, RemainingMinute: -1, RemainingDay: -1}
+
+	if !l.cfg.Enabled || requester.UserID == "" {
+		return decision
+	}
+
+	now := l.now()
+
+	if limits.PerMinute > 0 {
+		count, err := l.incr(ctx, requester.UserID, "minute", minuteWindow, now)
+		if err != nil {
+			log.Printf("⚠️ Квота пользователя %s не проверена: %v", requester.UserID, err)
+			return decision
+		}
+		decision.RemainingMinute = max(limits.PerMinute-int(count), 0)
+		if int(count) > limits.PerMinute {
+			decision.Allowed = false
+			decision.RetryAfter = untilWindowEnd(now, minuteWindow)
+			return decision
+		}
+	}
+
+	if limits.PerDay > 0 {
+		count, err := l.incr(ctx, requester.UserID, "day", dayWindow, now)
+		if err != nil {
+			log.Printf("⚠️ Квота пользователя %s не проверена: %v", requester.UserID, err)
+			return decision
+		}
+		decision.RemainingDay = max(limits.PerDay-int(count), 0)
+		if int(count) > limits.PerDay {
+			decision.Allowed = false
+			decision.RetryAfter = untilWindowEnd(now, dayWindow)
+		}
+	}
+
+	return decision
+}
+
+// метод увеличения счётчика пользователя в текущем окне
+// номер окна входит в ключ, поэтому новое окно начинается с нового счётчика, а старый истекает сам
+func (l *Limiter) incr(ctx context.Context, userID, name string, window time.Duration, now time.Time) (int64, error) {
+	key := fmt.Sprintf("%s:%s:%s:%d", l.cfg.KeyPrefix, strings.ToLower(userID), name, now.UnixNano()/int64(window))
+	return l.counter.Incr(ctx, key, untilWindowEnd(now, window))
+}
+
+// функция получения времени до конца текущего окна (окна отсчитываются от начала эпохи, сутки - по UTC)
+func untilWindowEnd(now time.Time, window time.Duration) time.Duration {
+	return window - time.Duration(now.UnixNano()%int64(window))
+}
@@ -0,0 +1,197 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"search_service/configs"
+	"search_service/internal/domain/models"
+)
+
+// счётчик, который всегда возвращает ошибку (недоступный Redis)
+type failingCounter struct{}
+
+func (failingCounter) Incr(context.Context, string, time.Duration) (int64, error) {
+	return 0, errors.New("connection refused")
+}
+
+// функция создания проверки квот с управляемым временем (для лимитера и счётчика в памяти)
+func newTestLimiter(cfg *configs.QuotaConfig, now *time.Time) *Limiter {
+	counter := NewMemoryCounter()
+	counter.now = func() time.Time { return *now }
+
+	limiter := NewLimiter(cfg, counter)
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func testQuotaConfig() *configs.QuotaConfig {
+	return &configs.QuotaConfig{
+		Enabled:   true,
+		KeyPrefix: "test",
+		Default:   configs.QuotaLimits{PerMinute: 2, PerDay: 3},
+		Roles: map[string]configs.QuotaLimits{
+			"premium": {PerMinute: 5, PerDay: 0},
+			"trial":   {PerMinute: 1, PerDay: 10},
+		},
+	}
+}
+
+// TestLimits проверяет выбор лимитов по ролям пользователя
+func TestLimits(t *testing.T) {
+	limiter := NewLimiter(testQuotaConfig(), NewMemoryCounter())
+
+	tests := []struct {
+		name  string
+		roles []string
+		want  configs.QuotaLimits
+	}{
+		{"без ролей", nil, configs.QuotaLimits{PerMinute: 2, PerDay: 3}},
+		{"неизвестная роль", []string{"user"}, configs.QuotaLimits{PerMinute: 2, PerDay: 3}},
+		{"роль из конфига", []string{"Trial"}, configs.QuotaLimits{PerMinute: 1, PerDay: 10}},
+		{"самый щедрый лимит", []string{"trial", "premium"}, configs.QuotaLimits{PerMinute: 5, PerDay: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := limiter.Limits(models.Requester{UserID: "u1", Roles: tt.roles})
+			if got != tt.want {
+				t.Errorf("Неверные лимиты. Ожидалось: %+v, получено: %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAllowMinuteQuota проверяет минутную квоту и её восстановление в следующей минуте
+func TestAllowMinuteQuota(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 15, 0, time.UTC)
+	limiter := newTestLimiter(testQuotaConfig(), &now)
+	user := models.Requester{UserID: "u1"}
+
+	for i := 0; i < 2; i++ {
+		if decision := limiter.Allow(context.Background(), user); !decision.Allowed {
+			t.Fatalf("Запрос %d должен быть разрешён", i+1)
+		}
+	}
+
+	decision := limiter.Allow(context.Background(), user)
+	if decision.Allowed {
+		t.Fatal("Запрос сверх минутной квоты должен быть отклонён")
+	}
+	if decision.RetryAfter != 45*time.Second {
+		t.Errorf("Неверный Retry-After. Ожидалось: 45s, получено: %v", decision.RetryAfter)
+	}
+	if decision.RemainingMinute != 0 {
+		t.Errorf("Неверный остаток минутной квоты: %d", decision.RemainingMinute)
+	}
+
+	// другой пользователь считается отдельно
+	if !limiter.Allow(context.Background(), models.Requester{UserID: "u2"}).Allowed {
+		t.Error("Квота одного пользователя не должна влиять на другого")
+	}
+
+	// в следующей минуте квота восстанавливается
+	now = now.Add(45 * time.Second)
+	decision = limiter.Allow(context.Background(), user)
+	if !decision.Allowed {
+		t.Fatal("В следующей минуте запрос должен быть разрешён")
+	}
+	if decision.RemainingDay != 0 {
+		t.Errorf("Отклонённый запрос не должен расходовать суточную квоту. Остаток: %d", decision.RemainingDay)
+	}
+}
+
+// TestAllowDayQuota проверяет суточную квоту
+func TestAllowDayQuota(t *testing.T) {
+	now := time.Date(2025, 3, 1, 23, 0, 0, 0, time.UTC)
+	limiter := newTestLimiter(testQuotaConfig(), &now)
+	user := models.Requester{UserID: "u1"}
+
+	for i := 0; i < 3; i++ {
+		if !limiter.Allow(context.Background(), user).Allowed {
+			t.Fatalf("Запрос %d должен быть разрешён", i+1)
+		}
+		now = now.Add(time.Minute)
+	}
+
+	decision := limiter.Allow(context.Background(), user)
+	if decision.Allowed {
+		t.Fatal("Запрос сверх суточной квоты должен быть отклонён")
+	}
+	if decision.RetryAfter != 57*time.Minute {
+		t.Errorf("Неверный Retry-After. Ожидалось: 57m, получено: %v", decision.RetryAfter)
+	}
+
+	// новые сутки (по UTC) - новая квота
+	now = now.Add(decision.RetryAfter)
+	if !limiter.Allow(context.Background(), user).Allowed {
+		t.Error("В новых сутках запрос должен быть разрешён")
+	}
+}
+
+// TestAllowUnlimited проверяет пользователей без ограничений и отключённые квоты
+func TestAllowUnlimited(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := testQuotaConfig()
+	limiter := newTestLimiter(cfg, &now)
+
+	premium := models.Requester{UserID: "u1", Roles: []string{"premium"}}
+	for i := 0; i < 5; i++ {
+		decision := limiter.Allow(context.Background(), premium)
+		if !decision.Allowed {
+			t.Fatalf("Запрос %d должен быть разрешён", i+1)
+		}
+		if decision.RemainingDay != -1 {
+			t.Errorf("Без суточного лимита остаток не считается, получено: %d", decision.RemainingDay)
+		}
+	}
+
+	// пользователь неизвестен - квоты не проверяются
+	for i := 0; i < 5; i++ {
+		if !limiter.Allow(context.Background(), models.Requester{}).Allowed {
+			t.Fatal("Запрос без пользователя не должен ограничиваться")
+		}
+	}
+
+	cfg.Enabled = false
+	for i := 0; i < 5; i++ {
+		if !limiter.Allow(context.Background(), models.Requester{UserID: "u2"}).Allowed {
+			t.Fatal("Отключённые квоты не должны ограничивать запросы")
+		}
+	}
+}
+
+// TestAllowCounterFailure проверяет, что недоступное хранилище счётчиков не блокирует поиск
+func TestAllowCounterFailure(t *testing.T) {
+	limiter := NewLimiter(testQuotaConfig(), failingCounter{})
+
+	if !limiter.Allow(context.Background(), models.Requester{UserID: "u1"}).Allowed {
+		t.Error("При недоступном хранилище счётчиков запрос должен быть разрешён")
+	}
+}
+
+// TestMemoryCounterExpiration проверяет истечение и удаление счётчиков в памяти
+func TestMemoryCounterExpiration(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	counter := NewMemoryCounter()
+	counter.now = func() time.Time { return now }
+
+	counter.Incr(context.Background(), "a", time.Second)
+	if count, _ := counter.Incr(context.Background(), "a", time.Second); count != 2 {
+		t.Errorf("Неверное значение счётчика. Ожидалось: 2, получено: %d", count)
+	}
+
+	now = now.Add(time.Second)
+	if count, _ := counter.Incr(context.Background(), "a", time.Second); count != 1 {
+		t.Errorf("Истёкший счётчик должен начинаться заново, получено: %d", count)
+	}
+
+	// истёкшие счётчики удаляются из памяти
+	now = now.Add(2 * memorySweepInterval)
+	counter.Incr(context.Background(), "b", time.Second)
+	if _, ok := counter.entries["a"]; ok {
+		t.Error("Истёкший счётчик должен быть удалён")
+	}
+}
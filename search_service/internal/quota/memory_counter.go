@@ -0,0 +1,65 @@
+package quota
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// как часто удалять истёкшие счётчики из памяти
+const memorySweepInterval = time.Minute
+
+// счётчик квоты в памяти
+type memoryEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// MemoryCounter - счётчики квот в памяти одной реплики
+// истёкшие счётчики удаляются при обращениях (не чаще раза в memorySweepInterval), фоновых горутин нет
+type MemoryCounter struct {
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+	now       func() time.Time // текущее время (подменяется в тестах)
+	mu        sync.Mutex
+}
+
+// конструктор для счётчиков квот в памяти
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Incr - метод увеличения счётчика на 1, новому (или истёкшему) счётчику ставится время жизни
+func (m *MemoryCounter) Incr(_ context.Context, key string, expiration time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweepLocked(now)
+
+	entry, ok := m.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		entry = memoryEntry{expiresAt: now.Add(expiration)}
+	}
+	entry.count++
+	m.entries[key] = entry
+
+	return entry.count, nil
+}
+
+// метод удаления истёкших счётчиков (вызывается под блокировкой)
+func (m *MemoryCounter) sweepLocked(now time.Time) {
+	if now.Sub(m.lastSweep) < memorySweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key, entry := range m.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(m.entries, key)
+		}
+	}
+}
@@ -1,9 +1,40 @@
 package search_interfaces
 
-import "time"
+import (
+	"search_service/internal/domain/models"
+	"time"
+)
 
-type CacheInterface interface {
-	GetItem(key string) (interface{}, bool)
-	AddItemWithTTL(key string, value interface{}, ttl time.Duration)
-	DeleteItem(key string)
+// CacheInterface - кэш значений типа V по строковому ключу
+type CacheInterface[V any] interface {
+	Get(key string) (V, bool)
+	Set(key string, value V, ttl time.Duration)
+	Delete(key string)
+}
+
+// StaleCacheInterface - кэш, который после TTL ещё какое-то время хранит значения как устаревшие (stale-while-revalidate)
+// необязательная возможность кэша: менеджер парсеров проверяет её через type assertion
+type StaleCacheInterface[V any] interface {
+	CacheInterface[V]
+	SetWithStale(key string, value V, ttl, staleTTL time.Duration)
+	GetStale(key string) (value V, fresh bool, ok bool)
+}
+
+// ShutdownCacheInterface - кэш, который нужно остановить вместе с сервисом (например, чтобы сохранить снимок на диск)
+// необязательная возможность кэша: менеджер парсеров проверяет её через type assertion
+type ShutdownCacheInterface interface {
+	Shutdown() error
+}
+
+// StatsCacheInterface - кэш, который ведёт статистику обращений (попадания, промахи, вытеснения)
+// необязательная возможность кэша: менеджер парсеров проверяет её через type assertion
+type StatsCacheInterface interface {
+	Stats() models.CacheStats
+}
+
+// PurgeCacheInterface - кэш, из которого можно удалять элементы по условию и очищать целиком
+// необязательная возможность кэша: менеджер парсеров проверяет её через type assertion
+type PurgeCacheInterface[V any] interface {
+	DeleteFunc(fn func(key string, value V) bool) int
+	Clear() int
 }
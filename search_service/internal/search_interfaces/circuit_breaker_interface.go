@@ -1,7 +1,10 @@
 package search_interfaces
 
+import "shared/circuitbreaker"
+
 // интерфейс для circuit breaker
 type CBInterface interface {
 	Execute(fn func() error) error
 	GetStats() (total, success, failure uint32)
+	State() circuitbreaker.State
 }
@@ -2,10 +2,17 @@ package search_interfaces
 
 import (
 	"context"
+	"net/http"
 	"time"
 )
 
 // HealthClient интерфейс для health checks
 type HealthClient interface {
-	CheckHealth(ctx context.Context, endpoint string) (time.Duration, bool, error)
+	CheckHealth(ctx context.Context, endpoint string, decorator RequestDecorator) (time.Duration, bool, error)
+}
+
+// RequestDecorator - необязательное расширение парсера: источник дополняет свои запросы заголовками и ключами API
+// health check должен уходить с теми же заголовками, что и поиск, иначе источник может отвечать 403
+type RequestDecorator interface {
+	DecorateRequest(req *http.Request)
 }
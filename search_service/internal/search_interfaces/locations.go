@@ -0,0 +1,14 @@
+package search_interfaces
+
+import "search_service/internal/domain/models"
+
+// LocationResolver - справочник локаций: переводит город, регион или страну из текста запроса в код источника
+type LocationResolver interface {
+	Resolve(catalog, text string) (models.Location, bool)
+}
+
+// LocationAwareParser - необязательное расширение парсера: источник переводит локацию в свои коды через справочник
+type LocationAwareParser interface {
+	LocationCatalog() string // какой справочник локаций использует источник ("" - передаёт локацию текстом)
+	SetLocationResolver(resolver LocationResolver)
+}
@@ -11,3 +11,15 @@ type Parser interface {
 	GetName() string
 	GetHealthEndPoint() string
 }
+
+// PagedParser - необязательное расширение парсера: источник сообщает, сколько всего вакансий и страниц он нашёл
+type PagedParser interface {
+	Parser
+	SearchVacanciesPage(ctx context.Context, params models.SearchParams) (models.SearchPage, error)
+}
+
+// LoadStatsParser - необязательное расширение парсера: парсер сообщает занятость своего семафора и состояние circuit breaker (для метрик)
+type LoadStatsParser interface {
+	Parser
+	LoadStats() models.ParserLoadStats
+}
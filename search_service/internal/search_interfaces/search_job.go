@@ -4,4 +4,6 @@ package search_interfaces
 type Job interface {
 	GetID() string
 	Complete(data interface{}, err error)
+	GetUserID() string // пользователь, поставивший джобу
+	GetPriority() int  // приоритет джобы в очереди
 }
@@ -0,0 +1,37 @@
+package converters
+
+import (
+	"search_service/internal/domain/models"
+	"search_service/internal/search_server/dto"
+)
+
+// конвертация статистики кэшей для DTO слоя
+func CacheStatsDomainToDTO(stats []models.CacheStats) dto.CachesStatsResponse {
+	response := dto.CachesStatsResponse{Caches: make([]dto.CacheStatsResponse, 0, len(stats))}
+
+	for _, s := range stats {
+		response.Caches = append(response.Caches, dto.CacheStatsResponse{
+			Name:       s.Name,
+			Available:  s.Available,
+			Hits:       s.Hits,
+			StaleHits:  s.StaleHits,
+			Misses:     s.Misses,
+			HitRatio:   s.HitRatio,
+			Evictions:  s.Evictions,
+			Expired:    s.Expired,
+			Entries:    s.Entries,
+			Bytes:      s.Bytes,
+			ShardSizes: s.ShardSizes,
+		})
+	}
+
+	return response
+}
+
+// конвертация результата очистки кэшей для DTO слоя
+func CachePurgeResultDomainToDTO(result models.CachePurgeResult) dto.CachePurgeResponse {
+	return dto.CachePurgeResponse{
+		Removed:     result.Removed,
+		Unsupported: result.Unsupported,
+	}
+}
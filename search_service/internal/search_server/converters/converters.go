@@ -3,17 +3,27 @@ package converters
 import (
 	"search_service/internal/domain/models"
 	"search_service/internal/search_server/dto"
-	"strconv"
 )
 
 // конвертация данных из DTO в доменную область
 // в параменте передаём значение, чтобы не модифицировать входные данные
 func SearchRequestDTOToParamsDomain(req dto.SearchRequest) models.SearchParams {
 	return models.SearchParams{
-		Text:    req.Query,
-		Country: req.Country,
-		PerPage: req.PerPage,
-		Page:    req.Page,
+		Text:     req.Query,
+		Country:  req.Country,
+		PerPage:  req.PerPage,
+		Page:     req.Page,
+		MaxPages: req.MaxPages,
+
+		SalaryFrom:          req.SalaryFrom,
+		OnlyWithSalary:      req.OnlyWithSalary,
+		Experience:          req.Experience,
+		Schedule:            req.Schedule,
+		Employment:          req.Employment,
+		PublishedWithinDays: req.PublishedWithinDays,
+		OrderBy:             req.OrderBy,
+
+		DisableDedup: req.DisableDedup,
 	}
 }
 
@@ -52,6 +62,13 @@ func SearchVacanciesResultDomainToDTO(domainResults []models.SearchVacanciesResu
 
 			sourceVacancies.Vacancies = append(sourceVacancies.Vacancies, vacanciesDTO...)
 			sourceVacancies.Count = len(sourceVacancies.Vacancies)
+
+			// данные для пагинации
+			sourceVacancies.Found = domainResult.Found
+			sourceVacancies.Pages = domainResult.Pages
+			sourceVacancies.Page = domainResult.Page
+			sourceVacancies.PagesFetched = domainResult.PagesFetched
+			sourceVacancies.Merged = domainResult.Merged
 		}
 
 		// Добавляем информацию о времени выполнения
@@ -64,22 +81,116 @@ func SearchVacanciesResultDomainToDTO(domainResults []models.SearchVacanciesResu
 
 		// Считаем общее количество
 		response.Total += sourceVacancies.Count
+		response.Found += domainResult.Found
+	}
+
+	return response
+}
+
+// конвертация общего списка вакансий по всем источникам для DTO слоя
+func MergedSearchResultDomainToDTO(domainResult models.MergedSearchResult) dto.MergedSearchResponse {
+	response := dto.MergedSearchResponse{
+		Vacancies: make([]dto.VacancyResponse, 0, len(domainResult.Vacancies)),
+		Total:     domainResult.Total,
+		Page:      domainResult.Page,
+		PerPage:   domainResult.PerPage,
+		Pages:     domainResult.Pages,
+		OrderBy:   domainResult.OrderBy,
+		Sources:   make(map[string]dto.SourceSummary),
+	}
+
+	for _, vacancy := range domainResult.Vacancies {
+		vacancyDTO := ConvertVacancyToDTO(vacancy.Vacancy)
+		vacancyDTO.Score = vacancy.Score
+		response.Vacancies = append(response.Vacancies, vacancyDTO)
+	}
+
+	for _, source := range domainResult.Sources {
+		response.Sources[source.ParserName] = sourceSummaryDomainToDTO(source)
+		response.Found += source.Found
+	}
+
+	return response
+}
+
+// конвертация результата одного источника для события потокового поиска
+func SearchStreamResultDomainToDTO(domainResult models.SearchVacanciesResult) dto.SearchStreamResult {
+	grouped := SearchVacanciesResultDomainToDTO([]models.SearchVacanciesResult{domainResult})
+
+	return dto.SearchStreamResult{
+		Source:          domainResult.ParserName,
+		SourceVacancies: grouped.Results[domainResult.ParserName],
+	}
+}
+
+// конвертация итога потокового поиска (результаты после объединения дублей) для DTO слоя
+func SearchStreamSummaryDomainToDTO(domainResults []models.SearchVacanciesResult) dto.SearchStreamSummary {
+	response := dto.SearchStreamSummary{
+		Sources: make(map[string]dto.SourceSummary),
+	}
+
+	for _, source := range domainResults {
+		summary := sourceSummaryDomainToDTO(source)
+		response.Sources[source.ParserName] = summary
+		response.Total += summary.Count
+		response.Found += source.Found
 	}
 
 	return response
 }
 
+// конвертация состояния асинхронного поиска для DTO слоя
+func SearchJobInfoDomainToDTO(info models.SearchJobInfo) dto.SearchJobResponse {
+	response := dto.SearchJobResponse{
+		ID:         info.ID,
+		Status:     info.Status,
+		CreatedAt:  formatTimestamp(info.CreatedAt),
+		StartedAt:  formatTimestamp(info.StartedAt),
+		FinishedAt: formatTimestamp(info.FinishedAt),
+	}
+
+	if info.Error != nil {
+		response.Error = info.Error.Error()
+	}
+	if info.Status == models.SearchJobDone {
+		result := SearchVacanciesResultDomainToDTO(info.Results)
+		response.Result = &result
+	}
+
+	return response
+}
+
+// Вспомогательная функция для сводки по одному источнику
+func sourceSummaryDomainToDTO(source models.SearchVacanciesResult) dto.SourceSummary {
+	summary := dto.SourceSummary{
+		Name:   getSourceName(source.ParserName),
+		Icon:   getSourceIcon(source.ParserName),
+		Count:  len(source.Vacancies),
+		Found:  source.Found,
+		Merged: source.Merged,
+	}
+	if source.Error != nil {
+		summary.HasError = true
+		summary.Error = source.Error.Error()
+	}
+	if source.Duration > 0 {
+		summary.Duration = formatDuration(source.Duration)
+	}
+	return summary
+}
+
 // Вспомогательная функция для конвертации одной вакансии
 func ConvertVacancyToDTO(vacancy models.Vacancy) dto.VacancyResponse {
 	dtoVacancy := dto.VacancyResponse{
 		ID:          vacancy.ID,
 		Job:         vacancy.Job,
 		Company:     vacancy.Company,
-		Salary:      formatSalary(vacancy.Salary, vacancy.Currency),
-		Currency:    vacancy.Currency,
+		Salary:      formatSalary(vacancy.Salary),
+		Currency:    vacancy.Salary.Currency,
 		Location:    vacancy.Location,
 		Experience:  formatExperience(vacancy.Experience),
 		Schedule:    formatSchedule(vacancy.Schedule),
+		Employment:  formatEmployment(vacancy.Employment),
 		URL:         vacancy.URL,
 		Description: vacancy.Description,
 		PublishedAt: formatPublishedAt(vacancy.PublishedAt),
@@ -88,6 +199,17 @@ func ConvertVacancyToDTO(vacancy models.Vacancy) dto.VacancyResponse {
 	// Заполняем информацию об источнике
 	dtoVacancy.Source.Name = getSourceName(vacancy.Source)
 	dtoVacancy.Source.Icon = getSourceIcon(vacancy.Source)
+	dtoVacancy.SalaryDetails = convertSalaryToDTO(vacancy.Salary)
+
+	// ссылки на ту же вакансию в других источниках
+	for _, source := range vacancy.Sources {
+		dtoVacancy.Sources = append(dtoVacancy.Sources, dto.VacancySourceLink{
+			Name: getSourceName(source.Source),
+			Icon: getSourceIcon(source.Source),
+			ID:   source.ID,
+			URL:  source.URL,
+		})
+	}
 
 	return dtoVacancy
 }
@@ -95,13 +217,34 @@ func ConvertVacancyToDTO(vacancy models.Vacancy) dto.VacancyResponse {
 // Функция для конвертации информации по вакансии с описанием
 func ConvertVacancyResultInfoDomainToDTO(resp models.SearchVacancyDetailesResult) dto.VacancyDetailsResponce {
 	dtoVacancyInfo := dto.VacancyDetailsResponce{
-		ID:          resp.ID,
-		Job:         resp.Name,
-		Company:     resp.Employer.Name,
-		Salary:      strconv.Itoa(resp.Salary.From),
-		Description: resp.Description,
-		URL:         resp.Url,
+		ID:            resp.ID,
+		Job:           resp.Name,
+		Company:       resp.Employer.Name,
+		Salary:        formatSalary(resp.Salary),
+		Description:   resp.Description,
+		URL:           resp.Url,
+		SalaryDetails: convertSalaryToDTO(resp.Salary),
 	}
 
 	return dtoVacancyInfo
 }
+
+// Вспомогательная функция для конвертации структурированной зарплаты (nil - зарплата не указана)
+func convertSalaryToDTO(salary models.Salary) *dto.SalaryResponse {
+	if !salary.Specified() {
+		return nil
+	}
+
+	period := salary.Period
+	if period == "" {
+		period = models.SalaryPeriodMonth
+	}
+
+	return &dto.SalaryResponse{
+		From:     salary.From,
+		To:       salary.To,
+		Currency: salary.Currency,
+		Gross:    salary.Gross,
+		Period:   period,
+	}
+}
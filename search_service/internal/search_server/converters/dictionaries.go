@@ -0,0 +1,76 @@
+package converters
+
+import (
+	"search_service/internal/domain/models"
+	"search_service/internal/search_server/dto"
+)
+
+// функция построения справочника из таблицы подписей на нужном языке
+func labelsDictionaryToDTO(name string, labels []label, lang string) dto.DictionaryResponse {
+	items := make([]dto.DictionaryItem, len(labels))
+	for i, l := range labels {
+		items[i] = dto.DictionaryItem{
+			ID:   l.code,
+			Name: localizedLabel(labels, l.code, lang),
+		}
+	}
+	return dto.DictionaryResponse{Name: name, Lang: lang, Items: items}
+}
+
+// ExperienceDictionaryToDTO - справочник опыта работы
+func ExperienceDictionaryToDTO(lang string) dto.DictionaryResponse {
+	return labelsDictionaryToDTO(dto.DictionaryExperience, experienceLabels, lang)
+}
+
+// SchedulesDictionaryToDTO - справочник графиков работы
+func SchedulesDictionaryToDTO(lang string) dto.DictionaryResponse {
+	return labelsDictionaryToDTO(dto.DictionarySchedules, scheduleLabels, lang)
+}
+
+// EmploymentDictionaryToDTO - справочник типов занятости
+func EmploymentDictionaryToDTO(lang string) dto.DictionaryResponse {
+	return labelsDictionaryToDTO(dto.DictionaryEmployment, employmentLabels, lang)
+}
+
+// CurrenciesDictionaryToDTO - справочник валют (коды - из конвертера валют сервиса)
+func CurrenciesDictionaryToDTO(codes []string, lang string) dto.DictionaryResponse {
+	items := make([]dto.DictionaryItem, len(codes))
+	for i, code := range codes {
+		items[i] = dto.DictionaryItem{
+			ID:     code,
+			Name:   localizedLabel(currencyLabels, code, lang),
+			Symbol: getCurrencySymbol(code),
+		}
+	}
+	return dto.DictionaryResponse{Name: dto.DictionaryCurrencies, Lang: lang, Items: items}
+}
+
+// SourcesDictionaryToDTO - справочник источников вакансий
+func SourcesDictionaryToDTO(sources []models.SourceInfo, lang string) dto.DictionaryResponse {
+	items := make([]dto.DictionaryItem, len(sources))
+	for i, source := range sources {
+		items[i] = dto.DictionaryItem{
+			ID:              source.Name,
+			Name:            getSourceName(source.Name),
+			Icon:            getSourceIcon(source.Name),
+			LocationCatalog: source.LocationCatalog,
+		}
+	}
+	return dto.DictionaryResponse{Name: dto.DictionarySources, Lang: lang, Items: items}
+}
+
+// LocationsDictionaryToDTO - справочник локаций источника (названия локаций - как у источника, подписи видов - на языке ответа)
+func LocationsDictionaryToDTO(locations []models.Location, lang string) dto.DictionaryResponse {
+	items := make([]dto.DictionaryItem, len(locations))
+	for i, location := range locations {
+		items[i] = dto.DictionaryItem{
+			ID:       location.Name, // в поиск передаётся название - каждый источник переведёт его в свой код
+			Name:     location.Name,
+			Code:     location.Code,
+			Kind:     location.Kind,
+			KindName: localizedLabel(locationKindLabels, location.Kind, lang),
+			Parent:   location.ParentCode,
+		}
+	}
+	return dto.DictionaryResponse{Name: dto.DictionaryLocations, Lang: lang, Items: items}
+}
@@ -0,0 +1,92 @@
+package converters
+
+import (
+	"search_service/internal/domain/models"
+	"strings"
+)
+
+// поддерживаемые языки подписей в ответах
+const (
+	LangRU = "ru"
+	LangEN = "en"
+)
+
+// подпись значения справочника на поддерживаемых языках
+type label struct {
+	code string
+	ru   string
+	en   string
+}
+
+// подписи опыта работы (в порядке выпадающего списка)
+var experienceLabels = []label{
+	{models.ExperienceNone, "Нет опыта", "No experience"},
+	{models.Experience1To3, "1-3 года", "1-3 years"},
+	{models.Experience3To6, "3-6 лет", "3-6 years"},
+	{models.ExperienceMoreThan6, "Более 6 лет", "More than 6 years"},
+}
+
+// подписи графика работы
+var scheduleLabels = []label{
+	{models.ScheduleFullDay, "Полный день", "Full day"},
+	{models.ScheduleShift, "Сменный график", "Shift schedule"},
+	{models.ScheduleFlexible, "Гибкий график", "Flexible schedule"},
+	{models.ScheduleRemote, "Удаленная работа", "Remote work"},
+	{models.ScheduleFlyInFlyOut, "Вахтовый метод", "Fly-in fly-out"},
+}
+
+// подписи типа занятости
+var employmentLabels = []label{
+	{models.EmploymentFull, "Полная занятость", "Full-time"},
+	{models.EmploymentPart, "Частичная занятость", "Part-time"},
+	{models.EmploymentProject, "Проектная работа", "Project work"},
+	{models.EmploymentVolunteer, "Волонтерство", "Volunteering"},
+	{models.EmploymentProbation, "Стажировка", "Internship"},
+}
+
+// названия валют (для валют без подписи выводится код)
+var currencyLabels = []label{
+	{"RUB", "Российский рубль", "Russian ruble"},
+	{"USD", "Доллар США", "US dollar"},
+	{"EUR", "Евро", "Euro"},
+	{"KZT", "Казахстанский тенге", "Kazakhstani tenge"},
+	{"BYN", "Белорусский рубль", "Belarusian ruble"},
+	{"UZS", "Узбекский сум", "Uzbekistani sum"},
+	{"UAH", "Украинская гривна", "Ukrainian hryvnia"},
+}
+
+// подписи видов локаций
+var locationKindLabels = []label{
+	{models.LocationCountry, "Страна", "Country"},
+	{models.LocationRegion, "Регион", "Region"},
+	{models.LocationCity, "Город", "City"},
+}
+
+// NormalizeLang - функция выбора языка подписей: поддерживаемый язык из параметра или заголовка Accept-Language, иначе - русский
+func NormalizeLang(lang, acceptLanguage string) string {
+	for _, candidate := range []string{lang, acceptLanguage} {
+		// "en-US,en;q=0.9" -> "en"
+		candidate = strings.ToLower(strings.TrimSpace(candidate))
+		if i := strings.IndexAny(candidate, "-_,;"); i >= 0 {
+			candidate = candidate[:i]
+		}
+		if candidate == LangRU || candidate == LangEN {
+			return candidate
+		}
+	}
+	return LangRU
+}
+
+// вспомогательная функция получения подписи значения на нужном языке (если подписи нет - возвращаем код)
+func localizedLabel(labels []label, code, lang string) string {
+	for _, l := range labels {
+		if l.code != code {
+			continue
+		}
+		if lang == LangEN {
+			return l.en
+		}
+		return l.ru
+	}
+	return code
+}
@@ -2,6 +2,7 @@ package converters
 
 import (
 	"fmt"
+	"search_service/internal/domain/models"
 	"strings"
 	"time"
 )
@@ -27,6 +28,7 @@ func getSourceIcon(source string) string {
 		"hh":       "https://hh.ru/favicon.ico",
 		"superjob": "https://www.superjob.ru/favicon.ico",
 		"habr":     "https://career.habr.com/favicon.ico",
+		"zarplata": "https://www.zarplata.ru/favicon.ico",
 	}
 
 	if icon, ok := iconMap[source]; ok {
@@ -35,12 +37,48 @@ func getSourceIcon(source string) string {
 	return ""
 }
 
-// вспомогательная функция форматирования поля зарплаты
-func formatSalary(salary *string, currency string) string {
-	if salary == nil || *salary == "" {
+// вспомогательная функция форматирования поля зарплаты ("от 150 000 ₽", "1 500 - 2 000 $ в час, до вычета налогов")
+func formatSalary(salary models.Salary) string {
+	if !salary.Specified() {
 		return "не указана"
 	}
-	return fmt.Sprintf("%s %s", *salary, getCurrencySymbol(currency))
+
+	var formatted string
+	switch {
+	case salary.From > 0 && salary.To > 0:
+		formatted = formatNumber(salary.From) + " - " + formatNumber(salary.To)
+	case salary.From > 0:
+		formatted = "от " + formatNumber(salary.From)
+	default:
+		formatted = "до " + formatNumber(salary.To)
+	}
+
+	if symbol := getCurrencySymbol(salary.Currency); symbol != "" {
+		formatted += " " + symbol
+	}
+
+	switch salary.Period {
+	case models.SalaryPeriodHour:
+		formatted += " в час"
+	case models.SalaryPeriodDay:
+		formatted += " в день"
+	case models.SalaryPeriodYear:
+		formatted += " в год"
+	}
+
+	if salary.Gross {
+		formatted += ", до вычета налогов"
+	}
+	return formatted
+}
+
+// вспомогательная функция разделения тысяч пробелами (1500000 -> "1 500 000")
+func formatNumber(num int) string {
+	if num >= 1000 {
+		// Рекурсивно обрабатываем тысячи и добавляем пробел
+		return formatNumber(num/1000) + " " + fmt.Sprintf("%03d", num%1000)
+	}
+	return fmt.Sprintf("%d", num)
 }
 
 // вспомогательная функция получения символа валюты
@@ -59,34 +97,17 @@ func getCurrencySymbol(currency string) string {
 
 // вспомогательная фукнция получения опыта
 func formatExperience(exp string) string {
-	// Маппинг кодов опыта в читаемый вид
-	experienceMap := map[string]string{
-		"noExperience": "Нет опыта",
-		"between1And3": "1-3 года",
-		"between3And6": "3-6 лет",
-		"moreThan6":    "Более 6 лет",
-	}
-
-	if formatted, ok := experienceMap[exp]; ok {
-		return formatted
-	}
-	return exp // возвращаем оригинал, если маппинга нет
+	return localizedLabel(experienceLabels, exp, LangRU)
 }
 
 // вспомогательная фукнция получения графика работы
 func formatSchedule(schedule string) string {
-	scheduleMap := map[string]string{
-		"fullDay":     "Полный день",
-		"shift":       "Сменный график",
-		"flexible":    "Гибкий график",
-		"remote":      "Удаленная работа",
-		"flyInFlyOut": "Вахтовый метод",
-	}
+	return localizedLabel(scheduleLabels, schedule, LangRU)
+}
 
-	if formatted, ok := scheduleMap[schedule]; ok {
-		return formatted
-	}
-	return schedule
+// вспомогательная фукнция получения типа занятости
+func formatEmployment(employment string) string {
+	return localizedLabel(employmentLabels, employment, LangRU)
 }
 
 // вспомогательная фукнция получения даты публикации
@@ -143,3 +164,11 @@ func formatDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%.1fs", d.Seconds())
 }
+
+// Вспомогательная функция для форматирования момента времени в RFC 3339 (нулевое время - пустая строка)
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
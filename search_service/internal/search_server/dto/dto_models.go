@@ -4,12 +4,34 @@ import "errors"
 
 // SearchRequest - DTO для входящего запроса
 type SearchRequest struct {
-	Query   string `json:"query" binding:"required,min=2,max=100"`
-	Country string `json:"country"`
-	PerPage int    `json:"per_page" binding:"min=1,max=100"`
-	Page    int    `json:"page" binding:"min=0"`
+	Query    string `json:"query" binding:"required,min=2,max=100"`
+	Country  string `json:"country"`
+	PerPage  int    `json:"per_page" binding:"min=1,max=100"`
+	Page     int    `json:"page" binding:"min=0"`
+	MaxPages int    `json:"max_pages" binding:"min=0,max=10"` // сколько страниц подряд запросить у каждого источника
+
+	// фильтры поиска (коды опыта, графика и занятости - как в справочниках HH.ru)
+	SalaryFrom          int    `json:"salary_from" binding:"min=0"`
+	OnlyWithSalary      bool   `json:"only_with_salary"`
+	Experience          string `json:"experience" binding:"omitempty,oneof=noExperience between1And3 between3And6 moreThan6"`
+	Schedule            string `json:"schedule" binding:"omitempty,oneof=fullDay shift flexible remote flyInFlyOut"`
+	Employment          string `json:"employment" binding:"omitempty,oneof=full part project volunteer probation"`
+	PublishedWithinDays int    `json:"published_within_days" binding:"min=0,max=365"`
+	OrderBy             string `json:"order_by" binding:"omitempty,oneof=relevance date salary_desc salary_asc"`
+
+	DisableDedup bool `json:"disable_dedup"` // не объединять одинаковые вакансии из разных источников
+
+	// вид ответа: grouped (по умолчанию) - вакансии сгруппированы по источникам, merged - один общий список,
+	// отсортированный по order_by (по умолчанию - по релевантности), page и per_page относятся к общему списку
+	View string `json:"view" binding:"omitempty,oneof=grouped merged"`
 }
 
+// виды ответа на поиск вакансий
+const (
+	ViewGrouped = "grouped"
+	ViewMerged  = "merged"
+)
+
 type SearchVacancyRequest struct {
 	VacancyID string `json:"vacancy_id"`
 	Source    string `json:"source"`
@@ -25,6 +47,7 @@ type VacancyResponse struct {
 	Location   string `json:"location"`
 	Experience string `json:"experience"` // "Нет опыта", "1-3 года"
 	Schedule   string `json:"schedule"`   // "Полный день", "Удаленная работа"
+	Employment string `json:"employment"` // "Полная занятость", "Частичная занятость"
 	Source     struct {
 		Name string `json:"name"` // "hh.ru", "SuperJob"
 		Icon string `json:"icon"` // URL иконки
@@ -32,12 +55,28 @@ type VacancyResponse struct {
 	URL         string `json:"url"`
 	Description string `json:"description"`
 	PublishedAt string `json:"published_at"` // "2 дня назад"
+
+	SalaryDetails *SalaryResponse `json:"salary_details,omitempty"` // структурированная зарплата (только если она указана)
+
+	Score float64 `json:"score,omitempty"` // оценка релевантности (только в общем списке)
+
+	// все источники, где найдена вакансия (только если она объединена с дублями из других источников)
+	Sources []VacancySourceLink `json:"sources,omitempty"`
+}
+
+// VacancySourceLink - ссылка на вакансию в одном из источников
+type VacancySourceLink struct {
+	Name string `json:"name"`
+	Icon string `json:"icon"`
+	ID   string `json:"id"`
+	URL  string `json:"url"`
 }
 
 // SearchVacanciesResponse - DTO для ответа с группировкой по источникам
 type SearchVacanciesResponse struct {
 	Results map[string]SourceVacancies `json:"results"`
-	Total   int                        `json:"total"`
+	Total   int                        `json:"total"` // сколько вакансий в этом ответе
+	Found   int                        `json:"found"` // сколько всего вакансий нашли источники (по их данным)
 }
 
 // DTO для ответа - расширенная информация по конкретной вакансии
@@ -48,6 +87,17 @@ type VacancyDetailsResponce struct {
 	Salary      string `json:"salary"` // "от 150 000 ₽" или "не указана"
 	Description string `json:"description"`
 	URL         string `json:"url"`
+
+	SalaryDetails *SalaryResponse `json:"salary_details,omitempty"` // структурированная зарплата (только если она указана)
+}
+
+// SalaryResponse - DTO структурированной зарплаты
+type SalaryResponse struct {
+	From     int    `json:"from,omitempty"`
+	To       int    `json:"to,omitempty"`
+	Currency string `json:"currency"` // код валюты ISO 4217
+	Gross    bool   `json:"gross"`    // true - до вычета налогов
+	Period   string `json:"period"`   // hour, day, month, year
 }
 
 // SourceVacancies - вакансии одного источника
@@ -59,6 +109,116 @@ type SourceVacancies struct {
 	HasError  bool              `json:"has_error"`
 	Error     string            `json:"error,omitempty"`
 	Duration  string            `json:"duration,omitempty"` // "1.2s"
+
+	// данные для пагинации по источнику
+	Found        int `json:"found"`         // сколько всего вакансий нашёл источник (0 - источник не сообщает)
+	Pages        int `json:"pages"`         // сколько всего страниц доступно у источника (0 - источник не сообщает)
+	Page         int `json:"page"`          // номер первой полученной страницы
+	PagesFetched int `json:"pages_fetched"` // сколько страниц подряд получено в этом ответе
+
+	Merged int `json:"merged"` // сколько вакансий источника объединено с такими же вакансиями других источников
+}
+
+// MergedSearchResponse - DTO для ответа одним общим списком по всем источникам
+type MergedSearchResponse struct {
+	Vacancies []VacancyResponse        `json:"vacancies"`
+	Total     int                      `json:"total"`    // сколько вакансий в общем списке
+	Found     int                      `json:"found"`    // сколько всего вакансий нашли источники (по их данным)
+	Page      int                      `json:"page"`     // номер страницы общего списка
+	PerPage   int                      `json:"per_page"` // размер страницы общего списка
+	Pages     int                      `json:"pages"`    // сколько всего страниц в общем списке
+	OrderBy   string                   `json:"order_by"` // как отсортирован список
+	Sources   map[string]SourceSummary `json:"sources"`
+}
+
+// SourceSummary - сводка по источнику для ответа общим списком
+type SourceSummary struct {
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Count    int    `json:"count"`  // сколько вакансий источника попало в общий список
+	Found    int    `json:"found"`  // сколько всего вакансий нашёл источник
+	Merged   int    `json:"merged"` // сколько вакансий объединено с такими же вакансиями других источников
+	HasError bool   `json:"has_error"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// события потокового поиска вакансий (Server-Sent Events)
+const (
+	StreamEventResult  = "result"  // результат одного источника
+	StreamEventSummary = "summary" // итог поиска по всем источникам (последнее событие)
+	StreamEventError   = "error"   // поиск не удался (последнее событие)
+)
+
+// SearchStreamResult - DTO события потокового поиска с результатом одного источника
+// вакансии приходят до объединения дублей между источниками (объединение - в итоге поиска)
+type SearchStreamResult struct {
+	Source string `json:"source"` // ключ источника (как в results обычного ответа)
+	SourceVacancies
+}
+
+// SearchStreamSummary - DTO итогового события потокового поиска
+type SearchStreamSummary struct {
+	Total   int                      `json:"total"` // сколько вакансий во всех источниках после объединения дублей
+	Found   int                      `json:"found"` // сколько всего вакансий нашли источники (по их данным)
+	Sources map[string]SourceSummary `json:"sources"`
+}
+
+// SearchJobResponse - DTO состояния асинхронного поиска
+type SearchJobResponse struct {
+	ID         string                   `json:"id"`
+	Status     string                   `json:"status"` // queued, running, done, failed, canceled
+	CreatedAt  string                   `json:"created_at"`
+	StartedAt  string                   `json:"started_at,omitempty"`
+	FinishedAt string                   `json:"finished_at,omitempty"`
+	Error      string                   `json:"error,omitempty"`
+	Result     *SearchVacanciesResponse `json:"result,omitempty"` // результаты поиска по источникам (только для done)
+}
+
+// справочники значений фильтров для клиента
+const (
+	DictionaryExperience = "experience"
+	DictionarySchedules  = "schedules"
+	DictionaryEmployment = "employment"
+	DictionaryCurrencies = "currencies"
+	DictionarySources    = "sources"
+	DictionaryLocations  = "locations"
+)
+
+// DictionaryItem - DTO значения справочника (для выпадающих списков фильтров)
+type DictionaryItem struct {
+	ID              string `json:"id"`                         // значение, которое нужно передать в запросе поиска
+	Name            string `json:"name"`                       // подпись на языке ответа
+	Symbol          string `json:"symbol,omitempty"`           // символ валюты
+	Icon            string `json:"icon,omitempty"`             // иконка источника
+	LocationCatalog string `json:"location_catalog,omitempty"` // справочник локаций источника
+	Code            string `json:"code,omitempty"`             // код локации у источника
+	Kind            string `json:"kind,omitempty"`             // вид локации: country, region, city
+	KindName        string `json:"kind_name,omitempty"`        // подпись вида локации
+	Parent          string `json:"parent,omitempty"`           // код родительской локации
+}
+
+// DictionaryResponse - DTO ответа со справочником
+type DictionaryResponse struct {
+	Name  string           `json:"name"`
+	Lang  string           `json:"lang"`
+	Items []DictionaryItem `json:"items"`
+}
+
+// LocationsRequest - DTO запроса справочника локаций (параметры строки запроса)
+type LocationsRequest struct {
+	Catalog string `form:"catalog" binding:"required"`                         // имя справочника (location_catalog источника)
+	Query   string `form:"q"`                                                  // начало названия
+	Kind    string `form:"kind" binding:"omitempty,oneof=country region city"` // вид локации
+	Parent  string `form:"parent"`                                             // код родительской локации
+	Limit   int    `form:"limit" binding:"min=0,max=500"`                      // сколько локаций вернуть (по умолчанию 50)
+}
+
+// метод нормализации запроса справочника локаций
+func (r *LocationsRequest) Normalize() {
+	if r.Limit == 0 {
+		r.Limit = 50
+	}
 }
 
 // метод валидации и нормализации данных из запроса поиска вакансий
@@ -78,6 +238,14 @@ func (r *SearchRequest) ValidateAndNormalize() error {
 		r.Page = 1
 	}
 
+	if r.MaxPages == 0 {
+		r.MaxPages = 1
+	}
+
+	if r.View == "" {
+		r.View = ViewGrouped
+	}
+
 	// Дополнительная валидация
 	if r.PerPage < 1 {
 		return errors.New("per_page must be positive")
@@ -100,3 +268,29 @@ func (r *SearchVacancyRequest) ValidateAndNormalize() error {
 	}
 	return nil
 }
+
+// CacheStatsResponse - DTO статистики одного кэша
+type CacheStatsResponse struct {
+	Name       string  `json:"name"`
+	Available  bool    `json:"available"` // false - кэш не ведёт статистику (кэш в Redis)
+	Hits       int64   `json:"hits"`
+	StaleHits  int64   `json:"stale_hits"`
+	Misses     int64   `json:"misses"`
+	HitRatio   float64 `json:"hit_ratio"`
+	Evictions  int64   `json:"evictions"` // вытеснено из-за лимитов размера
+	Expired    int64   `json:"expired"`   // удалено истёкших элементов
+	Entries    int     `json:"entries"`
+	Bytes      int64   `json:"bytes"` // примерный объём (0 - объём не ограничен и не считается)
+	ShardSizes []int   `json:"shard_sizes,omitempty"`
+}
+
+// CachesStatsResponse - DTO ответа со статистикой кэшей
+type CachesStatsResponse struct {
+	Caches []CacheStatsResponse `json:"caches"`
+}
+
+// CachePurgeResponse - DTO ответа на очистку кэшей
+type CachePurgeResponse struct {
+	Removed     map[string]int `json:"removed"`               // сколько элементов удалено из каждого кэша
+	Unsupported []string       `json:"unsupported,omitempty"` // кэши, которые не умеют такую очистку (кэши в Redis)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_server/converters"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddleware - middleware доступа к служебным эндпоинтам: только для пользователей с ролью admin (роли - из заголовков nginx)
+func (s *SearchHandler) AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !models.RequesterFromContext(c.Request.Context()).HasRole(models.RoleAdmin) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// метод получения статистики кэшей (GET /admin/caches)
+func (s *SearchHandler) ProcessCacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, converters.CacheStatsDomainToDTO(s.service.GetCacheStats()))
+}
+
+// метод удаления из кэшей результатов одного поиска (DELETE /admin/caches/searches/:hash)
+func (s *SearchHandler) ProcessPurgeCachedSearch(c *gin.Context) {
+	result := s.service.PurgeCachedSearch(c.Param("hash"))
+	c.JSON(http.StatusOK, converters.CachePurgeResultDomainToDTO(result))
+}
+
+// метод удаления из кэшей одной вакансии (DELETE /admin/caches/vacancies/:source/:id)
+func (s *SearchHandler) ProcessPurgeCachedVacancy(c *gin.Context) {
+	result := s.service.PurgeCachedVacancy(c.Param("source"), c.Param("id"))
+	c.JSON(http.StatusOK, converters.CachePurgeResultDomainToDTO(result))
+}
+
+// метод удаления из кэшей всех данных источника (DELETE /admin/caches/sources/:source)
+func (s *SearchHandler) ProcessPurgeCachedSource(c *gin.Context) {
+	result := s.service.PurgeCachedSource(c.Param("source"))
+	c.JSON(http.StatusOK, converters.CachePurgeResultDomainToDTO(result))
+}
+
+// метод полной очистки кэшей (DELETE /admin/caches)
+func (s *SearchHandler) ProcessFlushCaches(c *gin.Context) {
+	c.JSON(http.StatusOK, converters.CachePurgeResultDomainToDTO(s.service.FlushCaches()))
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"search_service/internal/search_server/converters"
+	"search_service/internal/search_server/dto"
+	"search_service/internal/search_server/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// метод обработки запроса справочника значений фильтров (GET /dictionaries/:name?lang=ru)
+// справочники меняются редко, поэтому готовые ответы кэшируются, а клиенту разрешается кэшировать их у себя
+func (s *SearchHandler) ProcessDictionaryRequest(c *gin.Context) {
+	name := c.Param("name")
+	lang := converters.NormalizeLang(c.Query("lang"), c.GetHeader("Accept-Language"))
+
+	// справочник локаций зависит от параметров запроса
+	var locationsReq dto.LocationsRequest
+	cacheKey := fmt.Sprintf("dictionary:%s:%s", name, lang)
+	if name == dto.DictionaryLocations {
+		if err := c.ShouldBindQuery(&locationsReq); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"Message": "invalid request", "error": err.Error()})
+			return
+		}
+		locationsReq.Normalize()
+		cacheKey = fmt.Sprintf("%s:%s:%s:%s:%s:%d", cacheKey, locationsReq.Catalog, locationsReq.Query, locationsReq.Kind, locationsReq.Parent, locationsReq.Limit)
+	}
+
+	// проверяем, нет ли готового ответа в кэше
+	if s.dictionaryCache != nil {
+		if response, ok := s.dictionaryCache.Get(cacheKey); ok {
+			s.writeDictionary(c, response)
+			return
+		}
+	}
+
+	var response dto.DictionaryResponse
+	switch name {
+	case dto.DictionaryExperience:
+		response = converters.ExperienceDictionaryToDTO(lang)
+	case dto.DictionarySchedules:
+		response = converters.SchedulesDictionaryToDTO(lang)
+	case dto.DictionaryEmployment:
+		response = converters.EmploymentDictionaryToDTO(lang)
+	case dto.DictionaryCurrencies:
+		response = converters.CurrenciesDictionaryToDTO(s.service.GetCurrencies(), lang)
+	case dto.DictionarySources:
+		response = converters.SourcesDictionaryToDTO(s.service.GetSources(), lang)
+	case dto.DictionaryLocations:
+		locations, err := s.service.GetLocations(locationsReq)
+		if err != nil {
+			if errors.Is(err, service.ErrUnknownLocationCatalog) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response = converters.LocationsDictionaryToDTO(locations, lang)
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("unknown dictionary: %s", name)})
+		return
+	}
+
+	// сохраняем готовый ответ в кэш
+	if s.dictionaryCache != nil {
+		s.dictionaryCache.Set(cacheKey, response, s.dictionaryTTL)
+	}
+
+	s.writeDictionary(c, response)
+}
+
+// метод отправки справочника клиенту с заголовками кэширования и языка
+func (s *SearchHandler) writeDictionary(c *gin.Context, response dto.DictionaryResponse) {
+	if s.dictionaryTTL > 0 {
+		c.Header("Cache-Control", "public, max-age="+strconv.Itoa(int(s.dictionaryTTL.Seconds())))
+	}
+	c.Header("Vary", "Accept-Language") // язык ответа может зависеть от заголовка
+	c.Header("Content-Language", response.Lang)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaMiddleware - middleware проверки квот пользователя на запросы поиска
+// пользователь берётся из TrustedAuthMiddleware (userID), роли - из заголовков nginx
+// в ответ добавляются заголовки квот, при превышении - 429 с Retry-After (в секундах)
+func (s *SearchHandler) QuotaMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.quotaLimiter == nil {
+			c.Next()
+			return
+		}
+
+		decision := s.quotaLimiter.Allow(c.Request.Context(), requesterFromGin(c))
+
+		if decision.Limits.PerMinute > 0 {
+			c.Header("X-RateLimit-Limit-Minute", strconv.Itoa(decision.Limits.PerMinute))
+		}
+		if decision.RemainingMinute >= 0 {
+			c.Header("X-RateLimit-Remaining-Minute", strconv.Itoa(decision.RemainingMinute))
+		}
+		if decision.Limits.PerDay > 0 {
+			c.Header("X-RateLimit-Limit-Day", strconv.Itoa(decision.Limits.PerDay))
+		}
+		if decision.RemainingDay >= 0 {
+			c.Header("X-RateLimit-Remaining-Day", strconv.Itoa(decision.RemainingDay))
+		}
+
+		if !decision.Allowed {
+			retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":       "quota_exceeded",
+				"message":     "Request quota exceeded, try again later",
+				"retry_after": retryAfter,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
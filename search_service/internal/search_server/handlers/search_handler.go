@@ -4,21 +4,31 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"search_service/internal/domain/models"
+	"search_service/internal/quota"
+	"search_service/internal/search_interfaces"
 	"search_service/internal/search_server/converters"
 	"search_service/internal/search_server/dto"
 	"search_service/internal/search_server/service"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type SearchHandler struct {
-	service service.SearchServiceInterface // интерфейс сервисного слоя для поиска
+	service         service.SearchServiceInterface                           // интерфейс сервисного слоя для поиска
+	dictionaryCache search_interfaces.CacheInterface[dto.DictionaryResponse] // кэш готовых ответов справочников (nil - без кэша)
+	dictionaryTTL   time.Duration                                            // время жизни ответа справочника в кэше и у клиента
+	quotaLimiter    *quota.Limiter                                           // квоты пользователей на запросы поиска (nil - без квот)
 }
 
 // конструктор для создания поискового хэндлера
-func NewSearchHandler(service service.SearchServiceInterface) *SearchHandler {
+func NewSearchHandler(service service.SearchServiceInterface, dictionaryCache search_interfaces.CacheInterface[dto.DictionaryResponse], dictionaryTTL time.Duration, quotaLimiter *quota.Limiter) *SearchHandler {
 	return &SearchHandler{
-		service: service,
+		service:         service,
+		dictionaryCache: dictionaryCache,
+		dictionaryTTL:   dictionaryTTL,
+		quotaLimiter:    quotaLimiter,
 	}
 }
 
@@ -47,6 +57,12 @@ func (s *SearchHandler) ProcessMultisearchRequest(c *gin.Context) {
 	// Конвертация DTO -> Domain
 	params := converters.SearchRequestDTOToParamsDomain(req)
 
+	// ответ одним общим списком по всем источникам
+	if req.View == dto.ViewMerged {
+		s.processMergedSearch(c, params)
+		return
+	}
+
 	// запускаем комплексный метод поиска (идём в сервисный слой)
 	searchVacanciesResults, err := s.service.SearchVacancies(c, params)
 	if err != nil {
@@ -67,6 +83,24 @@ func (s *SearchHandler) ProcessMultisearchRequest(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// метод поиска вакансий с ответом одним общим отсортированным списком
+func (s *SearchHandler) processMergedSearch(c *gin.Context, params models.SearchParams) {
+	mergedResult, err := s.service.SearchVacanciesMerged(c, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Проверяем, что получили хоть какаеи данные после поиска
+	if len(mergedResult.Sources) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Failed to find vacancies"})
+		return
+	}
+
+	// Конвертация Domain -> DTO и отдаём результат клиенту
+	c.JSON(http.StatusOK, converters.MergedSearchResultDomainToDTO(mergedResult))
+}
+
 // метод обработки запроса одной вакансии из списка найденных по ID
 func (s *SearchHandler) ProcessQuickRequest(c *gin.Context) {
 	// Парсинг DTO запроса
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_server/converters"
+	"search_service/internal/search_server/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// метод постановки асинхронного поиска в очередь (POST /searches)
+// сразу отвечает ID поиска, состояние и результаты клиент забирает через GET /searches/:id
+// результаты всегда сгруппированы по источникам (view не учитывается)
+func (s *SearchHandler) ProcessStartSearchJob(c *gin.Context) {
+	// Парсинг DTO запроса
+	var req dto.SearchRequest
+
+	// парсим данные запроса из JSON в необходимую структуру
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	// проводим валидацию и нормализацию входных данных
+	if err := req.ValidateAndNormalize(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// поиск принадлежит пользователю, прошедшему авторизацию (по нему проверяется доступ к состоянию и отмене)
+	ctx := models.WithRequester(c.Request.Context(), requesterFromGin(c))
+
+	// Конвертация DTO -> Domain и постановка в очередь
+	info, err := s.service.StartSearchJob(ctx, converters.SearchRequestDTOToParamsDomain(req))
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Location", "/searches/"+info.ID)
+	c.JSON(http.StatusAccepted, converters.SearchJobInfoDomainToDTO(info))
+}
+
+// метод получения состояния и результатов асинхронного поиска (GET /searches/:id)
+func (s *SearchHandler) ProcessGetSearchJob(c *gin.Context) {
+	info, ok := s.service.GetSearchJob(c.Param("id"))
+	// чужой поиск не отличается от несуществующего
+	if !ok || info.UserID != c.GetString("userID") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "search job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, converters.SearchJobInfoDomainToDTO(info))
+}
+
+// метод отмены асинхронного поиска (DELETE /searches/:id)
+// незавершённый поиск прерывается, у завершённого - удаляются сохранённые результаты
+func (s *SearchHandler) ProcessCancelSearchJob(c *gin.Context) {
+	// отменить поиск может только поставивший его пользователь (владелец поиска не меняется)
+	owned, ok := s.service.GetSearchJob(c.Param("id"))
+	if !ok || owned.UserID != c.GetString("userID") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "search job not found"})
+		return
+	}
+
+	info, ok := s.service.CancelSearchJob(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "search job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, converters.SearchJobInfoDomainToDTO(info))
+}
+
+// функция получения пользователя запроса: userID от TrustedAuthMiddleware важнее заголовка X-User-ID, роли - из заголовков nginx
+func requesterFromGin(c *gin.Context) models.Requester {
+	requester := models.RequesterFromContext(c.Request.Context())
+	if userID := c.GetString("userID"); userID != "" {
+		requester.UserID = userID
+	}
+	return requester
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"search_service/internal/domain/models"
+	"search_service/internal/search_server/converters"
+	"search_service/internal/search_server/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// итог потокового поиска, который приходит из горутины поиска
+type streamOutcome struct {
+	results []models.SearchVacanciesResult
+	err     error
+}
+
+// метод потокового поиска вакансий (POST /multisearch/stream, Server-Sent Events)
+// результат каждого источника отправляется клиенту сразу, как только источник ответил (событие result),
+// последним приходит итог поиска после объединения дублей (событие summary) или ошибка (событие error)
+// результаты, как и в обычном поиске, кэшируются - повторный запрос отдаёт их сразу
+func (s *SearchHandler) ProcessMultisearchStream(c *gin.Context) {
+	// Парсинг DTO запроса
+	var req dto.SearchRequest
+
+	// парсим данные запроса из JSON в необходимую структуру
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"Message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	// проводим валидацию и нормализацию входных данных
+	if err := req.ValidateAndNormalize(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Конвертация DTO -> Domain (поток всегда отдаёт вакансии по источникам, view не учитывается)
+	params := converters.SearchRequestDTOToParamsDomain(req)
+
+	// канал буферизирован на число источников - поиск не ждёт, пока клиент прочитает событие
+	ctx := c.Request.Context()
+	progress := make(chan models.SearchVacanciesResult, len(s.service.GetSources()))
+	done := make(chan streamOutcome, 1)
+
+	go func() {
+		results, err := s.service.SearchVacanciesStream(ctx, params, progress)
+		done <- streamOutcome{results: results, err: err}
+	}()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // nginx не должен буферизировать поток
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case result := <-progress:
+			c.SSEvent(dto.StreamEventResult, converters.SearchStreamResultDomainToDTO(result))
+			return true
+
+		case outcome := <-done:
+			// результаты источников, которые пришли раньше итога, отправляем до него
+			s.flushStreamResults(c, progress)
+
+			switch {
+			case outcome.err != nil:
+				c.SSEvent(dto.StreamEventError, gin.H{"error": outcome.err.Error()})
+			case len(outcome.results) == 0:
+				c.SSEvent(dto.StreamEventError, gin.H{"error": "Failed to find vacancies"})
+			default:
+				c.SSEvent(dto.StreamEventSummary, converters.SearchStreamSummaryDomainToDTO(outcome.results))
+			}
+			return false
+
+		case <-ctx.Done():
+			// клиент отключился - поиск доработает в воркере и попадёт в кэш
+			return false
+		}
+	})
+}
+
+// метод отправки клиенту результатов источников, которые уже лежат в канале
+func (s *SearchHandler) flushStreamResults(c *gin.Context, progress <-chan models.SearchVacanciesResult) {
+	for {
+		select {
+		case result := <-progress:
+			c.SSEvent(dto.StreamEventResult, converters.SearchStreamResultDomainToDTO(result))
+		default:
+			return
+		}
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"search_service/internal/domain/models"
 	"search_service/internal/search_server/handlers"
 	"shared/config"
+	"shared/metrics"
 	"shared/middleware"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -28,13 +31,28 @@ func NewSearchServer(ctx context.Context, config *config.ServerConfig, handler *
 		return nil, err
 	}
 
+	// значения контекста запроса (пользователь, request_id) доступны и через *gin.Context, который хэндлеры передают как context.Context
+	router.ContextWithFallback = true
+
 	// Добавляем middleware для проброса контекста
 	router.Use(func(c *gin.Context) {
 		ctx := context.WithValue(c.Request.Context(), "request_id", c.GetHeader("X-Request-ID"))
+
+		// пользователь и его роли (от nginx) - для честной очереди поиска
+		ctx = models.WithRequester(ctx, models.Requester{
+			UserID: strings.TrimSpace(c.GetHeader("X-User-ID")),
+			Roles:  models.ParseRoles(c.GetHeader("X-User-Roles")),
+		})
+
 		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	})
 
+	// метрики HTTP запросов и эндпоинт для Prometheus (регистрируется до CORS и проверки авторизации: его опрашивает Prometheus напрямую,
+	// снаружи он закрыт в nginx)
+	router.Use(metrics.HTTPMiddleware())
+	router.GET("/metrics", metrics.Handler())
+
 	router.Use(middleware.CORSMiddleware())        // используем для всех маршруторв работу с CORS
 	router.Use(middleware.TrustedAuthMiddleware()) // проверяет, что nginx проверил токен через сервис авторизации
 
@@ -47,10 +65,26 @@ func NewSearchServer(ctx context.Context, config *config.ServerConfig, handler *
 
 // Метод для маршрутизации сервера
 func (s *VacancySearchServer) SetUpRoutes() {
-	s.router.GET("/hello", s.Handler.EchoSearchServer)                  // тестовый ендпоинт
-	s.router.POST("/multisearch", s.Handler.ProcessMultisearchRequest)  // эндпоинт поиска всех доступных вакансий из всех доступных источников (согласно строке поиска)
-	s.router.POST("/quickoverview", s.Handler.ProcessQuickRequest)      // эндпоинт получения краткой инфы по конкретной найденной вакансии
-	s.router.POST("/vac_details", s.Handler.ProcessDetailedVacancyInfo) // эндпоинт получения подробной инфы по конкретной вакансии (отдельный запрос на внешний сервис)
+	// квоты пользователей - только на запросы, которые нагружают источники вакансий
+	quota := s.Handler.QuotaMiddleware()
+
+	s.router.GET("/hello", s.Handler.EchoSearchServer)                              // тестовый ендпоинт
+	s.router.POST("/multisearch", quota, s.Handler.ProcessMultisearchRequest)       // эндпоинт поиска всех доступных вакансий из всех доступных источников (согласно строке поиска)
+	s.router.POST("/multisearch/stream", quota, s.Handler.ProcessMultisearchStream) // потоковый поиск (Server-Sent Events): результаты источников по мере готовности, затем итог
+	s.router.POST("/searches", quota, s.Handler.ProcessStartSearchJob)              // асинхронный поиск: ставит поиск в очередь и сразу отвечает его ID
+	s.router.GET("/searches/:id", s.Handler.ProcessGetSearchJob)                    // состояние асинхронного поиска (queued, running, done, failed, canceled) и результаты
+	s.router.DELETE("/searches/:id", s.Handler.ProcessCancelSearchJob)              // отмена асинхронного поиска (или удаление результатов завершённого)
+	s.router.POST("/quickoverview", s.Handler.ProcessQuickRequest)                  // эндпоинт получения краткой инфы по конкретной найденной вакансии
+	s.router.POST("/vac_details", quota, s.Handler.ProcessDetailedVacancyInfo)      // эндпоинт получения подробной инфы по конкретной вакансии (отдельный запрос на внешний сервис)
+	s.router.GET("/dictionaries/:name", s.Handler.ProcessDictionaryRequest)         // справочники значений фильтров: experience, schedules, employment, currencies, sources, locations
+
+	// служебные эндпоинты кэшей поиска, обратного индекса и деталей вакансий (только для роли admin)
+	admin := s.router.Group("/admin", s.Handler.AdminMiddleware())
+	admin.GET("/caches", s.Handler.ProcessCacheStats)                                  // статистика кэшей: попадания, промахи, вытеснения, размеры шардов
+	admin.DELETE("/caches", s.Handler.ProcessFlushCaches)                              // полная очистка кэшей
+	admin.DELETE("/caches/searches/:hash", s.Handler.ProcessPurgeCachedSearch)         // удаление результатов одного поиска
+	admin.DELETE("/caches/vacancies/:source/:id", s.Handler.ProcessPurgeCachedVacancy) // удаление одной вакансии
+	admin.DELETE("/caches/sources/:source", s.Handler.ProcessPurgeCachedSource)        // удаление всех данных источника
 }
 
 // Метод для запуска сервера
@@ -0,0 +1,28 @@
+package service
+
+import "search_service/internal/domain/models"
+
+// метод сервисного слоя для получения статистики кэшей поиска, обратного индекса и деталей вакансий
+func (s *SearchService) GetCacheStats() []models.CacheStats {
+	return s.searchManager.CacheStats()
+}
+
+// метод сервисного слоя для удаления из кэшей результатов одного поиска (по хэшу поиска)
+func (s *SearchService) PurgeCachedSearch(searchHash string) models.CachePurgeResult {
+	return s.searchManager.PurgeSearchHash(searchHash)
+}
+
+// метод сервисного слоя для удаления из кэшей одной вакансии (по источнику и ID)
+func (s *SearchService) PurgeCachedVacancy(source, vacancyID string) models.CachePurgeResult {
+	return s.searchManager.PurgeVacancy(source, vacancyID)
+}
+
+// метод сервисного слоя для удаления из кэшей всех данных источника
+func (s *SearchService) PurgeCachedSource(source string) models.CachePurgeResult {
+	return s.searchManager.PurgeSource(source)
+}
+
+// метод сервисного слоя для полной очистки кэшей
+func (s *SearchService) FlushCaches() models.CachePurgeResult {
+	return s.searchManager.FlushCaches()
+}
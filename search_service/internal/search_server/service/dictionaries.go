@@ -0,0 +1,31 @@
+package service
+
+import (
+	"fmt"
+
+	"search_service/internal/domain/models"
+	"search_service/internal/search_server/dto"
+)
+
+// метод сервисного слоя для получения описания доступных источников вакансий
+func (s *SearchService) GetSources() []models.SourceInfo {
+	return s.searchManager.GetSources()
+}
+
+// метод сервисного слоя для получения кодов валют, которые умеет сравнивать сервис (базовая - первая)
+func (s *SearchService) GetCurrencies() []string {
+	return s.searchManager.CurrencyConverter().Currencies()
+}
+
+// метод сервисного слоя для поиска локаций в справочнике источника
+func (s *SearchService) GetLocations(req dto.LocationsRequest) ([]models.Location, error) {
+	if s.locations == nil {
+		return nil, fmt.Errorf("locations dictionary is not available")
+	}
+
+	found, ok := s.locations.Search(req.Catalog, req.Query, req.Kind, req.Parent, req.Limit)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLocationCatalog, req.Catalog)
+	}
+	return found, nil
+}
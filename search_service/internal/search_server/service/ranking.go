@@ -0,0 +1,144 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"search_service/internal/currency"
+	"search_service/internal/domain/models"
+	"search_service/pkg"
+)
+
+// веса составляющих оценки релевантности (в сумме - 1)
+const (
+	relevanceTitleWeight     = 0.6  // совпадение названия вакансии с текстом запроса
+	relevanceFreshnessWeight = 0.25 // свежесть публикации
+	relevanceSalaryWeight    = 0.15 // указана ли зарплата
+)
+
+// за какое время свежесть вакансии падает до нуля
+const relevanceFreshnessWindow = 30 * 24 * time.Hour
+
+// функция объединения вакансий всех источников в один список, отсортированный по orderBy
+// порядок не зависит от времени запроса и от того, какой источник ответил первым,
+// поэтому страницы одного и того же (закэшированного) поиска всегда стыкуются
+// зарплаты сравниваются за месяц в базовой валюте конвертера
+func mergeAndRank(results []models.SearchVacanciesResult, text, orderBy string, converter *currency.Converter) []models.RankedVacancy {
+	var ranked []models.RankedVacancy
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		for _, vacancy := range result.Vacancies {
+			ranked = append(ranked, models.RankedVacancy{Vacancy: vacancy})
+		}
+	}
+
+	// свежесть считаем относительно самой свежей вакансии в выдаче, а не текущего времени
+	var newest time.Time
+	for _, vacancy := range ranked {
+		if vacancy.PublishedAt.After(newest) {
+			newest = vacancy.PublishedAt
+		}
+	}
+
+	terms := pkg.NormalizedWords(text)
+	for i := range ranked {
+		ranked[i].Score = relevanceScore(ranked[i].Vacancy, terms, newest)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+
+		switch orderBy {
+		case models.OrderDate:
+			if !a.PublishedAt.Equal(b.PublishedAt) {
+				return a.PublishedAt.After(b.PublishedAt)
+			}
+		case models.OrderSalaryDesc, models.OrderSalaryAsc:
+			salaryA, salaryB := converter.MonthlyInBase(a.Salary), converter.MonthlyInBase(b.Salary)
+			if salaryA != salaryB {
+				// вакансии без зарплаты - всегда в конце списка
+				if salaryA == 0 || salaryB == 0 {
+					return salaryB == 0
+				}
+				if orderBy == models.OrderSalaryAsc {
+					return salaryA < salaryB
+				}
+				return salaryA > salaryB
+			}
+		}
+
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.ID < b.ID
+	})
+
+	return ranked
+}
+
+// функция оценки релевантности вакансии (от 0 до 1)
+func relevanceScore(vacancy models.Vacancy, terms []string, newest time.Time) float64 {
+	score := relevanceTitleWeight * titleMatchScore(vacancy.Job, terms)
+	score += relevanceFreshnessWeight * freshnessScore(vacancy.PublishedAt, newest)
+	if vacancy.Salary.Specified() {
+		score += relevanceSalaryWeight
+	}
+	return score
+}
+
+// функция оценки совпадения названия вакансии с запросом: доля слов запроса, найденных в названии
+// (слово названия может начинаться со слова запроса: "разработчик" подходит под "разраб")
+func titleMatchScore(title string, terms []string) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+
+	titleWords := pkg.NormalizedWords(title)
+	matched := 0
+	for _, term := range terms {
+		for _, word := range titleWords {
+			if strings.HasPrefix(word, term) {
+				matched++
+				break
+			}
+		}
+	}
+	return float64(matched) / float64(len(terms))
+}
+
+// функция оценки свежести: 1 - самая свежая вакансия выдачи, 0 - старше окна свежести или без даты
+func freshnessScore(publishedAt, newest time.Time) float64 {
+	if publishedAt.IsZero() || newest.IsZero() {
+		return 0
+	}
+
+	age := newest.Sub(publishedAt)
+	if age >= relevanceFreshnessWindow {
+		return 0
+	}
+	return 1 - float64(age)/float64(relevanceFreshnessWindow)
+}
+
+// функция выдачи нужной страницы общего списка (нумерация страниц с 1)
+func paginateRanked(ranked []models.RankedVacancy, page, perPage int) []models.RankedVacancy {
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * perPage
+	if perPage <= 0 || start >= len(ranked) {
+		return []models.RankedVacancy{}
+	}
+
+	end := start + perPage
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+	return ranked[start:end]
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+	"search_service/configs"
+	"search_service/internal/currency"
+	"search_service/internal/domain/models"
+	"testing"
+	"time"
+)
+
+func rankingTestResults() []models.SearchVacanciesResult {
+	now := time.Date(2024, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	return []models.SearchVacanciesResult{
+		{
+			ParserName: "SuperJob",
+			Vacancies: []models.Vacancy{
+				{ID: "sj1", Source: "SuperJob", Job: "Менеджер по продажам", PublishedAt: now, Salary: models.Salary{From: 300000, Currency: "RUB"}},
+				{ID: "sj2", Source: "SuperJob", Job: "Go разработчик", PublishedAt: now.AddDate(0, 0, -40)},
+			},
+		},
+		{
+			ParserName: "HH.ru",
+			Vacancies: []models.Vacancy{
+				{ID: "1", Source: "HH.ru", Job: "Senior Go разработчик", PublishedAt: now, Salary: models.Salary{From: 250000, Currency: "RUB"}},
+				{ID: "2", Source: "HH.ru", Job: "Go-разработчик", PublishedAt: now.AddDate(0, 0, -1)},
+			},
+		},
+		{ParserName: "Habr Career", Error: errors.New("timeout")},
+	}
+}
+
+func rankedIDs(ranked []models.RankedVacancy) []string {
+	ids := make([]string, len(ranked))
+	for i, vacancy := range ranked {
+		ids[i] = vacancy.ID
+	}
+	return ids
+}
+
+func TestMergeAndRank(t *testing.T) {
+	tests := []struct {
+		name    string
+		orderBy string
+		want    []string
+	}{
+		{name: "по релевантности", orderBy: models.OrderRelevance, want: []string{"1", "2", "sj2", "sj1"}},
+		{name: "по дате", orderBy: models.OrderDate, want: []string{"1", "sj1", "2", "sj2"}},
+		{name: "по убыванию зарплаты", orderBy: models.OrderSalaryDesc, want: []string{"sj1", "1", "2", "sj2"}},
+		{name: "по возрастанию зарплаты", orderBy: models.OrderSalaryAsc, want: []string{"1", "sj1", "2", "sj2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankedIDs(mergeAndRank(rankingTestResults(), "go разраб", tt.orderBy, nil))
+			if len(got) != len(tt.want) {
+				t.Fatalf("ожидалось %v, получено %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ожидалось %v, получено %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeAndRank_StableOrder(t *testing.T) {
+	// порядок ответов источников не должен влиять на итоговый список
+	results := rankingTestResults()
+	reversed := []models.SearchVacanciesResult{results[2], results[1], results[0]}
+
+	a := rankedIDs(mergeAndRank(results, "менеджер", models.OrderRelevance, nil))
+	b := rankedIDs(mergeAndRank(reversed, "менеджер", models.OrderRelevance, nil))
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("порядок зависит от порядка источников: %v != %v", a, b)
+		}
+	}
+}
+
+func TestPaginateRanked(t *testing.T) {
+	ranked := mergeAndRank(rankingTestResults(), "go", models.OrderRelevance, nil)
+
+	if got := paginateRanked(ranked, 2, 3); len(got) != 1 || got[0].ID != ranked[3].ID {
+		t.Errorf("вторая страница: ожидалась одна вакансия %s, получено %v", ranked[3].ID, rankedIDs(got))
+	}
+	if got := paginateRanked(ranked, 3, 3); len(got) != 0 {
+		t.Errorf("страница за пределами списка должна быть пустой, получено %v", rankedIDs(got))
+	}
+}
+
+func TestMergeAndRank_SalaryInBaseCurrency(t *testing.T) {
+	converter, err := currency.NewConverter(&configs.CurrencyConfig{
+		BaseCurrency: "RUB",
+		Rates:        map[string]float64{"USD": 90},
+	})
+	if err != nil {
+		t.Fatalf("ошибка создания конвертера: %v", err)
+	}
+
+	results := []models.SearchVacanciesResult{{
+		ParserName: "Mixed",
+		Vacancies: []models.Vacancy{
+			{ID: "rub", Source: "Mixed", Salary: models.Salary{From: 250000, Currency: "RUB"}},
+			{ID: "usd", Source: "Mixed", Salary: models.Salary{From: 3000, Currency: "USD"}},                                   // 270 000 ₽
+			{ID: "hour", Source: "Mixed", Salary: models.Salary{From: 1000, Currency: "RUR", Period: models.SalaryPeriodHour}}, // 164 000 ₽
+		},
+	}}
+
+	got := rankedIDs(mergeAndRank(results, "", models.OrderSalaryDesc, converter))
+	want := []string{"usd", "rub", "hour"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ожидалось %v, получено %v", want, got)
+		}
+	}
+}
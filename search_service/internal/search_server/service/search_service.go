@@ -3,8 +3,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"search_service/internal/domain/models"
+	"search_service/internal/locations"
 	"search_service/internal/parsers_manager"
 	"search_service/internal/search_server/dto"
 )
@@ -12,20 +14,38 @@ import (
 // интерфейс поискового сервиса
 type SearchServiceInterface interface {
 	SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.SearchVacanciesResult, error)
+	SearchVacanciesMerged(ctx context.Context, params models.SearchParams) (models.MergedSearchResult, error)
+	SearchVacanciesStream(ctx context.Context, params models.SearchParams, progress chan<- models.SearchVacanciesResult) ([]models.SearchVacanciesResult, error)
+	StartSearchJob(ctx context.Context, params models.SearchParams) (models.SearchJobInfo, error)
+	GetSearchJob(id string) (models.SearchJobInfo, bool)
+	CancelSearchJob(id string) (models.SearchJobInfo, bool)
 	GetBriefVacancyDetails(getVacReq dto.SearchVacancyRequest) (models.Vacancy, error)
 	GetVacancyDetails(ctx context.Context, getVacReq dto.SearchVacancyRequest) (models.SearchVacancyDetailesResult, error)
+	GetSources() []models.SourceInfo
+	GetCurrencies() []string
+	GetLocations(req dto.LocationsRequest) ([]models.Location, error)
+	GetCacheStats() []models.CacheStats
+	PurgeCachedSearch(searchHash string) models.CachePurgeResult
+	PurgeCachedVacancy(source, vacancyID string) models.CachePurgeResult
+	PurgeCachedSource(source string) models.CachePurgeResult
+	FlushCaches() models.CachePurgeResult
 	StopServices(ctx context.Context)
 }
 
+// ошибка запроса справочника локаций, которого нет
+var ErrUnknownLocationCatalog = errors.New("unknown location catalog")
+
 // структура поискового сервиса
 type SearchService struct {
 	searchManager *parsers_manager.ParsersManager
+	locations     *locations.Dictionary // справочник локаций (обновляется в фоне, останавливается вместе с сервисом)
 }
 
 // конструктор поискового сервиса
-func NewSearchService(searchManager *parsers_manager.ParsersManager) *SearchService {
+func NewSearchService(searchManager *parsers_manager.ParsersManager, locationDictionary *locations.Dictionary) *SearchService {
 	return &SearchService{
 		searchManager: searchManager,
+		locations:     locationDictionary,
 	}
 }
 
@@ -40,6 +60,66 @@ func (s *SearchService) SearchVacancies(ctx context.Context, params models.Searc
 	return results, nil
 }
 
+// метод сервисного слоя для потокового поиска: результат каждого источника отправляется в progress, как только он готов
+// progress должен быть буферизирован на число источников (GetSources)
+func (s *SearchService) SearchVacanciesStream(ctx context.Context, params models.SearchParams, progress chan<- models.SearchVacanciesResult) ([]models.SearchVacanciesResult, error) {
+	results, err := s.searchManager.SearchVacanciesStream(ctx, params, progress)
+	if err != nil {
+		return []models.SearchVacanciesResult{}, err
+	}
+
+	return results, nil
+}
+
+// метод сервисного слоя для поиска вакансий с выдачей одного общего списка по всем источникам
+// Page и PerPage относятся к общему списку: у источников всегда запрашиваются первые MaxPages страниц,
+// поэтому все страницы общего списка строятся из одного и того же (закэшированного) поиска
+func (s *SearchService) SearchVacanciesMerged(ctx context.Context, params models.SearchParams) (models.MergedSearchResult, error) {
+	sourceParams := params
+	sourceParams.Page = 1
+
+	results, err := s.searchManager.SearchVacancies(ctx, sourceParams)
+	if err != nil {
+		return models.MergedSearchResult{}, err
+	}
+
+	orderBy := params.OrderBy
+	if orderBy == "" {
+		orderBy = models.OrderRelevance
+	}
+	ranked := mergeAndRank(results, params.Text, orderBy, s.searchManager.CurrencyConverter())
+
+	pages := 0
+	if params.PerPage > 0 {
+		pages = (len(ranked) + params.PerPage - 1) / params.PerPage
+	}
+
+	return models.MergedSearchResult{
+		Vacancies: paginateRanked(ranked, params.Page, params.PerPage),
+		Total:     len(ranked),
+		Page:      params.Page,
+		PerPage:   params.PerPage,
+		Pages:     pages,
+		OrderBy:   orderBy,
+		Sources:   results,
+	}, nil
+}
+
+// метод сервисного слоя для постановки асинхронного поиска в очередь (результаты клиент забирает потом по ID)
+func (s *SearchService) StartSearchJob(ctx context.Context, params models.SearchParams) (models.SearchJobInfo, error) {
+	return s.searchManager.StartSearchJob(ctx, params)
+}
+
+// метод сервисного слоя для получения состояния и результатов асинхронного поиска
+func (s *SearchService) GetSearchJob(id string) (models.SearchJobInfo, bool) {
+	return s.searchManager.GetSearchJob(id)
+}
+
+// метод сервисного слоя для отмены асинхронного поиска (у завершённого поиска удаляются результаты)
+func (s *SearchService) CancelSearchJob(id string) (models.SearchJobInfo, bool) {
+	return s.searchManager.CancelSearchJob(id)
+}
+
 // метод сервисного слоя для получения сжатой информации по конкретной вакансии из списка уже найденных по ID и сервису
 func (s *SearchService) GetBriefVacancyDetails(getVacReq dto.SearchVacancyRequest) (models.Vacancy, error) {
 	// создаём составной индекс, в котором будет ID вакансии и сервис, в котором этот ID нужно будет искать
@@ -50,47 +130,36 @@ func (s *SearchService) GetBriefVacancyDetails(getVacReq dto.SearchVacancyReques
 	var targetVacancy models.Vacancy
 
 	// пытаемся найти в кэше №2 данные по заданному ключу (составному индексу)
-	searchResIndex, ok := s.searchManager.VacancyIndex.GetItem(compositeID)
+	searchResIndex, ok := s.searchManager.GetVacancyIndex(compositeID)
 	if !ok {
 		return models.Vacancy{}, fmt.Errorf("No Vacancy with ID:%s was found in cache", getVacReq.VacancyID)
 	}
 
-	// проводим type assertion, проверяем нужный тип (так как нам функция GetItem возвращает интерфейс)
-	searchResIndexChecked, ok := searchResIndex.(models.VacancyIndex)
-	if !ok {
-		fmt.Println("Type assertion after GetVacancyDetails ---> failed!")
-		return models.Vacancy{}, fmt.Errorf("Type assertion after GetVacancyDetails ---> failed!\n")
-	}
-
 	// теперь из полученного из кэша индексов индекса мы можем найти нужный хэш запроса,
 	// чтобы потом по этому хэшу из кэша поиска найти нужную вакансию по ID
 
-	// пытаемся найти в кэше данные по заданному хэш ключу
-	searchRes, ok := s.searchManager.SearchCache.GetItem(searchResIndexChecked.SearchHash)
+	// пытаемся найти в кэше данные по заданному хэш ключу (устаревшие результаты тоже подходят - они ещё обновятся в фоне)
+	searchRes, ok := s.searchManager.GetCachedSearchResults(searchResIndex.SearchHash)
 	if ok {
-		// если можно получить данные из кэша, то получаем интерфейс.
-		// проводим type assertion, проверяем нужный тип
-		searchResChecked, ok := searchRes.([]models.SearchVacanciesResult)
-		if !ok {
-			return models.Vacancy{}, fmt.Errorf("Type assertion after multi-search ---> failed!\n")
-		}
-
-		for _, neededElementRes := range searchResChecked {
-			if neededElementRes.ParserName == getVacReq.Source {
+		for _, neededElementRes := range searchRes {
+			// вакансия могла быть объединена с дублем другого источника - тогда она лежит в результатах основного источника
+			if neededElementRes.ParserName == searchResIndex.ParserName {
 				for _, vacancyRes := range neededElementRes.Vacancies {
-					if vacancyRes.ID == getVacReq.VacancyID {
+					if vacancyRes.HasSourceID(getVacReq.Source, getVacReq.VacancyID) {
 						targetVacancy.ID = vacancyRes.ID
 						targetVacancy.Job = vacancyRes.Job
 						targetVacancy.Salary = vacancyRes.Salary
 						targetVacancy.Company = vacancyRes.Company
 						targetVacancy.Location = vacancyRes.Location
 						targetVacancy.URL = vacancyRes.URL
+						targetVacancy.Source = vacancyRes.Source
+						targetVacancy.Sources = vacancyRes.Sources
 					}
 				}
 			}
 		}
 	} else {
-		s.searchManager.VacancyIndex.DeleteItem(compositeID)
+		s.searchManager.VacancyIndex.Delete(compositeID)
 		return models.Vacancy{}, fmt.Errorf("Данные устарели, сделайте повторный запрос поиска всех доступных вакансий\n")
 	}
 
@@ -109,4 +178,9 @@ func (s *SearchService) GetVacancyDetails(ctx context.Context, getVacReq dto.Sea
 // метод для остановки всех воркеров
 func (s *SearchService) StopServices(ctx context.Context) {
 	s.searchManager.Shutdown()
+
+	// останавливаем обновление справочника локаций
+	if s.locations != nil {
+		s.locations.Stop()
+	}
 }
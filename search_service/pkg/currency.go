@@ -0,0 +1,12 @@
+package pkg
+
+import "strings"
+
+// функция приведения кода валюты к общему виду: ISO 4217 в верхнем регистре ("rur", "RUR", "rub" -> "RUB")
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "RUR" {
+		return "RUB" // устаревший код рубля, который до сих пор отдают HH.ru и Habr Career
+	}
+	return code
+}
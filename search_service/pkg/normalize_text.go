@@ -0,0 +1,14 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// функция разбиения текста на слова для сравнения: нижний регистр, ё -> е, всё кроме букв и цифр - разделители
+func NormalizedWords(s string) []string {
+	s = strings.ReplaceAll(strings.ToLower(s), "ё", "е")
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
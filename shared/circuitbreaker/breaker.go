@@ -2,6 +2,7 @@ package circuitbreaker
 
 import (
 	"errors"
+	"fmt"
 	"shared/config"
 	"sync"
 	"time"
@@ -16,6 +17,19 @@ const (
 	StateHalfOpen
 )
 
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
 var (
 	ErrCircuitOpen     = errors.New("circuit breaker is open")
 	ErrTooManyRequests = errors.New("too many requests in half-open state")
@@ -43,6 +57,9 @@ type CircuitBreaker struct {
 	totalRequests  uint32
 	totalSuccesses uint32
 	totalFailures  uint32
+
+	// вызывается при каждой смене состояния (под блокировкой, не должна обращаться к circuit breaker)
+	onStateChange func(from, to State)
 }
 
 func NewCircutBreaker(config config.CircuitBreakerConfig) *CircuitBreaker {
@@ -71,3 +88,11 @@ func NewCircutBreaker(config config.CircuitBreakerConfig) *CircuitBreaker {
 		state:               StateClosed,
 	}
 }
+
+// OnStateChange задаёт функцию, которая вызывается при каждой смене состояния (например, для метрик)
+// задаётся до начала работы circuit breaker; функция вызывается под блокировкой и не должна обращаться к нему
+func (cb *CircuitBreaker) OnStateChange(fn func(from, to State)) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.onStateChange = fn
+}
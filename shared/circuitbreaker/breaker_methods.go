@@ -20,7 +20,7 @@ func (cb *CircuitBreaker) Execute(fn func() error) error {
 			return ErrCircuitOpen
 		}
 		// Переходим в Half-Open
-		cb.state = StateHalfOpen
+		cb.setState(StateHalfOpen)
 		atomic.StoreUint32(&cb.halfOpenAttempts, 0)
 		atomic.StoreUint32(&cb.successes, 0)
 		// Продолжаем выполнение как Half-Open
@@ -120,7 +120,7 @@ func (cb *CircuitBreaker) onFailure() {
 	case StateClosed:
 		atomic.AddUint32(&cb.failures, 1)
 		if atomic.LoadUint32(&cb.failures) >= cb.failureThreshold {
-			cb.state = StateOpen
+			cb.setState(StateOpen)
 			cb.lastFailureTime = time.Now()
 			// Сбрасываем счетчик ошибок при переходе в Open
 			atomic.StoreUint32(&cb.failures, 0)
@@ -129,7 +129,7 @@ func (cb *CircuitBreaker) onFailure() {
 	// если circuit breaker - полуоткрыт (пробный режим)
 	case StateHalfOpen:
 		// При ошибке в Half-Open возвращаемся в Open
-		cb.state = StateOpen
+		cb.setState(StateOpen)
 		cb.lastFailureTime = time.Now()
 		atomic.StoreUint32(&cb.halfOpenAttempts, 0)
 		atomic.StoreUint32(&cb.successes, 0)
@@ -156,7 +156,7 @@ func (cb *CircuitBreaker) onSuccess() {
 		successes := atomic.AddUint32(&cb.successes, 1)
 		if successes >= cb.successThreshold {
 			// Переходим в Closed состояние
-			cb.state = StateClosed
+			cb.setState(StateClosed)
 			atomic.StoreUint32(&cb.failures, 0)
 			atomic.StoreUint32(&cb.successes, 0)
 			atomic.StoreUint32(&cb.halfOpenAttempts, 0)
@@ -169,6 +169,21 @@ func (cb *CircuitBreaker) onSuccess() {
 	}
 }
 
+// метод смены состояния с оповещением OnStateChange
+// мьютекс уже захвачен вызывающим кодом
+func (cb *CircuitBreaker) setState(state State) {
+	from := cb.state
+	cb.state = state
+	if cb.onStateChange != nil && from != state {
+		cb.onStateChange(from, state)
+	}
+}
+
+// State возвращает текущее состояние (для метрик и статистики нагрузки)
+func (cb *CircuitBreaker) State() State {
+	return cb.getState()
+}
+
 // GetState возвращает текущее состояние
 func (cb *CircuitBreaker) getState() State {
 	cb.mu.RLock()
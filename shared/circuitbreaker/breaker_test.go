@@ -275,6 +275,49 @@ func TestStateTransitionHalfOpenToOpen(t *testing.T) {
 	}
 }
 
+// TestOnStateChange проверяет оповещение о сменах состояния
+func TestOnStateChange(t *testing.T) {
+	config := config.NewCircuitBreakerConfig(
+		1,                   // failureThreshold
+		1,                   // successThreshold
+		5,                   // halfOpenMaxRequests
+		50*time.Millisecond, // resetTimeout
+		1*time.Second,       // windowDuration
+	)
+	cb := NewCircutBreaker(config)
+
+	var transitions []string
+	cb.OnStateChange(func(from, to State) {
+		transitions = append(transitions, from.String()+"->"+to.String())
+	})
+
+	// Closed -> Open
+	cb.Execute(func() error {
+		return errors.New("error")
+	})
+
+	// пока circuit breaker открыт, состояние не меняется
+	cb.Execute(func() error {
+		return nil
+	})
+
+	// Open -> Half-Open -> Closed
+	time.Sleep(60 * time.Millisecond)
+	cb.Execute(func() error {
+		return nil
+	})
+
+	expected := []string{"closed->open", "open->half_open", "half_open->closed"}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+		}
+	}
+}
+
 // TestHalfOpenMaxRequestsConcurrent проверяет ограничение запросов в Half-Open при конкурентном доступе
 func TestHalfOpenMaxRequestsConcurrent(t *testing.T) {
 	// Используем очень маленький resetTimeout для быстрого перехода в Half-Open
@@ -122,3 +122,31 @@ func TestCacheOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestStaleItems(t *testing.T) {
+	cache, _ := NewInmemoryShardedCache(4, time.Hour)
+
+	cache.AddItemWithStaleTTL("stale-key", "value", 30*time.Millisecond, time.Hour)
+
+	value, fresh, ok := cache.GetStaleItem("stale-key")
+	assert.True(t, ok)
+	assert.True(t, fresh)
+	assert.Equal(t, "value", value)
+
+	time.Sleep(50 * time.Millisecond)
+
+	// после ttl значение устарело: GetItem его не отдаёт, GetStaleItem - отдаёт как устаревшее
+	_, ok = cache.GetItem("stale-key")
+	assert.False(t, ok)
+
+	value, fresh, ok = cache.GetStaleItem("stale-key")
+	assert.True(t, ok)
+	assert.False(t, fresh)
+	assert.Equal(t, "value", value)
+
+	// значения без staleTTL после ttl не отдаются вовсе
+	cache.AddItemWithTTL("plain-key", "value", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	_, _, ok = cache.GetStaleItem("plain-key")
+	assert.False(t, ok)
+}
@@ -3,37 +3,46 @@ package inmemory_cache
 import "time"
 
 // метод для вызова интервальной очистки кэша или его остановки
-func (c *InmemoryShardedCache) cleanUp(ttl time.Duration) {
+func (c *ShardedCache[K, V]) cleanUp(interval time.Duration) {
 
-	// создаём тикер, который буедт через интервал времени ttl посылать в свой канал ticker.C текущую дату
-	ticker := time.NewTicker(ttl)
+	// создаём тикер, который буедт через интервал времени посылать в свой канал ticker.C текущую дату
+	ticker := time.NewTicker(interval)
 	// останавливаем тикер по выходу из функции
 	defer ticker.Stop()
 
-	// в этом селекте будем ждать одно из 2х событий
-	// 1. читаем из канала тикера --> запускаем метод очистки устаревших записей из кэша
-	select {
-	case <-ticker.C:
-		c.cleanUpExpired()
-	// 2. читаем из stopChan самого кэша, это значит мы останавливаем кэш и останавливаем логику очистки
-	case <-c.stopChan:
-		return
+	// в этом цикле будем ждать одно из 2х событий
+	for {
+		select {
+		// 1. читаем из канала тикера --> запускаем метод очистки устаревших записей из кэша
+		case <-ticker.C:
+			c.cleanUpExpired()
+		// 2. читаем из stopChan самого кэша, это значит мы закрываем кэш и останавливаем логику очистки
+		case <-c.stopChan:
+			return
+		}
 	}
 }
 
-//метод для очистки кэша от устаревших данных
-func (c *InmemoryShardedCache) cleanUpExpired() {
+// метод для очистки кэша от устаревших данных
+func (c *ShardedCache[K, V]) cleanUpExpired() {
 	// создаём переменную, в которой будет содержаться текущее время на момент вызова этой функции
 	start := time.Now()
 	// пробегаемся циклом по всм шардам
 	for _, shard := range c.shards {
+		var evicted []evictedEntry[K, V]
+
 		shard.mu.Lock()
-		for key, value := range shard.Items {
+		for elem := shard.lru.Front(); elem != nil; {
+			next := elem.Next()
 			// если текущее время - это время после времени жизни элемента кэша, то удаляем его
-			if start.After(value.expTime) {
-				delete(shard.Items, key)
+			if entry := elem.Value.(*cacheEntry[K, V]); start.After(entry.expTime) {
+				shard.removeLocked(elem)
+				evicted = append(evicted, evictedEntry[K, V]{key: entry.key, value: entry.value, reason: EvictionExpired})
 			}
+			elem = next
 		}
 		shard.mu.Unlock()
+
+		c.notifyEvicted(evicted)
 	}
 }
@@ -0,0 +1,439 @@
+package inmemory_cache
+
+import (
+	"container/list"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EvictionReason - причина удаления элемента из кэша (передаётся в OnEvict)
+type EvictionReason int
+
+const (
+	EvictionExpired  EvictionReason = iota // истекло время жизни элемента
+	EvictionCapacity                       // элемент вытеснен как давно не использованный: кэш упёрся в MaxEntries или MaxBytes
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictionExpired:
+		return "expired"
+	case EvictionCapacity:
+		return "capacity"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(r))
+	}
+}
+
+// ShardedCacheConfig - параметры шардированного кэша
+// лимиты делятся между шардами поровну, поэтому вытеснение начинается, когда переполнен шард, а не весь кэш
+type ShardedCacheConfig[K comparable, V any] struct {
+	NumShards       int           // количество шардов
+	CleanUpInterval time.Duration // интервал удаления истёкших элементов (0 - истёкшие удаляются только при обращении и вытеснении)
+	MaxEntries      int           // сколько элементов может храниться в кэше (0 - без ограничения)
+	MaxBytes        int64         // примерный объём памяти под элементы в байтах (0 - без ограничения)
+
+	// оценка размера элемента в байтах (nil - EstimateSize ключа и значения), используется только при MaxBytes > 0
+	SizeOf func(key K, value V) int64
+	// вызывается после удаления элемента по истечению времени жизни или при вытеснении (не вызывается при Delete и перезаписи)
+	// вызывается вне блокировок шарда, поэтому может обращаться к кэшу
+	OnEvict func(key K, value V, reason EvictionReason)
+}
+
+// ShardedCache - шардированный кэш с ограничением размера и вытеснением давно не использованных элементов (LRU внутри шарда)
+type ShardedCache[K comparable, V any] struct {
+	shards    []*shard[K, V]
+	numShards int
+
+	maxEntriesPerShard int
+	maxBytesPerShard   int64
+	sizeOf             func(key K, value V) int64
+	onEvict            func(key K, value V, reason EvictionReason)
+
+	stats cacheCounters
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+
+	snapshotPath     string        // файл снимка кэша (пусто - снимки не сохраняются)
+	snapshotStop     chan struct{} // останавливает периодические снимки
+	snapshotStopOnce sync.Once
+}
+
+// счётчики обращений и удалений кэша
+type cacheCounters struct {
+	hits      atomic.Int64
+	staleHits atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
+	expired   atomic.Int64
+}
+
+// CacheStats - статистика кэша с момента создания
+type CacheStats struct {
+	Hits       int64 // найдено свежее значение
+	StaleHits  int64 // найдено устаревшее значение (только GetStale)
+	Misses     int64 // значения нет, оно истекло или (для Get) устарело
+	Evictions  int64 // вытеснено давно не использованных элементов из-за лимитов
+	Expired    int64 // удалено истёкших элементов
+	Entries    int   // элементов в кэше сейчас
+	Bytes      int64 // примерный объём элементов (0, если MaxBytes не задан)
+	ShardSizes []int // элементов в каждом шарде
+}
+
+// HitRatio - доля обращений, на которые нашлось значение (вместе с устаревшими), 0 - обращений не было
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.StaleHits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.StaleHits) / float64(total)
+}
+
+// структура отдельного шарда: мапа для поиска по ключу и список элементов от недавно использованных к давно использованным
+type shard[K comparable, V any] struct {
+	items map[K]*list.Element
+	lru   *list.List
+	bytes int64 // примерный объём элементов шарда (считается только при MaxBytes > 0)
+	mu    sync.Mutex
+}
+
+// структура отдельного элемента кэша
+type cacheEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	freshUntil time.Time // до этого времени элемент свежий, после - устаревший (отдаётся только через GetStale)
+	expTime    time.Time // после этого времени элемент удаляется
+	size       int64
+}
+
+// удалённый из шарда элемент, о котором нужно сообщить в OnEvict (после снятия блокировки)
+type evictedEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// конструктор шардированного кэша
+func NewShardedCache[K comparable, V any](cfg ShardedCacheConfig[K, V]) (*ShardedCache[K, V], error) {
+	if cfg.NumShards <= 0 {
+		return nil, fmt.Errorf("numShards must be positive, got %d", cfg.NumShards)
+	}
+	if cfg.NumShards > 1000 {
+		return nil, fmt.Errorf("numShards is too large: %d", cfg.NumShards)
+	}
+	if cfg.CleanUpInterval < 0 {
+		return nil, fmt.Errorf("cleanUpInterval must be non-negative, got %v", cfg.CleanUpInterval)
+	}
+	if cfg.MaxEntries < 0 {
+		return nil, fmt.Errorf("maxEntries must be non-negative, got %d", cfg.MaxEntries)
+	}
+	if cfg.MaxBytes < 0 {
+		return nil, fmt.Errorf("maxBytes must be non-negative, got %d", cfg.MaxBytes)
+	}
+
+	c := &ShardedCache[K, V]{
+		shards:       make([]*shard[K, V], cfg.NumShards),
+		numShards:    cfg.NumShards,
+		onEvict:      cfg.OnEvict,
+		stopChan:     make(chan struct{}),
+		snapshotStop: make(chan struct{}),
+	}
+
+	// лимиты делим между шардами с округлением вверх, чтобы маленький лимит не превратился в ноль (без ограничения)
+	if cfg.MaxEntries > 0 {
+		c.maxEntriesPerShard = (cfg.MaxEntries + cfg.NumShards - 1) / cfg.NumShards
+	}
+	if cfg.MaxBytes > 0 {
+		c.maxBytesPerShard = (cfg.MaxBytes + int64(cfg.NumShards) - 1) / int64(cfg.NumShards)
+		c.sizeOf = cfg.SizeOf
+		if c.sizeOf == nil {
+			c.sizeOf = func(key K, value V) int64 {
+				return EstimateSize(key) + EstimateSize(value)
+			}
+		}
+	}
+
+	for i := range c.shards {
+		c.shards[i] = &shard[K, V]{
+			items: make(map[K]*list.Element),
+			lru:   list.New(),
+		}
+	}
+
+	if cfg.CleanUpInterval > 0 {
+		go c.cleanUp(cfg.CleanUpInterval)
+	}
+
+	return c, nil
+}
+
+// метод, чтобы находить нужный шард по заданному ключу
+func (c *ShardedCache[K, V]) getShard(key K) *shard[K, V] {
+	hashf := fnv.New32a()
+
+	var err error
+	switch k := any(key).(type) {
+	case string:
+		_, err = hashf.Write([]byte(k))
+	default:
+		_, err = fmt.Fprint(hashf, k)
+	}
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return c.shards[hashf.Sum32()%uint32(c.numShards)]
+}
+
+// метод получения свежего значения по ключу (найденный элемент становится недавно использованным)
+func (c *ShardedCache[K, V]) Get(key K) (V, bool) {
+	value, fresh, ok := c.lookup(key)
+	if !ok || !fresh {
+		c.stats.misses.Add(1)
+		var zeroVal V
+		return zeroVal, false
+	}
+	c.stats.hits.Add(1)
+	return value, true
+}
+
+// метод получения значения по ключу вместе с устаревшими (у которых истёк ttl, но не staleTTL)
+// fresh - значение ещё свежее, ok - значение найдено
+func (c *ShardedCache[K, V]) GetStale(key K) (value V, fresh bool, ok bool) {
+	value, fresh, ok = c.lookup(key)
+	switch {
+	case !ok:
+		c.stats.misses.Add(1)
+	case fresh:
+		c.stats.hits.Add(1)
+	default:
+		c.stats.staleHits.Add(1)
+	}
+	return value, fresh, ok
+}
+
+// метод поиска элемента по ключу (без учёта в статистике обращений): истёкший элемент сразу удаляется
+func (c *ShardedCache[K, V]) lookup(key K) (value V, fresh bool, ok bool) {
+	shard := c.getShard(key)
+	now := time.Now()
+
+	shard.mu.Lock()
+	elem, found := shard.items[key]
+	if !found {
+		shard.mu.Unlock()
+		return value, false, false
+	}
+
+	entry := elem.Value.(*cacheEntry[K, V])
+	if now.After(entry.expTime) {
+		shard.removeLocked(elem)
+		shard.mu.Unlock()
+		c.notifyEvicted([]evictedEntry[K, V]{{key: entry.key, value: entry.value, reason: EvictionExpired}})
+		return value, false, false
+	}
+
+	shard.lru.MoveToFront(elem)
+	value, fresh = entry.value, !now.After(entry.freshUntil)
+	shard.mu.Unlock()
+
+	return value, fresh, true
+}
+
+// метод записи значения в кэш с заданным TTL
+func (c *ShardedCache[K, V]) Set(key K, value V, ttl time.Duration) {
+	c.SetWithStale(key, value, ttl, 0)
+}
+
+// метод записи значения, которое после ttl ещё staleTTL хранится как устаревшее
+// Get отдаёт значение только первые ttl, GetStale - все ttl + staleTTL
+func (c *ShardedCache[K, V]) SetWithStale(key K, value V, ttl, staleTTL time.Duration) {
+	now := time.Now()
+	c.setEntry(key, value, now.Add(ttl), now.Add(ttl+staleTTL))
+}
+
+// метод записи элемента с готовым временем жизни (используется и при загрузке снимков)
+func (c *ShardedCache[K, V]) setEntry(key K, value V, freshUntil, expTime time.Time) {
+	var size int64
+	if c.sizeOf != nil {
+		size = c.sizeOf(key, value)
+	}
+
+	shard := c.getShard(key)
+
+	shard.mu.Lock()
+	if elem, ok := shard.items[key]; ok {
+		entry := elem.Value.(*cacheEntry[K, V])
+		shard.bytes += size - entry.size
+		entry.value, entry.freshUntil, entry.expTime, entry.size = value, freshUntil, expTime, size
+		shard.lru.MoveToFront(elem)
+	} else {
+		shard.items[key] = shard.lru.PushFront(&cacheEntry[K, V]{
+			key:        key,
+			value:      value,
+			freshUntil: freshUntil,
+			expTime:    expTime,
+			size:       size,
+		})
+		shard.bytes += size
+	}
+	evicted := c.evictLocked(shard)
+	shard.mu.Unlock()
+
+	c.notifyEvicted(evicted)
+}
+
+// метод вытеснения давно не использованных элементов, пока шард не уложится в лимиты (вызывается под блокировкой шарда)
+// элемент, который больше всего шарда, тоже вытесняется - иначе он занял бы шард целиком
+func (c *ShardedCache[K, V]) evictLocked(shard *shard[K, V]) []evictedEntry[K, V] {
+	var evicted []evictedEntry[K, V]
+	for shard.lru.Len() > 0 && c.overLimitLocked(shard) {
+		entry := shard.removeLocked(shard.lru.Back())
+		evicted = append(evicted, evictedEntry[K, V]{key: entry.key, value: entry.value, reason: EvictionCapacity})
+	}
+	return evicted
+}
+
+// метод проверки, превышает ли шард лимиты (вызывается под блокировкой шарда)
+func (c *ShardedCache[K, V]) overLimitLocked(shard *shard[K, V]) bool {
+	if c.maxEntriesPerShard > 0 && shard.lru.Len() > c.maxEntriesPerShard {
+		return true
+	}
+	return c.maxBytesPerShard > 0 && shard.bytes > c.maxBytesPerShard
+}
+
+// метод удаления элемента из шарда (вызывается под блокировкой шарда)
+func (s *shard[K, V]) removeLocked(elem *list.Element) *cacheEntry[K, V] {
+	entry := s.lru.Remove(elem).(*cacheEntry[K, V])
+	delete(s.items, entry.key)
+	s.bytes -= entry.size
+	return entry
+}
+
+// метод удаления элемента из кэша по ключу
+func (c *ShardedCache[K, V]) Delete(key K) {
+	shard := c.getShard(key)
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if elem, ok := shard.items[key]; ok {
+		shard.removeLocked(elem)
+	}
+}
+
+// метод удаления всех элементов, для которых fn вернула true (fn вызывается под блокировкой шарда и не должна обращаться к кэшу)
+// возвращает количество удалённых элементов; OnEvict, как и при Delete, не вызывается
+func (c *ShardedCache[K, V]) DeleteFunc(fn func(key K, value V) bool) int {
+	removed := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		for elem := shard.lru.Front(); elem != nil; {
+			next := elem.Next()
+			if entry := elem.Value.(*cacheEntry[K, V]); fn(entry.key, entry.value) {
+				shard.removeLocked(elem)
+				removed++
+			}
+			elem = next
+		}
+		shard.mu.Unlock()
+	}
+	return removed
+}
+
+// метод удаления всех элементов кэша (статистика обращений сохраняется), возвращает количество удалённых элементов
+func (c *ShardedCache[K, V]) Clear() int {
+	removed := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		removed += shard.lru.Len()
+		shard.items = make(map[K]*list.Element)
+		shard.lru.Init()
+		shard.bytes = 0
+		shard.mu.Unlock()
+	}
+	return removed
+}
+
+// метод получения количества элементов в кэше (вместе с устаревшими и ещё не удалёнными истёкшими)
+func (c *ShardedCache[K, V]) Len() int {
+	total := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		total += shard.lru.Len()
+		shard.mu.Unlock()
+	}
+	return total
+}
+
+// метод получения примерного объёма элементов кэша в байтах (0, если MaxBytes не задан)
+func (c *ShardedCache[K, V]) Bytes() int64 {
+	var total int64
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		total += shard.bytes
+		shard.mu.Unlock()
+	}
+	return total
+}
+
+// метод обхода неистёкших элементов кэша (шард блокируется на время его обхода, fn не должна обращаться к кэшу)
+func (c *ShardedCache[K, V]) rangeEntries(fn func(entry *cacheEntry[K, V])) {
+	now := time.Now()
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		for elem := shard.lru.Front(); elem != nil; elem = elem.Next() {
+			entry := elem.Value.(*cacheEntry[K, V])
+			if !now.After(entry.expTime) {
+				fn(entry)
+			}
+		}
+		shard.mu.Unlock()
+	}
+}
+
+// метод учёта удалённых элементов в статистике и оповещения OnEvict (вызывается без блокировок)
+func (c *ShardedCache[K, V]) notifyEvicted(evicted []evictedEntry[K, V]) {
+	for _, e := range evicted {
+		if e.reason == EvictionExpired {
+			c.stats.expired.Add(1)
+		} else {
+			c.stats.evictions.Add(1)
+		}
+		if c.onEvict != nil {
+			c.onEvict(e.key, e.value, e.reason)
+		}
+	}
+}
+
+// метод получения статистики кэша
+func (c *ShardedCache[K, V]) Stats() CacheStats {
+	stats := CacheStats{
+		Hits:       c.stats.hits.Load(),
+		StaleHits:  c.stats.staleHits.Load(),
+		Misses:     c.stats.misses.Load(),
+		Evictions:  c.stats.evictions.Load(),
+		Expired:    c.stats.expired.Load(),
+		ShardSizes: make([]int, len(c.shards)),
+	}
+
+	for i, shard := range c.shards {
+		shard.mu.Lock()
+		stats.ShardSizes[i] = shard.lru.Len()
+		stats.Bytes += shard.bytes
+		shard.mu.Unlock()
+		stats.Entries += stats.ShardSizes[i]
+	}
+
+	return stats
+}
+
+// Close останавливает фоновую очистку кэша, сам кэш продолжает работать (повторный вызов ничего не делает)
+func (c *ShardedCache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.stopChan)
+	})
+}
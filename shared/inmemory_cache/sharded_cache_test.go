@@ -0,0 +1,263 @@
+package inmemory_cache
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// удалённый элемент, о котором сообщил OnEvict
+type evictedKey struct {
+	key    int
+	reason EvictionReason
+}
+
+// функция создания кэша, который запоминает удалённые элементы
+func newRecordingCache(t *testing.T, cfg ShardedCacheConfig[int, string]) (*ShardedCache[int, string], func() []evictedKey) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var evicted []evictedKey
+	cfg.OnEvict = func(key int, value string, reason EvictionReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		evicted = append(evicted, evictedKey{key: key, reason: reason})
+	}
+
+	cache, err := NewShardedCache(cfg)
+	require.NoError(t, err)
+	t.Cleanup(cache.Close)
+
+	return cache, func() []evictedKey {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]evictedKey(nil), evicted...)
+	}
+}
+
+func TestShardedCacheLRUEviction(t *testing.T) {
+	cache, evicted := newRecordingCache(t, ShardedCacheConfig[int, string]{NumShards: 1, MaxEntries: 3})
+
+	cache.Set(1, "one", time.Minute)
+	cache.Set(2, "two", time.Minute)
+	cache.Set(3, "three", time.Minute)
+
+	// обращение к 1 делает его недавно использованным, поэтому вытесняется 2
+	_, ok := cache.Get(1)
+	require.True(t, ok)
+	cache.Set(4, "four", time.Minute)
+
+	_, ok = cache.Get(2)
+	assert.False(t, ok)
+	for _, key := range []int{1, 3, 4} {
+		_, ok := cache.Get(key)
+		assert.True(t, ok, "key %d", key)
+	}
+	assert.Equal(t, 3, cache.Len())
+	assert.Equal(t, []evictedKey{{key: 2, reason: EvictionCapacity}}, evicted())
+
+	// перезапись существующего ключа не вытесняет другие элементы
+	cache.Set(3, "three again", time.Minute)
+	assert.Equal(t, 3, cache.Len())
+	assert.Len(t, evicted(), 1)
+}
+
+func TestShardedCacheMaxBytes(t *testing.T) {
+	cache, evicted := newRecordingCache(t, ShardedCacheConfig[int, string]{
+		NumShards: 1,
+		MaxBytes:  250,
+		SizeOf:    func(key int, value string) int64 { return int64(len(value)) },
+	})
+
+	cache.Set(1, strings.Repeat("a", 100), time.Minute)
+	cache.Set(2, strings.Repeat("b", 100), time.Minute)
+	assert.Equal(t, int64(200), cache.Bytes())
+
+	// третье значение не помещается - вытесняется самое давнее
+	cache.Set(3, strings.Repeat("c", 100), time.Minute)
+	assert.Equal(t, int64(200), cache.Bytes())
+	_, ok := cache.Get(1)
+	assert.False(t, ok)
+
+	// значение больше всего кэша не хранится
+	cache.Set(4, strings.Repeat("d", 300), time.Minute)
+	_, ok = cache.Get(4)
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+	assert.Equal(t, int64(0), cache.Bytes())
+	assert.Len(t, evicted(), 4)
+}
+
+func TestShardedCacheLimitsSplitBetweenShards(t *testing.T) {
+	cache, err := NewShardedCache(ShardedCacheConfig[string, int]{NumShards: 4, MaxEntries: 100})
+	require.NoError(t, err)
+	defer cache.Close()
+
+	for i := 0; i < 1000; i++ {
+		cache.Set(fmt.Sprintf("key-%d", i), i, time.Minute)
+	}
+
+	assert.LessOrEqual(t, cache.Len(), 100)
+	assert.Greater(t, cache.Len(), 50)
+}
+
+func TestShardedCacheDelete(t *testing.T) {
+	cache, evicted := newRecordingCache(t, ShardedCacheConfig[int, string]{NumShards: 4})
+
+	for i := 0; i < 10; i++ {
+		cache.Set(i, fmt.Sprint(i), time.Minute)
+	}
+
+	// удаляется только указанный ключ
+	cache.Delete(5)
+	_, ok := cache.Get(5)
+	assert.False(t, ok)
+	assert.Equal(t, 9, cache.Len())
+
+	cache.Delete(100)
+	assert.Equal(t, 9, cache.Len())
+	assert.Empty(t, evicted(), "Delete не должен вызывать OnEvict")
+}
+
+func TestShardedCacheExpiration(t *testing.T) {
+	cache, evicted := newRecordingCache(t, ShardedCacheConfig[int, string]{NumShards: 2, CleanUpInterval: 10 * time.Millisecond})
+
+	cache.Set(1, "short", 20*time.Millisecond)
+	cache.SetWithStale(2, "stale", 20*time.Millisecond, time.Hour)
+	cache.Set(3, "long", time.Hour)
+
+	// самоочистка срабатывает не один раз, а на каждом тике
+	require.Eventually(t, func() bool { return cache.Len() == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []evictedKey{{key: 1, reason: EvictionExpired}}, evicted())
+
+	cache.Set(4, "another", 20*time.Millisecond)
+	require.Eventually(t, func() bool { return cache.Len() == 2 }, time.Second, 5*time.Millisecond)
+
+	// устаревший элемент не отдаётся через Get, но отдаётся через GetStale
+	_, ok := cache.Get(2)
+	assert.False(t, ok)
+	value, fresh, ok := cache.GetStale(2)
+	assert.True(t, ok)
+	assert.False(t, fresh)
+	assert.Equal(t, "stale", value)
+}
+
+func TestShardedCacheClose(t *testing.T) {
+	cache, err := NewShardedCache(ShardedCacheConfig[int, string]{NumShards: 1, CleanUpInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	cache.Close()
+	cache.Close() // повторный вызов безопасен
+
+	// после остановки самоочистки истёкшие элементы остаются в кэше, но не отдаются
+	cache.Set(1, "value", 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, cache.Len())
+	_, ok := cache.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len(), "истёкший элемент удаляется при обращении")
+}
+
+func TestShardedCacheInvalidConfig(t *testing.T) {
+	_, err := NewShardedCache(ShardedCacheConfig[int, string]{NumShards: 1, MaxEntries: -1})
+	assert.Error(t, err)
+
+	_, err = NewShardedCache(ShardedCacheConfig[int, string]{NumShards: 1, MaxBytes: -1})
+	assert.Error(t, err)
+}
+
+func TestEstimateSize(t *testing.T) {
+	type item struct {
+		Name string
+		Tags []string
+	}
+
+	small := EstimateSize(item{Name: "a"})
+	large := EstimateSize(item{Name: strings.Repeat("a", 1000), Tags: []string{strings.Repeat("b", 1000)}})
+	assert.Greater(t, large-small, int64(2000))
+
+	// циклические ссылки не зацикливают оценку
+	type node struct{ Next *node }
+	n := &node{}
+	n.Next = n
+	assert.Greater(t, EstimateSize(n), int64(0))
+}
+
+func TestInmemoryShardedCacheDeleteItem(t *testing.T) {
+	cache, err := NewInmemoryShardedCacheWithLimits(4, time.Minute, 0, 0)
+	require.NoError(t, err)
+	defer cache.Shutdown()
+
+	cache.AddItemWithTTL("keep", "value", time.Minute)
+	cache.AddItemWithTTL("drop", "value", time.Minute)
+
+	cache.DeleteItem("drop")
+
+	_, ok := cache.GetItem("drop")
+	assert.False(t, ok)
+	_, ok = cache.GetItem("keep")
+	assert.True(t, ok, "DeleteItem удалил чужой ключ")
+}
+
+func TestShardedCacheStats(t *testing.T) {
+	cache, err := NewShardedCache(ShardedCacheConfig[int, string]{NumShards: 1, MaxEntries: 1})
+	require.NoError(t, err)
+	defer cache.Close()
+
+	cache.SetWithStale(1, "stale", time.Millisecond, time.Hour)
+	time.Sleep(5 * time.Millisecond)
+	cache.GetStale(1) // stale hit
+	cache.Get(1)      // для Get устаревшее значение - miss
+
+	cache.Set(2, "two", time.Minute) // вытесняет 1
+	cache.Get(2)                     // hit
+
+	cache.Set(3, "short", time.Millisecond) // вытесняет 2
+	time.Sleep(5 * time.Millisecond)
+	cache.Get(3) // истёк - miss и expired
+
+	assert.Equal(t, CacheStats{
+		Hits:       1,
+		StaleHits:  1,
+		Misses:     2,
+		Evictions:  2,
+		Expired:    1,
+		Entries:    0,
+		ShardSizes: []int{0},
+	}, cache.Stats())
+	assert.InDelta(t, 0.5, cache.Stats().HitRatio(), 1e-9)
+	assert.Zero(t, CacheStats{}.HitRatio())
+}
+
+func TestShardedCachePurge(t *testing.T) {
+	cache, err := NewInmemoryShardedCache(4, time.Minute)
+	require.NoError(t, err)
+	defer cache.Shutdown()
+
+	for i := 0; i < 10; i++ {
+		cache.AddItemWithTTL(fmt.Sprintf("hh_%d", i), i, time.Minute)
+		cache.AddItemWithTTL(fmt.Sprintf("sj_%d", i), i, time.Minute)
+	}
+
+	removed := cache.DeleteItemsFunc(func(key string, value interface{}) bool {
+		return strings.HasPrefix(key, "hh_")
+	})
+	assert.Equal(t, 10, removed)
+	_, ok := cache.GetItem("hh_1")
+	assert.False(t, ok)
+	_, ok = cache.GetItem("sj_1")
+	assert.True(t, ok)
+
+	assert.Equal(t, 10, cache.Flush())
+	assert.Equal(t, 0, cache.Len())
+
+	// после очистки кэш продолжает работать
+	cache.AddItemWithTTL("key", "value", time.Minute)
+	_, ok = cache.GetItem("key")
+	assert.True(t, ok)
+}
@@ -0,0 +1,110 @@
+package inmemory_cache
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// примерные накладные расходы на элемент кэша: запись в мапе, элемент списка и служебные поля
+const entryOverhead = 128
+
+// EstimateSize - примерная оценка памяти, которую занимает значение вместе со всем, на что оно ссылается
+// учитываются строки, слайсы, мапы, указатели и интерфейсы; каналы и функции считаются по размеру ссылки
+// оценка грубая (без выравнивания и служебных данных рантайма), но растёт вместе с реальным размером значения
+func EstimateSize(value interface{}) int64 {
+	if value == nil {
+		return entryOverhead
+	}
+	v := reflect.ValueOf(value)
+	return entryOverhead + int64(v.Type().Size()) + indirectSize(v, make(map[uintptr]bool))
+}
+
+// функция оценки памяти, на которую ссылается значение (без размера самого значения)
+// seen - уже посчитанные указатели, чтобы не считать общие данные дважды и не зациклиться
+func indirectSize(v reflect.Value, seen map[uintptr]bool) int64 {
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + indirectSize(elem, seen)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + indirectSize(elem, seen)
+
+	case reflect.Slice:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		if hasIndirect(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += indirectSize(v.Index(i), seen)
+			}
+		}
+		return size
+
+	case reflect.Array:
+		var size int64
+		if hasIndirect(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += indirectSize(v.Index(i), seen)
+			}
+		}
+		return size
+
+	case reflect.Map:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		keyType, elemType := v.Type().Key(), v.Type().Elem()
+		size := int64(v.Len()) * int64(keyType.Size()+elemType.Size()+unsafe.Sizeof(uintptr(0)))
+		if hasIndirect(keyType) || hasIndirect(elemType) {
+			iter := v.MapRange()
+			for iter.Next() {
+				size += indirectSize(iter.Key(), seen) + indirectSize(iter.Value(), seen)
+			}
+		}
+		return size
+
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += indirectSize(v.Field(i), seen)
+		}
+		return size
+
+	default:
+		return 0
+	}
+}
+
+// функция проверки, может ли значение типа ссылаться на другую память (если нет - элементы слайсов и мап не обходим)
+func hasIndirect(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	case reflect.Array:
+		return hasIndirect(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasIndirect(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
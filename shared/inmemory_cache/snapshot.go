@@ -0,0 +1,177 @@
+package inmemory_cache
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// типы значений, которые можно сохранять в снимок кэша со значениями-интерфейсами (например, InmemoryShardedCache)
+// значения других типов в снимок не попадают (gob не умеет кодировать незарегистрированные типы за interface{})
+// кэшу с конкретным типом значений регистрация не нужна
+var snapshotTypes sync.Map
+
+// RegisterSnapshotType регистрирует тип значения кэша для сохранения в снимок (передаётся пример значения)
+// регистрировать нужно до сохранения и загрузки снимков, обычно - при старте сервиса
+func RegisterSnapshotType(value interface{}) {
+	gob.Register(value)
+	snapshotTypes.Store(reflect.TypeOf(value), struct{}{})
+}
+
+// версия формата снимка (при несовпадении снимок не загружается)
+const snapshotVersion = 1
+
+// структура файла снимка кэша
+type snapshotFile[K comparable, V any] struct {
+	Version int
+	SavedAt time.Time
+	Items   []snapshotItem[K, V]
+}
+
+// структура элемента в снимке кэша
+type snapshotItem[K comparable, V any] struct {
+	Key        K
+	Value      V
+	FreshUntil time.Time
+	ExpTime    time.Time
+}
+
+// метод включения снимков кэша: снимок сохраняется в файл каждые interval (0 - только при Shutdown)
+func (c *ShardedCache[K, V]) EnableSnapshots(path string, interval time.Duration) {
+	c.snapshotPath = path
+
+	if interval > 0 {
+		go c.snapshotLoop(interval)
+	}
+}
+
+// метод периодического сохранения снимка (до остановки кэша)
+func (c *ShardedCache[K, V]) snapshotLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := c.SaveSnapshot(c.snapshotPath); err != nil {
+				log.Printf("Cache snapshot %s failed: %v", c.snapshotPath, err)
+			}
+		case <-c.snapshotStop:
+			return
+		}
+	}
+}
+
+// метод сохранения снимка кэша в файл: сохраняются неистёкшие элементы вместе с их временем жизни
+// если значения кэша - интерфейсы, сохраняются только значения зарегистрированных типов (RegisterSnapshotType)
+// файл записывается целиком во временный файл и потом подменяется, поэтому прерванное сохранение не портит прошлый снимок
+// возвращает количество сохранённых элементов
+func (c *ShardedCache[K, V]) SaveSnapshot(path string) (int, error) {
+	snapshot := snapshotFile[K, V]{
+		Version: snapshotVersion,
+		SavedAt: time.Now(),
+	}
+
+	interfaceValues := reflect.TypeOf((*V)(nil)).Elem().Kind() == reflect.Interface
+
+	skipped := 0
+	c.rangeEntries(func(entry *cacheEntry[K, V]) {
+		if interfaceValues {
+			if _, ok := snapshotTypes.Load(reflect.TypeOf(entry.value)); !ok {
+				skipped++
+				return
+			}
+		}
+		snapshot.Items = append(snapshot.Items, snapshotItem[K, V]{
+			Key:        entry.key,
+			Value:      entry.value,
+			FreshUntil: entry.freshUntil,
+			ExpTime:    entry.expTime,
+		})
+	})
+
+	if skipped > 0 {
+		log.Printf("Cache snapshot %s: %d items of unregistered types skipped", path, skipped)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("create snapshot dir failed: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, fmt.Errorf("create snapshot file failed: %w", err)
+	}
+
+	if err := gob.NewEncoder(file).Encode(snapshot); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return 0, fmt.Errorf("encode snapshot failed: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return 0, fmt.Errorf("write snapshot failed: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, fmt.Errorf("replace snapshot failed: %w", err)
+	}
+
+	return len(snapshot.Items), nil
+}
+
+// метод загрузки снимка кэша из файла: элементы, которые уже истекли, пропускаются
+// если файла нет - кэш остаётся пустым без ошибки; возвращает количество загруженных элементов
+func (c *ShardedCache[K, V]) LoadSnapshot(path string) (int, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("open snapshot file failed: %w", err)
+	}
+	defer file.Close()
+
+	var snapshot snapshotFile[K, V]
+	if err := gob.NewDecoder(file).Decode(&snapshot); err != nil {
+		return 0, fmt.Errorf("decode snapshot failed: %w", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+
+	now := time.Now()
+	loaded := 0
+	for _, item := range snapshot.Items {
+		if now.After(item.ExpTime) {
+			continue
+		}
+
+		c.setEntry(item.Key, item.Value, item.FreshUntil, item.ExpTime)
+		loaded++
+	}
+
+	return loaded, nil
+}
+
+// метод остановки кэша: останавливает самоочистку и периодические снимки и сохраняет последний снимок (если снимки включены)
+func (c *ShardedCache[K, V]) Shutdown() error {
+	c.snapshotStopOnce.Do(func() {
+		close(c.snapshotStop)
+	})
+	c.Close()
+
+	if c.snapshotPath == "" {
+		return nil
+	}
+
+	_, err := c.SaveSnapshot(c.snapshotPath)
+	return err
+}
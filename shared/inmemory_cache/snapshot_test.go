@@ -0,0 +1,112 @@
+package inmemory_cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// тестовое значение кэша для снимков
+type snapshotValue struct {
+	Name  string
+	Items []int
+}
+
+// незарегистрированный тип значения
+type unregisteredValue struct {
+	Name string
+}
+
+func TestSnapshotSaveAndLoad(t *testing.T) {
+	RegisterSnapshotType(snapshotValue{})
+	path := filepath.Join(t.TempDir(), "snapshots", "cache.gob")
+
+	cache, _ := NewInmemoryShardedCache(4, time.Hour)
+	cache.AddItemWithTTL("fresh", snapshotValue{Name: "fresh", Items: []int{1, 2}}, time.Hour)
+	cache.AddItemWithStaleTTL("stale", snapshotValue{Name: "stale"}, time.Millisecond, time.Hour)
+	cache.AddItemWithTTL("expired", snapshotValue{Name: "expired"}, time.Millisecond)
+	cache.AddItemWithTTL("unregistered", unregisteredValue{Name: "skip"}, time.Hour)
+	time.Sleep(5 * time.Millisecond)
+
+	saved, err := cache.SaveSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, saved, "сохраняются только неистёкшие элементы зарегистрированных типов")
+
+	restored, _ := NewInmemoryShardedCache(4, time.Hour)
+	loaded, err := restored.LoadSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+
+	value, ok := restored.GetItem("fresh")
+	require.True(t, ok)
+	assert.Equal(t, snapshotValue{Name: "fresh", Items: []int{1, 2}}, value)
+
+	// устаревшее значение остаётся устаревшим и после загрузки
+	_, ok = restored.GetItem("stale")
+	assert.False(t, ok)
+	_, fresh, ok := restored.GetStaleItem("stale")
+	assert.True(t, ok)
+	assert.False(t, fresh)
+
+	_, ok = restored.GetItem("unregistered")
+	assert.False(t, ok)
+}
+
+func TestSnapshotMissingAndBrokenFile(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewInmemoryShardedCache(4, time.Hour)
+
+	// первого запуска без снимка - не ошибка
+	loaded, err := cache.LoadSnapshot(filepath.Join(dir, "missing.gob"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, loaded)
+
+	broken := filepath.Join(dir, "broken.gob")
+	require.NoError(t, os.WriteFile(broken, []byte("not a snapshot"), 0o644))
+	_, err = cache.LoadSnapshot(broken)
+	assert.Error(t, err)
+}
+
+func TestShutdownSavesSnapshot(t *testing.T) {
+	RegisterSnapshotType(snapshotValue{})
+	path := filepath.Join(t.TempDir(), "cache.gob")
+
+	cache, _ := NewInmemoryShardedCache(4, time.Hour)
+	cache.EnableSnapshots(path, time.Hour)
+	cache.AddItemWithTTL("key", snapshotValue{Name: "value"}, time.Hour)
+
+	require.NoError(t, cache.Shutdown())
+	require.NoError(t, cache.Shutdown(), "повторная остановка не должна паниковать")
+
+	restored, _ := NewInmemoryShardedCache(4, time.Hour)
+	loaded, err := restored.LoadSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded)
+}
+
+func TestShardedCacheSnapshotTypedValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "typed.gob")
+
+	// кэшу с конкретным типом значений регистрация типа не нужна
+	cache, err := NewShardedCache(ShardedCacheConfig[string, unregisteredValue]{NumShards: 4})
+	require.NoError(t, err)
+	cache.Set("key", unregisteredValue{Name: "typed"}, time.Hour)
+
+	saved, err := cache.SaveSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, saved)
+
+	restored, err := NewShardedCache(ShardedCacheConfig[string, unregisteredValue]{NumShards: 4})
+	require.NoError(t, err)
+	loaded, err := restored.LoadSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded)
+
+	value, ok := restored.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, unregisteredValue{Name: "typed"}, value)
+}
@@ -1,8 +1,14 @@
 package interfaces
 
+import "context"
+
 // Интерфейс с дженериком для FIFO очереди
 type FIFOQueueInterface[T any] interface {
 	Enqueue(item T) bool // потокобезопасен, так как очередь построена на базе каналов
 	Dequeue() (T, bool)
+	EnqueueContext(ctx context.Context, item T) error // ждёт свободного места, пока не отменён контекст или не закрыта очередь
+	DequeueContext(ctx context.Context) (T, error)    // ждёт элемента, пока не отменён контекст или не закрыта (и опустошена) очередь
 	Size() int
+	Close()     // после закрытия добавление невозможно, чтение вернёт оставшиеся элементы
+	Drain() []T // забирает все оставшиеся элементы
 }
@@ -0,0 +1,64 @@
+// общие метрики Prometheus для HTTP серверов сервисов и обработчик /metrics
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// значения метки result
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+var (
+	// количество обработанных HTTP запросов
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Количество обработанных HTTP запросов.",
+	}, []string{"method", "route", "status"})
+
+	// время обработки HTTP запросов
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Время обработки HTTP запросов.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// Handler - хэндлер, который отдаёт метрики в формате Prometheus
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
+
+// HTTPMiddleware - middleware для учёта количества и времени обработки HTTP запросов
+// запросы учитываются по шаблону маршрута (а не по пути), чтобы ID в пути не плодили серии метрик
+func HTTPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Result - значение метки result по ошибке операции
+func Result(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
@@ -49,7 +49,7 @@ func CORSMiddleware() gin.HandlerFunc {
 
 		// Заголовки, которые можно читать клиенту
 		c.Writer.Header().Set("Access-Control-Expose-Headers",
-			"Content-Length, Content-Type, Authorization")
+			"Content-Length, Content-Type, Authorization, Retry-After, X-RateLimit-Limit-Minute, X-RateLimit-Remaining-Minute, X-RateLimit-Limit-Day, X-RateLimit-Remaining-Day")
 
 		// Разрешаем отправку кук/авторизации
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
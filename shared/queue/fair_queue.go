@@ -0,0 +1,288 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// ItemClass - класс элемента честной очереди
+type ItemClass struct {
+	Owner    string // владелец элемента (например, ID пользователя): владельцы одного приоритета обслуживаются по кругу
+	Priority int    // приоритет: чем больше, тем раньше обслуживается
+}
+
+// FairQueueConfig - параметры честной очереди
+type FairQueueConfig struct {
+	Capacity        int // общая ёмкость очереди
+	MaxPerOwner     int // сколько элементов одного владельца может ждать в очереди (0 - без ограничения)
+	StarvationLimit int // после стольких элементов подряд с более высоким приоритетом выдаётся элемент с более низким (0 - строгий приоритет)
+}
+
+// FairQueue - очередь с приоритетами и честным обслуживанием владельцев
+// элементы с большим приоритетом выдаются раньше, внутри одного приоритета владельцы обслуживаются по кругу
+// (по одному элементу за ход), элементы одного владельца выдаются в порядке добавления
+// поэтому владелец, который добавил много элементов, не задерживает остальных дольше, чем на один круг
+type FairQueue[T any] struct {
+	cfg         FairQueueConfig
+	classify    func(item T) ItemClass // определение владельца и приоритета элемента
+	levels      []*fairLevel[T]        // уровни приоритета по убыванию приоритета
+	ownerCounts map[string]int         // сколько элементов каждого владельца ждёт в очереди
+	size        int
+	streak      int           // сколько элементов подряд выдано с верхнего уровня, пока на нижних кто-то ждал
+	closed      bool          // после закрытия добавление невозможно, а чтение вернет оставшиеся элементы
+	changed     chan struct{} // закрывается (и заменяется новым) при каждом изменении очереди - будит заблокированные операции
+	mu          sync.Mutex
+}
+
+// уровень приоритета честной очереди
+type fairLevel[T any] struct {
+	priority int
+	owners   map[string][]T // элементы каждого владельца в порядке добавления
+	ring     []string       // владельцы, у которых есть элементы, в порядке обслуживания
+	next     int            // чей ход в ring
+}
+
+// конструктор для честной очереди
+func NewFairQueue[T any](cfg FairQueueConfig, classify func(item T) ItemClass) *FairQueue[T] {
+	return &FairQueue[T]{
+		cfg:         cfg,
+		classify:    classify,
+		ownerCounts: make(map[string]int),
+		changed:     make(chan struct{}),
+	}
+}
+
+// метод для добавления нового элемента в очередь
+// false - очередь закрыта, переполнена или владелец исчерпал свою долю очереди
+func (q *FairQueue[T]) Enqueue(item T) bool {
+	class := q.classify(item)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return !q.closed && q.enqueueLocked(item, class)
+}
+
+// EnqueueContext добавляет элемент в очередь, дожидаясь свободного места (и свободной доли владельца)
+// возвращает ErrQueueClosed, если очередь закрыта, или ошибку контекста, если место не освободилось
+func (q *FairQueue[T]) EnqueueContext(ctx context.Context, item T) error {
+	class := q.classify(item)
+
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrQueueClosed
+		}
+		if q.enqueueLocked(item, class) {
+			q.mu.Unlock()
+			return nil
+		}
+		wait := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// метод для получения элемента из очереди
+func (q *FairQueue[T]) Dequeue() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.size == 0 {
+		var zeroVal T
+		return zeroVal, false // очередь пуста
+	}
+	return q.dequeueLocked(), true
+}
+
+// DequeueContext получает элемент из очереди, дожидаясь его появления
+// из закрытой очереди сначала выдаются оставшиеся элементы, потом - ErrQueueClosed
+func (q *FairQueue[T]) DequeueContext(ctx context.Context) (T, error) {
+	var zeroVal T
+
+	for {
+		q.mu.Lock()
+		if q.size > 0 {
+			item := q.dequeueLocked()
+			q.mu.Unlock()
+			return item, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return zeroVal, ErrQueueClosed
+		}
+		wait := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return zeroVal, ctx.Err()
+		}
+	}
+}
+
+// метод добавления элемента (вызывается под блокировкой), false - нет места в очереди или в доле владельца
+func (q *FairQueue[T]) enqueueLocked(item T, class ItemClass) bool {
+	if q.size >= q.cfg.Capacity {
+		return false
+	}
+	if q.cfg.MaxPerOwner > 0 && q.ownerCounts[class.Owner] >= q.cfg.MaxPerOwner {
+		return false
+	}
+
+	level := q.level(class.Priority)
+	if len(level.owners[class.Owner]) == 0 {
+		// новый владелец встаёт в конец круга
+		level.ring = append(level.ring, class.Owner)
+	}
+	level.owners[class.Owner] = append(level.owners[class.Owner], item)
+
+	q.ownerCounts[class.Owner]++
+	q.size++
+	q.notifyLocked()
+	return true
+}
+
+// метод выдачи следующего элемента (вызывается под блокировкой, только если очередь не пуста)
+func (q *FairQueue[T]) dequeueLocked() T {
+	var zeroVal T
+
+	level := q.pickLevel()
+	owner := level.ring[level.next]
+	items := level.owners[owner]
+
+	item := items[0]
+	items[0] = zeroVal // не держим ссылку на выданный элемент
+	items = items[1:]
+
+	if len(items) == 0 {
+		// у владельца больше нет элементов - убираем его из круга, ход переходит к следующему
+		delete(level.owners, owner)
+		level.ring = append(level.ring[:level.next], level.ring[level.next+1:]...)
+		if level.next >= len(level.ring) {
+			level.next = 0
+		}
+	} else {
+		level.owners[owner] = items
+		level.next = (level.next + 1) % len(level.ring)
+	}
+
+	q.ownerCounts[owner]--
+	if q.ownerCounts[owner] == 0 {
+		delete(q.ownerCounts, owner)
+	}
+	q.size--
+	q.notifyLocked()
+
+	return item
+}
+
+// метод оповещения заблокированных операций об изменении очереди (вызывается под блокировкой)
+func (q *FairQueue[T]) notifyLocked() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// метод для получения размера очереди в данный момент
+func (q *FairQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.size
+}
+
+// Close закрывает очередь
+// После закрытия добавление элементов невозможно, а чтение вернет оставшиеся элементы
+func (q *FairQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		q.notifyLocked()
+	}
+}
+
+// Drain забирает из очереди все оставшиеся элементы (например, чтобы завершить их с ошибкой после Close)
+func (q *FairQueue[T]) Drain() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	drained := make([]T, 0, q.size)
+	for q.size > 0 {
+		drained = append(drained, q.dequeueLocked())
+	}
+	return drained
+}
+
+// Clear очищает очередь, только если очередь не была закрыта
+func (q *FairQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.levels = nil
+	q.ownerCounts = make(map[string]int)
+	q.size = 0
+	q.streak = 0
+	q.notifyLocked()
+}
+
+// метод получения уровня приоритета (уровень создаётся при первом элементе с таким приоритетом)
+func (q *FairQueue[T]) level(priority int) *fairLevel[T] {
+	pos := 0
+	for ; pos < len(q.levels); pos++ {
+		if q.levels[pos].priority == priority {
+			return q.levels[pos]
+		}
+		if q.levels[pos].priority < priority {
+			break
+		}
+	}
+
+	level := &fairLevel[T]{priority: priority, owners: make(map[string][]T)}
+	q.levels = append(q.levels, nil)
+	copy(q.levels[pos+1:], q.levels[pos:])
+	q.levels[pos] = level
+	return level
+}
+
+// метод выбора уровня, с которого выдаётся следующий элемент (вызывается, только если очередь не пуста)
+// обычно это верхний непустой уровень, но после StarvationLimit элементов подряд ход получает следующий непустой уровень
+func (q *FairQueue[T]) pickLevel() *fairLevel[T] {
+	var top, lower *fairLevel[T]
+	for _, level := range q.levels {
+		if len(level.ring) == 0 {
+			continue
+		}
+		if top == nil {
+			top = level
+			continue
+		}
+		lower = level
+		break
+	}
+
+	if lower == nil {
+		q.streak = 0
+		return top
+	}
+
+	if q.cfg.StarvationLimit > 0 && q.streak >= q.cfg.StarvationLimit {
+		q.streak = 0
+		return lower
+	}
+
+	q.streak++
+	return top
+}
@@ -0,0 +1,263 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// тестовый элемент честной очереди
+type fairItem struct {
+	owner    string
+	priority int
+	n        int
+}
+
+func classifyFairItem(item fairItem) ItemClass {
+	return ItemClass{Owner: item.owner, Priority: item.priority}
+}
+
+func newTestFairQueue(cfg FairQueueConfig) *FairQueue[fairItem] {
+	return NewFairQueue[fairItem](cfg, classifyFairItem)
+}
+
+// функция выдачи всех элементов очереди в виде "владелец:номер"
+func drainFairQueue(q *FairQueue[fairItem]) []string {
+	var order []string
+	for {
+		item, ok := q.Dequeue()
+		if !ok {
+			return order
+		}
+		order = append(order, fmt.Sprintf("%s:%d", item.owner, item.n))
+	}
+}
+
+func assertOrder(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("Неверный порядок. Ожидалось: %v, получено: %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Неверный порядок. Ожидалось: %v, получено: %v", want, got)
+		}
+	}
+}
+
+// TestFairQueueSingleOwnerFIFO проверяет, что для одного владельца очередь работает как FIFO
+func TestFairQueueSingleOwnerFIFO(t *testing.T) {
+	q := newTestFairQueue(FairQueueConfig{Capacity: 10})
+
+	for i := 1; i <= 3; i++ {
+		if !q.Enqueue(fairItem{owner: "a", n: i}) {
+			t.Fatalf("Не удалось добавить элемент %d", i)
+		}
+	}
+
+	assertOrder(t, drainFairQueue(q), []string{"a:1", "a:2", "a:3"})
+
+	if _, ok := q.Dequeue(); ok {
+		t.Error("Извлечение из пустой очереди должно возвращать false")
+	}
+}
+
+// TestFairQueueRoundRobin проверяет, что владельцы одного приоритета обслуживаются по кругу
+func TestFairQueueRoundRobin(t *testing.T) {
+	q := newTestFairQueue(FairQueueConfig{Capacity: 10})
+
+	q.Enqueue(fairItem{owner: "a", n: 1})
+	q.Enqueue(fairItem{owner: "a", n: 2})
+	q.Enqueue(fairItem{owner: "a", n: 3})
+	q.Enqueue(fairItem{owner: "b", n: 1})
+	q.Enqueue(fairItem{owner: "c", n: 1})
+	q.Enqueue(fairItem{owner: "c", n: 2})
+
+	assertOrder(t, drainFairQueue(q), []string{"a:1", "b:1", "c:1", "a:2", "c:2", "a:3"})
+}
+
+// TestFairQueueBoundedWaitForLightUser проверяет, что "лёгкий" пользователь не ждёт,
+// пока обработаются все элементы "тяжёлого" пользователя, который добавил их раньше
+func TestFairQueueBoundedWaitForLightUser(t *testing.T) {
+	const heavyItems = 100
+	q := newTestFairQueue(FairQueueConfig{Capacity: heavyItems + 10})
+
+	for i := 0; i < heavyItems; i++ {
+		q.Enqueue(fairItem{owner: "heavy", n: i})
+	}
+
+	// тяжёлый пользователь уже обслуживается
+	for i := 0; i < 10; i++ {
+		q.Dequeue()
+	}
+
+	q.Enqueue(fairItem{owner: "light", n: 1})
+
+	// при двух активных пользователях лёгкий ждёт не больше одного круга (одного элемента тяжёлого)
+	for waited := 0; waited <= 2; waited++ {
+		item, ok := q.Dequeue()
+		if !ok {
+			t.Fatal("Очередь не должна быть пустой")
+		}
+		if item.owner == "light" {
+			return
+		}
+	}
+	t.Fatal("Лёгкий пользователь ждал дольше одного круга")
+}
+
+// TestFairQueueBoundedWaitManyUsers проверяет, что при многих активных пользователях
+// ожидание ограничено количеством пользователей, а не количеством их элементов
+func TestFairQueueBoundedWaitManyUsers(t *testing.T) {
+	const heavyUsers = 5
+	const itemsPerUser = 50
+	q := newTestFairQueue(FairQueueConfig{Capacity: heavyUsers*itemsPerUser + 1})
+
+	for u := 0; u < heavyUsers; u++ {
+		for i := 0; i < itemsPerUser; i++ {
+			q.Enqueue(fairItem{owner: fmt.Sprintf("heavy%d", u), n: i})
+		}
+	}
+	q.Enqueue(fairItem{owner: "light", n: 1})
+
+	for waited := 0; waited <= heavyUsers; waited++ {
+		item, _ := q.Dequeue()
+		if item.owner == "light" {
+			return
+		}
+	}
+	t.Fatalf("Лёгкий пользователь ждал дольше %d элементов", heavyUsers)
+}
+
+// TestFairQueuePriority проверяет, что элементы с большим приоритетом выдаются раньше
+func TestFairQueuePriority(t *testing.T) {
+	q := newTestFairQueue(FairQueueConfig{Capacity: 10})
+
+	q.Enqueue(fairItem{owner: "user", n: 1})
+	q.Enqueue(fairItem{owner: "user", n: 2})
+	q.Enqueue(fairItem{owner: "premium", priority: 1, n: 1})
+	q.Enqueue(fairItem{owner: "vip", priority: 2, n: 1})
+
+	assertOrder(t, drainFairQueue(q), []string{"vip:1", "premium:1", "user:1", "user:2"})
+}
+
+// TestFairQueueStarvationLimit проверяет, что элементы с низким приоритетом не ждут бесконечно
+func TestFairQueueStarvationLimit(t *testing.T) {
+	q := newTestFairQueue(FairQueueConfig{Capacity: 20, StarvationLimit: 3})
+
+	q.Enqueue(fairItem{owner: "user", n: 1})
+	for i := 1; i <= 6; i++ {
+		q.Enqueue(fairItem{owner: "premium", priority: 1, n: i})
+	}
+
+	assertOrder(t, drainFairQueue(q)[:4], []string{"premium:1", "premium:2", "premium:3", "user:1"})
+}
+
+// TestFairQueueLimits проверяет ограничения общей ёмкости и доли одного владельца
+func TestFairQueueLimits(t *testing.T) {
+	q := newTestFairQueue(FairQueueConfig{Capacity: 3, MaxPerOwner: 2})
+
+	if !q.Enqueue(fairItem{owner: "a", n: 1}) || !q.Enqueue(fairItem{owner: "a", n: 2}) {
+		t.Fatal("Не удалось добавить элементы в пределах доли владельца")
+	}
+	if q.Enqueue(fairItem{owner: "a", n: 3}) {
+		t.Error("Владелец не должен занимать больше своей доли очереди")
+	}
+	if !q.Enqueue(fairItem{owner: "b", n: 1}) {
+		t.Error("Другой владелец должен добавлять элементы")
+	}
+	if q.Enqueue(fairItem{owner: "c", n: 1}) {
+		t.Error("Очередь не должна принимать элементы при переполнении")
+	}
+	if size := q.Size(); size != 3 {
+		t.Errorf("Неверный размер очереди. Ожидалось: 3, получено: %d", size)
+	}
+
+	// после выдачи элемента владелец снова может добавлять
+	q.Dequeue()
+	if !q.Enqueue(fairItem{owner: "a", n: 3}) {
+		t.Error("Владелец должен добавлять элементы после освобождения доли")
+	}
+}
+
+// TestFairQueueClose проверяет закрытие очереди
+func TestFairQueueClose(t *testing.T) {
+	q := newTestFairQueue(FairQueueConfig{Capacity: 3})
+	q.Enqueue(fairItem{owner: "a", n: 1})
+
+	q.Close()
+
+	if q.Enqueue(fairItem{owner: "a", n: 2}) {
+		t.Error("Нельзя добавлять элементы в закрытую очередь")
+	}
+	assertOrder(t, drainFairQueue(q), []string{"a:1"})
+}
+
+// TestFairQueueConcurrentAccess проверяет конкурентный доступ
+func TestFairQueueConcurrentAccess(t *testing.T) {
+	const workers = 10
+	const itemsPerWorker = 100
+	q := newTestFairQueue(FairQueueConfig{Capacity: workers * itemsPerWorker})
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for i := 0; i < itemsPerWorker; i++ {
+				q.Enqueue(fairItem{owner: fmt.Sprint(id % 3), priority: id % 2, n: i})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if got := len(drainFairQueue(q)); got != workers*itemsPerWorker {
+		t.Errorf("Прочитано не все: %d из %d", got, workers*itemsPerWorker)
+	}
+}
+
+// TestFairQueueBlockingOperations проверяет блокирующие операции честной очереди
+func TestFairQueueBlockingOperations(t *testing.T) {
+	q := newTestFairQueue(FairQueueConfig{Capacity: 2, MaxPerOwner: 1})
+
+	// читатель ждёт, пока появится элемент
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		q.Enqueue(fairItem{owner: "a", n: 1})
+	}()
+	item, err := q.DequeueContext(context.Background())
+	if err != nil || item.n != 1 {
+		t.Fatalf("Ожидался элемент a:1, получено: %v, ошибка: %v", item, err)
+	}
+
+	// писатель ждёт, пока освободится доля владельца
+	q.Enqueue(fairItem{owner: "a", n: 2})
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		q.Dequeue()
+	}()
+	if err := q.EnqueueContext(context.Background(), fairItem{owner: "a", n: 3}); err != nil {
+		t.Fatalf("Не удалось добавить элемент после освобождения доли: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := q.EnqueueContext(ctx, fairItem{owner: "a", n: 4}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Ожидалась ошибка контекста, получено: %v", err)
+	}
+
+	// закрытие будит ждущих, оставшиеся элементы забираются через Drain
+	q.Close()
+	if err := q.EnqueueContext(context.Background(), fairItem{owner: "b", n: 1}); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Ожидалась ErrQueueClosed, получено: %v", err)
+	}
+	if drained := q.Drain(); len(drained) != 1 || drained[0].n != 3 {
+		t.Errorf("Ожидался оставшийся элемент a:3, получено: %v", drained)
+	}
+	if _, err := q.DequeueContext(context.Background()); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Ожидалась ErrQueueClosed из пустой закрытой очереди, получено: %v", err)
+	}
+}
@@ -1,10 +1,16 @@
 package queue
 
-import "sync/atomic"
+import (
+	"context"
+	"sync/atomic"
+)
 
 // метод для добавления нового элемента в очередь
 func (q *FIFOQueue[T]) Enqueue(item T) bool {
-	// Атомарная проверка - не блокирует другие горутины
+	// проверка закрытия и добавление - под одной блокировкой, иначе элемент может попасть в уже закрытую очередь
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
 	if atomic.LoadInt32(&q.closed) == 1 {
 		return false
 	}
@@ -17,21 +23,55 @@ func (q *FIFOQueue[T]) Enqueue(item T) bool {
 	}
 }
 
+// EnqueueContext добавляет элемент в очередь, дожидаясь свободного места
+// возвращает ErrQueueClosed, если очередь закрыта, или ошибку контекста, если место не освободилось
+func (q *FIFOQueue[T]) EnqueueContext(ctx context.Context, item T) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if atomic.LoadInt32(&q.closed) == 1 {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.items <- item:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // метод для получения элемента из очереди
 func (q *FIFOQueue[T]) Dequeue() (T, bool) {
 	var zeroVal T
 	select {
-	case item, ok := <-q.items:
-		if ok {
-			return item, true
-		} else {
-			return zeroVal, false
-		}
+	case item := <-q.items:
+		return item, true
 	default:
 		return zeroVal, false // очередь пуста
 	}
 }
 
+// DequeueContext получает элемент из очереди, дожидаясь его появления
+// из закрытой очереди сначала выдаются оставшиеся элементы, потом - ErrQueueClosed
+func (q *FIFOQueue[T]) DequeueContext(ctx context.Context) (T, error) {
+	var zeroVal T
+	select {
+	case item := <-q.items:
+		return item, nil
+	case <-q.done:
+		// очередь закрыта - отдаём то, что в ней осталось
+		if item, ok := q.Dequeue(); ok {
+			return item, nil
+		}
+		return zeroVal, ErrQueueClosed
+	case <-ctx.Done():
+		return zeroVal, ctx.Err()
+	}
+}
+
 // метод для получения размера очереди в данный момент
 func (q *FIFOQueue[T]) Size() int {
 	return len(q.items)
@@ -39,10 +79,27 @@ func (q *FIFOQueue[T]) Size() int {
 
 // Close безопасно закрывает очередь
 // После закрытия добавление элементов невозможно, а чтение вернет оставшиеся элементы
+// канал элементов не закрывается, чтобы добавление, которое ждёт места, не паниковало
+// Close дожидается добавлений, начатых до закрытия, поэтому Drain после Close забирает все добавленные элементы
 func (q *FIFOQueue[T]) Close() {
 	// CAS гарантирует, что закрываем только один раз
 	if atomic.CompareAndSwapInt32(&q.closed, 0, 1) {
-		close(q.items)
+		close(q.done) // будит добавления, которые ждут места
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+}
+
+// Drain забирает из очереди все оставшиеся элементы (например, чтобы завершить их с ошибкой после Close)
+func (q *FIFOQueue[T]) Drain() []T {
+	var drained []T
+	for {
+		item, ok := q.Dequeue()
+		if !ok {
+			return drained
+		}
+		drained = append(drained, item)
 	}
 }
 
@@ -1,9 +1,19 @@
 package queue
 
+import (
+	"errors"
+	"sync"
+)
+
+// ошибка операции с закрытой очередью (для чтения - закрытой и уже пустой)
+var ErrQueueClosed = errors.New("queue is closed")
+
 // структура для очереди
 type FIFOQueue[T any] struct {
 	items  chan T
-	closed int32 // 0 = открыт, 1 = закрыт
+	closed int32         // 0 = открыт, 1 = закрыт
+	done   chan struct{} // закрывается при закрытии очереди - будит заблокированные операции
+	mu     sync.RWMutex  // добавления держат на чтение, Close берёт на запись - чтобы дождаться добавлений, начатых до закрытия
 }
 
 // конструктор для очереди
@@ -11,5 +21,6 @@ func NewFIFOQueue[T any](capacity int) *FIFOQueue[T] {
 	return &FIFOQueue[T]{
 		items:  make(chan T, capacity),
 		closed: 0, // при создании экзмепляра очереди устанавливаем в флаг 0. Канал открыт
+		done:   make(chan struct{}),
 	}
 }
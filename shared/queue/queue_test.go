@@ -1,9 +1,12 @@
 package queue
 
 import (
+	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // TestNewFIFOQueue проверяет создание новой очереди
@@ -301,3 +304,105 @@ func TestStressLimitedCapacity(t *testing.T) {
 		t.Errorf("Прочитано не все: %d из %d", dequeuedCount, totalOperations)
 	}
 }
+
+// TestDequeueContext проверяет блокирующее извлечение: ожидание элемента, отмену контекста и закрытие очереди
+func TestDequeueContext(t *testing.T) {
+	q := NewFIFOQueue[int](3)
+
+	// элемент появляется, пока читатель ждёт
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		q.Enqueue(7)
+	}()
+	val, err := q.DequeueContext(context.Background())
+	if err != nil || val != 7 {
+		t.Errorf("Ожидалось 7, получено: %v, ошибка: %v", val, err)
+	}
+
+	// контекст отменён, пока очередь пуста
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := q.DequeueContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Ожидалась ошибка контекста, получено: %v", err)
+	}
+
+	// закрытие будит читателя, но сначала отдаются оставшиеся элементы
+	q.Enqueue(8)
+	q.Close()
+	if val, err := q.DequeueContext(context.Background()); err != nil || val != 8 {
+		t.Errorf("Ожидалось 8 из закрытой очереди, получено: %v, ошибка: %v", val, err)
+	}
+	if _, err := q.DequeueContext(context.Background()); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Ожидалась ErrQueueClosed, получено: %v", err)
+	}
+}
+
+// TestEnqueueContext проверяет блокирующее добавление: ожидание места и закрытие очереди во время ожидания
+func TestEnqueueContext(t *testing.T) {
+	q := NewFIFOQueue[int](1)
+	q.Enqueue(1)
+
+	// место освобождается, пока писатель ждёт
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		q.Dequeue()
+	}()
+	if err := q.EnqueueContext(context.Background(), 2); err != nil {
+		t.Fatalf("Не удалось добавить элемент после освобождения места: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := q.EnqueueContext(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Ожидалась ошибка контекста, получено: %v", err)
+	}
+
+	// закрытие будит писателя без паники
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		q.Close()
+	}()
+	if err := q.EnqueueContext(context.Background(), 4); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Ожидалась ErrQueueClosed, получено: %v", err)
+	}
+
+	if drained := q.Drain(); len(drained) != 1 || drained[0] != 2 {
+		t.Errorf("Ожидался оставшийся элемент 2, получено: %v", drained)
+	}
+}
+
+// TestCloseWaitsForEnqueue проверяет, что элемент, добавленный одновременно с Close, не теряется: его забирает Drain
+func TestCloseWaitsForEnqueue(t *testing.T) {
+	for round := 0; round < 200; round++ {
+		q := NewFIFOQueue[int](8)
+
+		var added atomic.Int32
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				if i%2 == 0 {
+					if q.Enqueue(i) {
+						added.Add(1)
+					}
+					return
+				}
+				if q.EnqueueContext(context.Background(), i) == nil {
+					added.Add(1)
+				}
+			}(i)
+		}
+
+		close(start)
+		q.Close()
+		drained := q.Drain()
+		wg.Wait()
+
+		if len(drained) != int(added.Load()) {
+			t.Fatalf("Раунд %d: добавлено %d элементов, после Close забрано %d", round, added.Load(), len(drained))
+		}
+	}
+}
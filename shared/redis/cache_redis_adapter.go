@@ -60,3 +60,18 @@ func (r *CacheRedisAdapter) Expire(ctx context.Context, key string, expiration t
 func (r *CacheRedisAdapter) TTL(ctx context.Context, key string) (time.Duration, error) {
 	return r.client.TTL(ctx, key).Result()
 }
+
+// скрипт увеличения счётчика: время жизни ставится только новому счётчику, чтобы окно не сдвигалось при каждом запросе
+var incrScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// метод атомарно увеличивает счётчик в Redis на 1 и возвращает новое значение
+// время жизни устанавливается только при создании счётчика (в одном скрипте с INCR, без гонок между репликами)
+func (r *CacheRedisAdapter) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	return incrScript.Run(ctx, r.client, []string{key}, expiration.Milliseconds()).Int64()
+}
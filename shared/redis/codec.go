@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"encoding/json"
+)
+
+// Codec - кодирование значений кэша типа V в байты для Redis и обратно
+type Codec[V any] interface {
+	Encode(value V) ([]byte, error)
+	Decode(data []byte) (V, error)
+}
+
+// JSONCodec - кодек значений типа T в JSON
+// поля-интерфейсы (например, error) кодируются только пустыми: такие значения в Redis не сохраняются полностью
+type JSONCodec[T any] struct{}
+
+// конструктор для JSON кодека значений типа T
+func NewJSONCodec[T any]() JSONCodec[T] {
+	return JSONCodec[T]{}
+}
+
+// метод кодирования значения
+func (JSONCodec[T]) Encode(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// метод декодирования значения
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"global_models/global_cache"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// длина заголовка значения в Redis: до какого времени (unix nano) значение свежее
+const freshUntilHeaderLen = 8
+
+// ItemCache - кэш значений типа V с TTL поверх Redis (общий для всех реплик сервиса)
+// повторяет интерфейс инмемори кэша (Get, Set, Delete и устаревшие значения),
+// значения кодируются кодеком, ключи - с префиксом, чтобы разные кэши не пересекались в одной базе Redis
+// ошибки Redis не ломают работу сервиса: чтение считается промахом, запись - пропускается (с записью в лог)
+type ItemCache[V any] struct {
+	cache   global_cache.Cache
+	codec   Codec[V]
+	prefix  string
+	timeout time.Duration // таймаут одной операции с Redis
+}
+
+// конструктор для кэша значений поверх Redis
+func NewItemCache[V any](cache global_cache.Cache, codec Codec[V], prefix string, timeout time.Duration) *ItemCache[V] {
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	return &ItemCache[V]{
+		cache:   cache,
+		codec:   codec,
+		prefix:  prefix,
+		timeout: timeout,
+	}
+}
+
+// метод получения свежего значения из кэша по ключу
+func (c *ItemCache[V]) Get(key string) (V, bool) {
+	value, fresh, ok := c.GetStale(key)
+	if !ok || !fresh {
+		var zeroVal V
+		return zeroVal, false
+	}
+	return value, true
+}
+
+// метод получения значения из кэша вместе с устаревшими (у которых истёк ttl, но не staleTTL)
+// fresh - значение ещё свежее, ok - значение найдено
+func (c *ItemCache[V]) GetStale(key string) (value V, fresh bool, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	data, err := c.cache.GetBytes(ctx, c.prefix+key)
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Printf("Redis cache get %s failed: %v", c.prefix+key, err)
+		}
+		return value, false, false
+	}
+	if len(data) < freshUntilHeaderLen {
+		log.Printf("Redis cache get %s failed: value is too short", c.prefix+key)
+		return value, false, false
+	}
+
+	freshUntil := time.Unix(0, int64(binary.BigEndian.Uint64(data[:freshUntilHeaderLen])))
+	value, err = c.codec.Decode(data[freshUntilHeaderLen:])
+	if err != nil {
+		log.Printf("Redis cache decode %s failed: %v", c.prefix+key, err)
+		var zeroVal V
+		return zeroVal, false, false
+	}
+
+	return value, !time.Now().After(freshUntil), true
+}
+
+// метод, чтобы записать значение в кэш с заданным TTL
+func (c *ItemCache[V]) Set(key string, value V, ttl time.Duration) {
+	c.SetWithStale(key, value, ttl, 0)
+}
+
+// метод, чтобы записать значение в кэш, которое после ttl ещё staleTTL хранится как устаревшее
+// Redis удаляет значение сам через ttl + staleTTL
+func (c *ItemCache[V]) SetWithStale(key string, value V, ttl, staleTTL time.Duration) {
+	encoded, err := c.codec.Encode(value)
+	if err != nil {
+		log.Printf("Redis cache encode %s failed: %v", c.prefix+key, err)
+		return
+	}
+
+	data := make([]byte, freshUntilHeaderLen+len(encoded))
+	binary.BigEndian.PutUint64(data[:freshUntilHeaderLen], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(data[freshUntilHeaderLen:], encoded)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.cache.Set(ctx, c.prefix+key, data, ttl+staleTTL); err != nil {
+		log.Printf("Redis cache set %s failed: %v", c.prefix+key, err)
+	}
+}
+
+// метод удаления элемента из кэша по ключу
+func (c *ItemCache[V]) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.cache.Delete(ctx, c.prefix+key); err != nil {
+		log.Printf("Redis cache delete %s failed: %v", c.prefix+key, err)
+	}
+}
@@ -0,0 +1,126 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// тестовое значение кэша
+type cachedValue struct {
+	Name  string
+	Items []int
+}
+
+func TestItemCache(t *testing.T) {
+	_, client := newStubRedis(t)
+	cache := NewItemCache(NewCacheAdapter(client), NewJSONCodec[cachedValue](), "test:", time.Second)
+
+	t.Run("Set and Get", func(t *testing.T) {
+		cache.Set("key", cachedValue{Name: "value", Items: []int{1, 2}}, time.Minute)
+
+		got, ok := cache.Get("key")
+		require.True(t, ok)
+		assert.Equal(t, cachedValue{Name: "value", Items: []int{1, 2}}, got, "значение возвращается того же типа")
+	})
+
+	t.Run("Get non-existent key", func(t *testing.T) {
+		_, ok := cache.Get("missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		cache.Set("deleted", cachedValue{Name: "value"}, time.Minute)
+		cache.Delete("deleted")
+
+		_, ok := cache.Get("deleted")
+		assert.False(t, ok)
+	})
+
+	t.Run("Broken value", func(t *testing.T) {
+		brokenCache := NewItemCache(NewCacheAdapter(client), NewJSONCodec[string](), "test:", time.Second)
+		brokenCache.Set("broken", "not a cachedValue", time.Minute)
+
+		_, ok := cache.Get("broken")
+		assert.False(t, ok, "значение, которое не декодируется, считается промахом")
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		cache.Set("expiring", cachedValue{Name: "value"}, 20*time.Millisecond)
+		time.Sleep(40 * time.Millisecond)
+
+		_, _, ok := cache.GetStale("expiring")
+		assert.False(t, ok)
+	})
+}
+
+func TestItemCacheStale(t *testing.T) {
+	_, client := newStubRedis(t)
+	cache := NewItemCache(NewCacheAdapter(client), NewJSONCodec[cachedValue](), "test:", time.Second)
+
+	cache.SetWithStale("stale", cachedValue{Name: "value"}, 20*time.Millisecond, time.Minute)
+
+	_, fresh, ok := cache.GetStale("stale")
+	require.True(t, ok)
+	assert.True(t, fresh)
+
+	time.Sleep(40 * time.Millisecond)
+
+	// после ttl значение устарело: Get его не отдаёт, GetStale - отдаёт как устаревшее
+	_, ok = cache.Get("stale")
+	assert.False(t, ok)
+
+	got, fresh, ok := cache.GetStale("stale")
+	require.True(t, ok)
+	assert.False(t, fresh)
+	assert.Equal(t, cachedValue{Name: "value"}, got)
+}
+
+func TestItemCacheSharedBetweenInstances(t *testing.T) {
+	_, client := newStubRedis(t)
+
+	// две реплики сервиса с одним Redis видят значения друг друга, а разные кэши (префиксы) - не пересекаются
+	replicaA := NewItemCache(NewCacheAdapter(client), NewJSONCodec[cachedValue](), "search:", time.Second)
+	replicaB := NewItemCache(NewCacheAdapter(client), NewJSONCodec[cachedValue](), "search:", time.Second)
+	otherCache := NewItemCache(NewCacheAdapter(client), NewJSONCodec[cachedValue](), "index:", time.Second)
+
+	replicaA.Set("key", cachedValue{Name: "shared"}, time.Minute)
+
+	got, ok := replicaB.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, cachedValue{Name: "shared"}, got)
+
+	_, ok = otherCache.Get("key")
+	assert.False(t, ok)
+}
+
+func TestItemCacheRedisUnavailable(t *testing.T) {
+	stub, client := newStubRedis(t)
+	cache := NewItemCache(NewCacheAdapter(client), NewJSONCodec[cachedValue](), "test:", 100*time.Millisecond)
+	stub.listener.Close()
+	client.Close()
+
+	// недоступный Redis - промах кэша, а не паника или зависание
+	cache.Set("key", cachedValue{Name: "value"}, time.Minute)
+	_, ok := cache.Get("key")
+	assert.False(t, ok)
+}
+
+func TestCacheAdapterIncr(t *testing.T) {
+	_, client := newStubRedis(t)
+	adapter := NewCacheAdapter(client)
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		count, err := adapter.Incr(ctx, "counter", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, want, count)
+	}
+
+	ttl, err := adapter.TTL(ctx, "counter")
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0), "новому счётчику ставится время жизни")
+}
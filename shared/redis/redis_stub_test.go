@@ -0,0 +1,196 @@
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// stubRedis - Redis для тестов внутри процесса: понимает протокол RESP и команды, которыми пользуется адаптер кэша
+// (GET, SET с EX/PX, DEL, EXISTS, EXPIRE, PEXPIRE, TTL и скрипт счётчика через EVALSHA/EVAL)
+type stubRedis struct {
+	listener net.Listener
+	items    map[string]stubItem
+	mu       sync.Mutex
+}
+
+// значение в тестовом Redis
+type stubItem struct {
+	value     []byte
+	expiresAt time.Time // нулевое время - без времени жизни
+}
+
+// функция запуска тестового Redis и клиента к нему (оба останавливаются в конце теста)
+func newStubRedis(t *testing.T) (*stubRedis, *redis.Client) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("не удалось запустить тестовый Redis: %v", err)
+	}
+
+	stub := &stubRedis{listener: listener, items: make(map[string]stubItem)}
+	go stub.serve()
+
+	client := redis.NewClient(&redis.Options{Addr: listener.Addr().String(), MaxRetries: -1})
+	t.Cleanup(func() {
+		client.Close()
+		listener.Close()
+	})
+	return stub, client
+}
+
+func (s *stubRedis) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *stubRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write([]byte(s.exec(args))); err != nil {
+			return
+		}
+	}
+}
+
+// функция чтения команды RESP (массив bulk строк)
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command %q", line)
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+
+	args := make([]string, count)
+	for i := range args {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+// метод получения живого значения (вызывается под блокировкой)
+func (s *stubRedis) getLocked(key string) (stubItem, bool) {
+	item, ok := s.items[key]
+	if ok && !item.expiresAt.IsZero() && !time.Now().Before(item.expiresAt) {
+		delete(s.items, key)
+		return stubItem{}, false
+	}
+	return item, ok
+}
+
+func (s *stubRedis) exec(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		item, ok := s.getLocked(args[1])
+		if !ok {
+			return "$-1\r\n"
+		}
+		return bulk(string(item.value))
+	case "SET":
+		item := stubItem{value: []byte(args[2])}
+		if len(args) >= 5 {
+			n, _ := strconv.Atoi(args[4])
+			unit := time.Second
+			if strings.EqualFold(args[3], "PX") {
+				unit = time.Millisecond
+			}
+			item.expiresAt = time.Now().Add(time.Duration(n) * unit)
+		}
+		s.items[args[1]] = item
+		return "+OK\r\n"
+	case "DEL", "EXISTS":
+		count := 0
+		for _, key := range args[1:] {
+			if _, ok := s.getLocked(key); ok {
+				count++
+				if strings.EqualFold(args[0], "DEL") {
+					delete(s.items, key)
+				}
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", count)
+	case "EXPIRE", "PEXPIRE":
+		item, ok := s.getLocked(args[1])
+		if !ok {
+			return ":0\r\n"
+		}
+		n, _ := strconv.Atoi(args[2])
+		unit := time.Second
+		if strings.EqualFold(args[0], "PEXPIRE") {
+			unit = time.Millisecond
+		}
+		item.expiresAt = time.Now().Add(time.Duration(n) * unit)
+		s.items[args[1]] = item
+		return ":1\r\n"
+	case "TTL":
+		item, ok := s.getLocked(args[1])
+		switch {
+		case !ok:
+			return ":-2\r\n"
+		case item.expiresAt.IsZero():
+			return ":-1\r\n"
+		}
+		return fmt.Sprintf(":%d\r\n", int(time.Until(item.expiresAt).Seconds()))
+	case "EVALSHA":
+		// скрипты не кэшируются - клиент повторит через EVAL
+		return "-NOSCRIPT No matching script. Please use EVAL.\r\n"
+	case "EVAL":
+		if !strings.Contains(args[1], "INCR") {
+			return "-ERR unsupported script\r\n"
+		}
+		// скрипт счётчика: INCR, новому счётчику - PEXPIRE
+		key := args[3]
+		item, _ := s.getLocked(key)
+		count, _ := strconv.Atoi(string(item.value))
+		count++
+		item.value = []byte(strconv.Itoa(count))
+		if count == 1 {
+			ms, _ := strconv.Atoi(args[4])
+			item.expiresAt = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		s.items[key] = item
+		return fmt.Sprintf(":%d\r\n", count)
+	}
+	return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+}
+
+func bulk(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}